@@ -17,6 +17,7 @@ package backend
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/rpc"
@@ -31,6 +32,14 @@ var (
 	ticketsReleased    = stats.Int64("open-match.dev/backend/tickets_released", "Number of tickets released per request", stats.UnitDimensionless)
 	ticketsAssigned    = stats.Int64("open-match.dev/backend/tickets_assigned", "Number of tickets assigned per request", stats.UnitDimensionless)
 
+	mmfEndpointCalls     = stats.Int64("open-match.dev/backend/mmf_endpoint_calls", "Number of calls to each match function endpoint", stats.UnitDimensionless)
+	mmfEndpointEjections = stats.Int64("open-match.dev/backend/mmf_endpoint_ejections", "Number of times each match function endpoint was ejected", stats.UnitDimensionless)
+	invalidProposals     = stats.Int64("open-match.dev/backend/invalid_proposals", "Number of invalid match function proposals", stats.UnitDimensionless)
+
+	endpointTag = tag.MustNewKey("endpoint")
+	resultTag   = tag.MustNewKey("result")
+	reasonTag   = tag.MustNewKey("reason")
+
 	totalMatchesView = &view.View{
 		Measure:     totalBytesPerMatch,
 		Name:        "open-match.dev/backend/total_matches",
@@ -61,15 +70,39 @@ var (
 		Description: "Number of tickets released per request",
 		Aggregation: view.Sum(),
 	}
+	mmfEndpointCallsView = &view.View{
+		Measure:     mmfEndpointCalls,
+		Name:        "open-match.dev/backend/mmf_endpoint_calls",
+		Description: "Number of calls to each match function endpoint, by result",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{endpointTag, resultTag},
+	}
+	mmfEndpointEjectionsView = &view.View{
+		Measure:     mmfEndpointEjections,
+		Name:        "open-match.dev/backend/mmf_endpoint_ejections",
+		Description: "Number of times each match function endpoint was ejected",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{endpointTag},
+	}
+	invalidProposalsView = &view.View{
+		Measure:     invalidProposals,
+		Name:        "open-match.dev/backend/invalid_proposals",
+		Description: "Number of invalid match function proposals, by reason",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{reasonTag},
+	}
 )
 
 // BindService creates the backend service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	cc := rpc.NewClientCache(p.Config())
 	service := &backendService{
+		cfg:          p.Config(),
 		synchronizer: newSynchronizerClient(p.Config()),
 		store:        statestore.New(p.Config()),
-		cc:           rpc.NewClientCache(p.Config()),
+		mmfs:         newMmfBalancer(p.Config(), cc),
 	}
+	b.AddCloser(service.mmfs.start())
 
 	b.AddHealthCheckFunc(service.store.HealthCheck)
 	b.AddHandleFunc(func(s *grpc.Server) {
@@ -81,6 +114,9 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		ticketsPerMatchView,
 		ticketsAssignedView,
 		ticketsReleasedView,
+		mmfEndpointCallsView,
+		mmfEndpointEjectionsView,
+		invalidProposalsView,
 	)
 	return nil
 }
@@ -22,11 +22,13 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
@@ -34,6 +36,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/appmain/contextcause"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
@@ -43,9 +46,10 @@ import (
 // The service implementing the Backend API that is called to generate matches
 // and make assignments for Tickets.
 type backendService struct {
+	cfg          config.View
 	synchronizer *synchronizerClient
 	store        statestore.Service
-	cc           *rpc.ClientCache
+	mmfs         *mmfBalancer
 }
 
 var (
@@ -53,6 +57,13 @@ var (
 		"app":       "openmatch",
 		"component": "app.backend",
 	})
+
+	// errMmfTimedOut cancels the match function when it runs longer than the
+	// request's function_timeout.
+	errMmfTimedOut = errors.New("match function ran longer than function_timeout, canceling")
+	// errProposalWindowClosed cancels the match function when the
+	// synchronization cycle stops accepting proposals before it finishes.
+	errProposalWindowClosed = errors.New("match function ran longer than proposal window, canceling")
 )
 
 // FetchMatches triggers a MatchFunction with the specified MatchProfiles, while each MatchProfile
@@ -60,18 +71,123 @@ var (
 // FetchMatches immediately returns an error if it encounters any execution failures.
 //   - If the synchronizer is enabled, FetchMatch will then call the synchronizer to deduplicate proposals with overlapped tickets.
 func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.BackendService_FetchMatchesServer) error {
-	if req.Config == nil {
-		return status.Error(codes.InvalidArgument, ".config is required")
+	run, err := s.newMmfRun(req)
+	if err != nil {
+		return err
 	}
-	if req.Profile == nil {
-		return status.Error(codes.InvalidArgument, ".profile is required")
+	if req.DryRun {
+		return s.fetchMatchesDryRun(run, stream)
+	}
+
+	mmfErrs, syncErr := s.synchronize(stream.Context(), []*mmfRun{run}, func(index int, match *pb.Match, reservationToken string) error {
+		return stream.Send(&pb.FetchMatchesResponse{Match: match, ReservationToken: reservationToken})
+	})
+	mmfErr := mmfErrs[0]
+
+	// TODO: Send mmf error in FetchSummary instead of erroring call.
+	if syncErr != nil || mmfErr != nil {
+		logger.WithFields(logrus.Fields{
+			"syncErr": syncErr,
+			"mmfErr":  mmfErr,
+		}).Error("error(s) in FetchMatches call.")
+
+		code := codes.Unknown
+		if syncErr == nil {
+			code = mmfErrorCode(mmfErr)
+		}
+		return status.Errorf(
+			code,
+			"error(s) in FetchMatches call. syncErr=[%s], mmfErr=[%s]",
+			syncErr,
+			mmfErr,
+		)
+	}
+
+	return nil
+}
+
+// FetchMatchesBatch triggers the MatchFunction of each request in a single
+// synchronization cycle, streaming the matches back tagged with the request
+// they were made for.  A MatchFunction failing is sent as a status for its
+// request rather than failing the call.
+func (s *backendService) FetchMatchesBatch(req *pb.FetchMatchesBatchRequest, stream pb.BackendService_FetchMatchesBatchServer) error {
+	if len(req.GetRequests()) == 0 {
+		return status.Error(codes.InvalidArgument, ".requests is required")
+	}
+
+	runs := make([]*mmfRun, 0, len(req.GetRequests()))
+	for i, r := range req.GetRequests() {
+		if r.GetDryRun() {
+			return status.Errorf(codes.InvalidArgument, ".requests[%d]: .dry_run is not supported in a batch", i)
+		}
+		run, err := s.newMmfRun(r)
+		if err != nil {
+			st := status.Convert(err)
+			return status.Errorf(st.Code(), ".requests[%d]: %s", i, st.Message())
+		}
+		runs = append(runs, run)
+	}
+
+	mmfErrs, syncErr := s.synchronize(stream.Context(), runs, func(index int, match *pb.Match, reservationToken string) error {
+		return stream.Send(&pb.FetchMatchesBatchResponse{
+			Index:            int32(index),
+			ProfileName:      runs[index].profile.GetName(),
+			Match:            match,
+			ReservationToken: reservationToken,
+		})
+	})
+	if syncErr != nil {
+		logger.WithFields(logrus.Fields{
+			"syncErr": syncErr,
+		}).Error("error in FetchMatchesBatch call.")
+		return status.Errorf(codes.Unknown, "error in FetchMatchesBatch call. syncErr=[%s]", syncErr)
 	}
 
+	for i, mmfErr := range mmfErrs {
+		if mmfErr == nil {
+			continue
+		}
+		logger.WithFields(logrus.Fields{
+			"mmfErr":  mmfErr,
+			"profile": runs[i].profile.GetName(),
+		}).Error("match function failed in FetchMatchesBatch call.")
+
+		err := stream.Send(&pb.FetchMatchesBatchResponse{
+			Index:       int32(i),
+			ProfileName: runs[i].profile.GetName(),
+			Status:      status.New(mmfErrorCode(mmfErr), mmfErr.Error()).Proto(),
+		})
+		if err != nil {
+			return fmt.Errorf("error sending match function failure to caller of backend: %w", err)
+		}
+	}
+	return nil
+}
+
+// mmfErrorCode returns the code a match function's failure is reported with.
+// The match function being canceled has its own code, so that callers can tell
+// it apart from the match function failing.
+func mmfErrorCode(err error) codes.Code {
+	switch err {
+	case errMmfTimedOut:
+		return codes.DeadlineExceeded
+	case errProposalWindowClosed:
+		return codes.Aborted
+	default:
+		return codes.Unknown
+	}
+}
+
+// synchronize registers with a synchronization cycle, runs every match
+// function once the cycle starts, and passes each match the evaluator accepts
+// to send, along with the index of the run which proposed it.  It returns the
+// error of each run, and the error of the synchronizer calls.
+func (s *backendService) synchronize(ctx context.Context, runs []*mmfRun, send func(index int, match *pb.Match, reservationToken string) error) ([]error, error) {
 	// Error group for handling the synchronizer calls only.
-	eg, ctx := errgroup.WithContext(stream.Context())
+	eg, ctx := errgroup.WithContext(ctx)
 	syncStream, err := s.synchronizer.synchronize(ctx)
 	if err != nil {
-		return err
+		return make([]error, len(runs)), err
 	}
 
 	// The mmf must be canceled if the synchronizer call fails (which will
@@ -80,44 +196,197 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	mmfCtx, cancelMmfs := contextcause.WithCancelCause(ctx)
 	// Closed when mmfs should start.
 	startMmfs := make(chan struct{})
-	proposals := make(chan *pb.Match)
+	proposals := make(chan *proposal)
 	m := &sync.Map{}
 
 	eg.Go(func() error {
 		return synchronizeSend(ctx, syncStream, m, proposals)
 	})
 	eg.Go(func() error {
-		return synchronizeRecv(ctx, syncStream, m, stream, startMmfs, cancelMmfs)
+		return synchronizeRecv(ctx, syncStream, m, send, startMmfs, cancelMmfs)
 	})
 
-	var mmfErr error
+	mmfErrs := make([]error, len(runs))
 	select {
 	case <-mmfCtx.Done():
-		mmfErr = fmt.Errorf("mmf was never started")
+		for i := range mmfErrs {
+			mmfErrs[i] = fmt.Errorf("mmf was never started")
+		}
 	case <-startMmfs:
-		mmfErr = callMmf(mmfCtx, s.cc, req, proposals)
+		var wg sync.WaitGroup
+		for i, run := range runs {
+			wg.Add(1)
+			go func(i int, run *mmfRun) {
+				defer wg.Done()
+				mmfErrs[i] = run.run(mmfCtx, s.mmfs, i, proposals)
+			}(i, run)
+		}
+		wg.Wait()
+		close(proposals)
 	}
 
-	syncErr := eg.Wait()
+	return mmfErrs, eg.Wait()
+}
 
-	// TODO: Send mmf error in FetchSummary instead of erroring call.
-	if syncErr != nil || mmfErr != nil {
-		logger.WithFields(logrus.Fields{
-			"syncErr": syncErr,
-			"mmfErr":  mmfErr,
-		}).Error("error(s) in FetchMatches call.")
+// newMmfRun validates the request, returning the match function call it
+// makes.
+func (s *backendService) newMmfRun(req *pb.FetchMatchesRequest) (*mmfRun, error) {
+	mmf, err := getMatchFunction(s.cfg, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Profile == nil {
+		return nil, status.Error(codes.InvalidArgument, ".profile is required")
+	}
+	timeout, err := getFunctionTimeout(req)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := newProposalValidator(s.cfg, s.store, req.GetProfile())
+	if err != nil {
+		return nil, err
+	}
+	return &mmfRun{
+		mmf:       mmf,
+		profile:   req.GetProfile(),
+		timeout:   timeout,
+		validator: validator,
+	}, nil
+}
 
-		return fmt.Errorf(
-			"error(s) in FetchMatches call. syncErr=[%s], mmfErr=[%s]",
-			syncErr,
-			mmfErr,
-		)
+// getFunctionTimeout returns the request's function_timeout, or 0 if it has
+// none.
+func getFunctionTimeout(req *pb.FetchMatchesRequest) (time.Duration, error) {
+	if req.GetFunctionTimeout() == nil {
+		return 0, nil
+	}
+	timeout, err := ptypes.Duration(req.GetFunctionTimeout())
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid .function_timeout: %v", err)
 	}
+	if timeout <= 0 {
+		return 0, status.Error(codes.InvalidArgument, ".function_timeout must be positive")
+	}
+	return timeout, nil
+}
 
+// fetchMatchesDryRun runs the MMF and evaluates its proposals, returning each
+// proposal with the evaluator's decision.  Unlike FetchMatches, it doesn't
+// join a synchronization cycle, so the proposals don't compete with other
+// calls' proposals, and tickets aren't moved to pending.
+func (s *backendService) fetchMatchesDryRun(run *mmfRun, stream pb.BackendService_FetchMatchesServer) error {
+	eg, ctx := errgroup.WithContext(stream.Context())
+	proposals := make(chan *proposal)
+	var matches []*pb.Match
+
+	eg.Go(func() error {
+		defer close(proposals)
+		return run.run(ctx, s.mmfs, 0, proposals)
+	})
+	eg.Go(func() error {
+		seen := make(map[string]struct{})
+		for p := range proposals {
+			if _, ok := seen[p.match.GetMatchId()]; ok {
+				return fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.match.GetMatchId())
+			}
+			seen[p.match.GetMatchId()] = struct{}{}
+			matches = append(matches, p.match)
+		}
+		return nil
+	})
+	// The proposals returned before the match function timed out are still
+	// evaluated.
+	mmfErr := eg.Wait()
+	if mmfErr != nil && mmfErr != errMmfTimedOut {
+		return fmt.Errorf("error(s) in FetchMatches dry run call. mmfErr=[%s]", mmfErr)
+	}
+
+	acceptedIDs, err := s.evaluateDryRun(stream.Context(), run.profile.GetName(), matches)
+	if err != nil {
+		return err
+	}
+
+	// Maps the ids of tickets and backfills in accepted matches to the match
+	// id.
+	acceptedTickets := make(map[string]string)
+	acceptedBackfills := make(map[string]string)
+	for _, match := range matches {
+		if _, ok := acceptedIDs[match.GetMatchId()]; ok {
+			for _, t := range match.GetTickets() {
+				acceptedTickets[t.GetId()] = match.GetMatchId()
+			}
+			if id := match.GetBackfill().GetId(); id != "" {
+				acceptedBackfills[id] = match.GetMatchId()
+			}
+		}
+	}
+
+	for _, match := range matches {
+		err = stream.Send(&pb.FetchMatchesResponse{
+			Match:        match,
+			DryRunResult: dryRunResult(match, acceptedIDs, acceptedTickets, acceptedBackfills),
+		})
+		if err != nil {
+			return fmt.Errorf("error sending match to caller of backend: %w", err)
+		}
+	}
+	if mmfErr != nil {
+		return status.Errorf(codes.DeadlineExceeded, "error(s) in FetchMatches dry run call. mmfErr=[%s]", mmfErr)
+	}
 	return nil
 }
 
-func synchronizeSend(ctx context.Context, syncStream synchronizerStream, m *sync.Map, proposals <-chan *pb.Match) error {
+// evaluateDryRun sends the matches to the synchronizer to be evaluated,
+// returning the ids of the accepted matches.
+func (s *backendService) evaluateDryRun(ctx context.Context, profileName string, matches []*pb.Match) (map[string]struct{}, error) {
+	syncStream, err := s.synchronizer.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, match := range matches {
+		err = syncStream.Send(&ipb.SynchronizeRequest{Proposal: match, ProfileName: profileName})
+		if err != nil {
+			return nil, fmt.Errorf("error sending proposal to synchronizer: %w", err)
+		}
+	}
+	err = syncStream.CloseSend()
+	if err != nil {
+		return nil, fmt.Errorf("error closing send stream of proposals to synchronizer: %w", err)
+	}
+
+	acceptedIDs := make(map[string]struct{})
+	for {
+		resp, err := syncStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error receiving match from synchronizer: %w", err)
+		}
+		acceptedIDs[resp.GetMatchId()] = struct{}{}
+	}
+	return acceptedIDs, nil
+}
+
+// dryRunResult returns the evaluator's decision on the match, given the ids
+// of the accepted matches, and the accepted matches by ticket and backfill id.
+func dryRunResult(match *pb.Match, acceptedIDs map[string]struct{}, acceptedTickets, acceptedBackfills map[string]string) *pb.DryRunResult {
+	if _, ok := acceptedIDs[match.GetMatchId()]; ok {
+		return &pb.DryRunResult{Accepted: true}
+	}
+	for _, t := range match.GetTickets() {
+		if mID, ok := acceptedTickets[t.GetId()]; ok {
+			return &pb.DryRunResult{CollidedMatchId: mID}
+		}
+	}
+	if mID, ok := acceptedBackfills[match.GetBackfill().GetId()]; ok {
+		return &pb.DryRunResult{CollidedMatchId: mID}
+	}
+	return &pb.DryRunResult{}
+}
+
+func synchronizeSend(ctx context.Context, syncStream synchronizerStream, m *sync.Map, proposals <-chan *proposal) error {
 sendProposals:
 	for {
 		select {
@@ -127,11 +396,11 @@ sendProposals:
 			if !ok {
 				break sendProposals
 			}
-			_, loaded := m.LoadOrStore(p.GetMatchId(), p)
+			_, loaded := m.LoadOrStore(p.match.GetMatchId(), p)
 			if loaded {
-				return fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.GetMatchId())
+				return fmt.Errorf("MatchMakingFunction returned same match_id twice: \"%s\"", p.match.GetMatchId())
 			}
-			err := syncStream.Send(&ipb.SynchronizeRequest{Proposal: p})
+			err := syncStream.Send(&ipb.SynchronizeRequest{Proposal: p.match, ProfileName: p.profileName})
 			if err != nil {
 				return fmt.Errorf("error sending proposal to synchronizer: %w", err)
 			}
@@ -145,7 +414,7 @@ sendProposals:
 	return nil
 }
 
-func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, stream pb.BackendService_FetchMatchesServer, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc) error {
+func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, send func(index int, match *pb.Match, reservationToken string) error, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc) error {
 	var startMmfsOnce sync.Once
 
 	for {
@@ -164,17 +433,24 @@ func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync
 		}
 
 		if resp.CancelMmfs {
-			cancelMmfs(errors.New("match function ran longer than proposal window, canceling"))
+			cancelMmfs(errProposalWindowClosed)
 		}
 
 		if v, ok := m.Load(resp.GetMatchId()); ok {
-			match, ok := v.(*pb.Match)
+			p, ok := v.(*proposal)
 			if !ok {
-				return fmt.Errorf("error casting sync map value into *pb.Match: %w", err)
+				return fmt.Errorf("error casting sync map value into *proposal: %w", err)
+			}
+			match := p.match
+			if resp.GetBackfill() != nil {
+				// Return the backfill as it was written, with its new id or
+				// generation.
+				match = proto.Clone(match).(*pb.Match)
+				match.Backfill = resp.GetBackfill()
 			}
 			stats.Record(ctx, totalBytesPerMatch.M(int64(proto.Size(match))))
 			stats.Record(ctx, ticketsPerMatch.M(int64(len(match.GetTickets()))))
-			err = stream.Send(&pb.FetchMatchesResponse{Match: match})
+			err = send(p.index, match, resp.GetReservationToken())
 			if err != nil {
 				return fmt.Errorf("error sending match to caller of backend: %w", err)
 			}
@@ -182,22 +458,94 @@ func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync
 	}
 }
 
-// callMmf triggers execution of MMFs to fetch match proposals.
-func callMmf(ctx context.Context, cc *rpc.ClientCache, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
-	defer close(proposals)
-	address := fmt.Sprintf("%s:%d", req.GetConfig().GetHost(), req.GetConfig().GetPort())
+// proposal is a match function's proposal, along with the run which made it.
+type proposal struct {
+	index       int
+	profileName string
+	match       *pb.Match
+}
 
-	switch req.GetConfig().GetType() {
-	case pb.FunctionConfig_GRPC:
-		return callGrpcMmf(ctx, cc, req.GetProfile(), address, proposals)
-	case pb.FunctionConfig_REST:
-		return callHTTPMmf(ctx, cc, req.GetProfile(), address, proposals)
-	default:
-		return status.Error(codes.InvalidArgument, "provided match function type is not supported")
+// mmfRun is a call to a match function for a profile.
+type mmfRun struct {
+	mmf     *matchFunction
+	profile *pb.MatchProfile
+	// timeout is how long the match function may run for, or 0 if there is no
+	// limit.
+	timeout   time.Duration
+	validator *proposalValidator
+}
+
+// run calls the match function, sending its valid proposals to out with the
+// given index.  When the match function fails because it ran longer than the
+// timeout, errMmfTimedOut is returned, and when ctx was canceled by
+// errProposalWindowClosed, that is returned.
+func (r *mmfRun) run(ctx context.Context, mmfs *mmfBalancer, index int, out chan<- *proposal) error {
+	mmfCtx, cancel := contextcause.WithCancelCause(ctx)
+	defer cancel(nil)
+	if r.timeout > 0 {
+		timer := time.AfterFunc(r.timeout, func() {
+			cancel(errMmfTimedOut)
+		})
+		defer timer.Stop()
+	}
+
+	matches := make(chan *pb.Match)
+	forwarded := make(chan error, 1)
+	go func() {
+		forwarded <- r.forward(ctx, cancel, index, matches, out)
+	}()
+
+	err := callMmf(mmfCtx, mmfs, r.mmf, r.profile, matches)
+	if forwardErr := <-forwarded; forwardErr != nil {
+		return forwardErr
+	}
+	if err != nil {
+		if mmfCtx.Err() == errMmfTimedOut {
+			return errMmfTimedOut
+		}
+		if ctx.Err() == errProposalWindowClosed {
+			return errProposalWindowClosed
+		}
 	}
+	return err
 }
 
-func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) error {
+// forward validates the match function's proposals, sending the valid ones to
+// out.  If a proposal fails the call, the match function is canceled and the
+// error is returned.
+func (r *mmfRun) forward(ctx context.Context, cancel contextcause.CancelErrFunc, index int, matches <-chan *pb.Match, out chan<- *proposal) error {
+	var err error
+	for match := range matches {
+		if err != nil {
+			continue
+		}
+		var valid bool
+		valid, err = r.validator.check(ctx, match)
+		if err != nil {
+			cancel(err)
+			continue
+		}
+		if !valid {
+			continue
+		}
+		select {
+		case out <- &proposal{index: index, profileName: r.profile.GetName(), match: match}:
+		case <-ctx.Done():
+		}
+	}
+	return err
+}
+
+// callMmf triggers execution of MMFs to fetch match proposals.
+func callMmf(ctx context.Context, mmfs *mmfBalancer, mmf *matchFunction, profile *pb.MatchProfile, proposals chan<- *pb.Match) error {
+	defer close(proposals)
+	return mmfs.run(ctx, mmf, profile, proposals)
+}
+
+// callGrpcMmf calls a gRPC match function endpoint, returning whether any
+// proposals were streamed.
+func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) (bool, error) {
+	streamed := false
 	var conn *grpc.ClientConn
 	conn, err := cc.GetGRPC(address)
 	if err != nil {
@@ -205,7 +553,7 @@ func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 			"error":    err.Error(),
 			"function": address,
 		}).Error("failed to establish grpc client connection to match function")
-		return status.Error(codes.InvalidArgument, "failed to connect to match function")
+		return streamed, status.Error(codes.InvalidArgument, "failed to connect to match function")
 	}
 	client := pb.NewMatchFunctionClient(conn)
 
@@ -214,9 +562,9 @@ func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 		logger.WithError(err).Error("failed to run match function for profile")
 		if ctx.Err() != nil {
 			// gRPC likes to suppress the context's error, so stop that.
-			return ctx.Err()
+			return streamed, ctx.Err()
 		}
-		return err
+		return streamed, err
 	}
 
 	for {
@@ -228,44 +576,48 @@ func callGrpcMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 			logger.Errorf("%v.Run() error, %v\n", client, err)
 			if ctx.Err() != nil {
 				// gRPC likes to suppress the context's error, so stop that.
-				return ctx.Err()
+				return streamed, ctx.Err()
 			}
-			return err
+			return streamed, err
 		}
 		select {
 		case proposals <- resp.GetProposal():
+			streamed = true
 		case <-ctx.Done():
-			return ctx.Err()
+			return streamed, ctx.Err()
 		}
 	}
 
-	return nil
+	return streamed, nil
 }
 
-func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) error {
+// callHTTPMmf calls a REST match function endpoint, returning whether any
+// proposals were streamed.
+func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) (bool, error) {
+	streamed := false
 	client, baseURL, err := cc.GetHTTP(address)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error":    err.Error(),
 			"function": address,
 		}).Error("failed to establish rest client connection to match function")
-		return status.Error(codes.InvalidArgument, "failed to connect to match function")
+		return streamed, status.Error(codes.InvalidArgument, "failed to connect to match function")
 	}
 
 	var m jsonpb.Marshaler
 	strReq, err := m.MarshalToString(&pb.RunRequest{Profile: profile})
 	if err != nil {
-		return status.Errorf(codes.FailedPrecondition, "failed to marshal profile pb to string for profile %s: %s", profile.GetName(), err.Error())
+		return streamed, status.Errorf(codes.FailedPrecondition, "failed to marshal profile pb to string for profile %s: %s", profile.GetName(), err.Error())
 	}
 
 	req, err := http.NewRequest("POST", baseURL+"/v1/matchfunction:run", strings.NewReader(strReq))
 	if err != nil {
-		return status.Errorf(codes.FailedPrecondition, "failed to create mmf http request for profile %s: %s", profile.GetName(), err.Error())
+		return streamed, status.Errorf(codes.FailedPrecondition, "failed to create mmf http request for profile %s: %s", profile.GetName(), err.Error())
 	}
 
 	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to get response from mmf run for profile %s: %s", profile.Name, err.Error())
+		return streamed, status.Errorf(codes.Unavailable, "failed to get response from mmf run for profile %s: %s", profile.Name, err.Error())
 	}
 	defer func() {
 		err = resp.Body.Close()
@@ -286,23 +638,24 @@ func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 			break
 		}
 		if err != nil {
-			return status.Errorf(codes.Unavailable, "failed to read response from HTTP JSON stream: %s", err.Error())
+			return streamed, status.Errorf(codes.Unavailable, "failed to read response from HTTP JSON stream: %s", err.Error())
 		}
 		if len(item.Error) != 0 {
-			return status.Errorf(codes.Unavailable, "failed to execute matchfunction.Run: %v", item.Error)
+			return streamed, status.Errorf(codes.Internal, "failed to execute matchfunction.Run: %v", item.Error)
 		}
 		resp := &pb.RunResponse{}
 		if err := jsonpb.UnmarshalString(string(item.Result), resp); err != nil {
-			return status.Errorf(codes.Unavailable, "failed to execute json.Unmarshal(%s, &resp): %v", item.Result, err)
+			return streamed, status.Errorf(codes.Unavailable, "failed to execute json.Unmarshal(%s, &resp): %v", item.Result, err)
 		}
 		select {
 		case proposals <- resp.GetProposal():
+			streamed = true
 		case <-ctx.Done():
-			return ctx.Err()
+			return streamed, ctx.Err()
 		}
 	}
 
-	return nil
+	return streamed, nil
 }
 
 func (s *backendService) ReleaseTickets(ctx context.Context, req *pb.ReleaseTicketsRequest) (*pb.ReleaseTicketsResponse, error) {
@@ -324,6 +677,21 @@ func (s *backendService) ReleaseAllTickets(ctx context.Context, req *pb.ReleaseA
 	return &pb.ReleaseAllTicketsResponse{}, nil
 }
 
+// ReleaseMatches moves the tickets of matches returned by FetchMatches from the
+// pending state back to the active state, by their reservation tokens.
+func (s *backendService) ReleaseMatches(ctx context.Context, req *pb.ReleaseMatchesRequest) (*pb.ReleaseMatchesResponse, error) {
+	ids, err := s.store.ReleaseMatches(ctx, req.GetReservationTokens())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"reservation_tokens": req.GetReservationTokens(),
+		}).WithError(err).Error("failed to release the tickets of the requested matches")
+		return nil, err
+	}
+
+	stats.Record(ctx, ticketsReleased.M(int64(len(ids))))
+	return &pb.ReleaseMatchesResponse{}, nil
+}
+
 // AssignTickets overwrites the Assignment field of the input TicketIds.
 func (s *backendService) AssignTickets(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
 	resp, err := doAssignTickets(ctx, req, s.store)
@@ -348,10 +716,23 @@ func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store st
 		return nil, err
 	}
 
+	// Tickets whose reservation was lost may belong to another match, so they
+	// are left as they are.
+	lost := make(map[string]struct{})
+	for _, f := range resp.Failures {
+		if f.Cause == pb.AssignmentFailure_RESERVATION_LOST {
+			lost[f.TicketId] = struct{}{}
+		}
+	}
+
 	ids := []string{}
 
 	for _, ag := range req.Assignments {
-		ids = append(ids, ag.TicketIds...)
+		for _, id := range ag.TicketIds {
+			if _, ok := lost[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
 	}
 
 	for _, id := range ids {
@@ -0,0 +1,132 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// matchFunctionsConfigName is the registry of named match functions.  Each
+	// match function is configured under matchFunctions.<name> with hostname,
+	// grpcport, httpport and type, which is either "grpc" (the default) or
+	// "rest".  A match function with several endpoints lists their hostnames
+	// under hostnames instead, and sets resolve to use every address each
+	// hostname resolves to, such as for a headless Kubernetes service.
+	matchFunctionsConfigName = "matchFunctions"
+	// requireNamedMatchFunctionsConfigName, when true, rejects FetchMatches
+	// calls which give the match function's address instead of its name, so
+	// that only match functions in the registry can be called.
+	requireNamedMatchFunctionsConfigName = "requireNamedMatchFunctions"
+)
+
+// matchFunction is a match function server, which may have several endpoints.
+type matchFunction struct {
+	typ       pb.FunctionConfig_Type
+	hostnames []string
+	// resolve is set if each hostname is looked up, and each of its addresses
+	// is an endpoint.
+	resolve bool
+	// port is the port the match function is called on.
+	port int
+	// healthPort is the http port serving the match function's health checks,
+	// or 0 if it is not known.
+	healthPort int
+}
+
+// getMatchFunction returns the match function the request calls, looking up
+// named match functions in the registry.
+func getMatchFunction(cfg config.View, req *pb.FetchMatchesRequest) (*matchFunction, error) {
+	name := req.GetFunctionName()
+	if name == "" {
+		if req.GetConfig() == nil {
+			return nil, status.Error(codes.InvalidArgument, ".config or .function_name is required")
+		}
+		if cfg.GetBool(requireNamedMatchFunctionsConfigName) {
+			return nil, status.Error(codes.InvalidArgument, ".config is not allowed, match functions must be called by .function_name")
+		}
+		mf := &matchFunction{
+			typ:       req.GetConfig().GetType(),
+			hostnames: []string{req.GetConfig().GetHost()},
+			port:      int(req.GetConfig().GetPort()),
+		}
+		if mf.typ == pb.FunctionConfig_REST {
+			mf.healthPort = mf.port
+		}
+		return mf, nil
+	}
+	if req.GetConfig() != nil {
+		return nil, status.Error(codes.InvalidArgument, "only one of .config and .function_name may be set")
+	}
+
+	key := matchFunctionsConfigName + "." + name
+	if strings.Contains(name, ".") || !(cfg.IsSet(key+".hostname") || cfg.IsSet(key+".hostnames")) {
+		return nil, status.Errorf(codes.NotFound, "match function %q is not configured", name)
+	}
+
+	mf := &matchFunction{
+		resolve:    cfg.GetBool(key + ".resolve"),
+		healthPort: cfg.GetInt(key + ".httpport"),
+	}
+	if cfg.IsSet(key + ".hostname") {
+		mf.hostnames = append(mf.hostnames, cfg.GetString(key+".hostname"))
+	}
+	mf.hostnames = append(mf.hostnames, cfg.GetStringSlice(key+".hostnames")...)
+
+	switch t := strings.ToLower(cfg.GetString(key + ".type")); t {
+	case "", "grpc":
+		mf.typ = pb.FunctionConfig_GRPC
+		mf.port = cfg.GetInt(key + ".grpcport")
+	case "rest":
+		mf.typ = pb.FunctionConfig_REST
+		mf.port = mf.healthPort
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "match function %q has unsupported type %q, must be grpc or rest", name, t)
+	}
+	return mf, nil
+}
+
+// endpoints returns the addresses of the match function's endpoints.
+func (mf *matchFunction) endpoints(ctx context.Context) ([]mmfEndpoint, error) {
+	var endpoints []mmfEndpoint
+	for _, hostname := range mf.hostnames {
+		hosts := []string{hostname}
+		if mf.resolve {
+			var err error
+			hosts, err = net.DefaultResolver.LookupHost(ctx, hostname)
+			if err != nil {
+				return nil, status.Errorf(codes.Unavailable, "failed to resolve match function hostname %s: %v", hostname, err)
+			}
+		}
+		for _, host := range hosts {
+			e := mmfEndpoint{
+				address: net.JoinHostPort(host, strconv.Itoa(mf.port)),
+			}
+			if mf.healthPort != 0 {
+				e.healthAddress = net.JoinHostPort(host, strconv.Itoa(mf.healthPort))
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints, nil
+}
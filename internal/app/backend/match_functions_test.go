@@ -0,0 +1,115 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestGetMatchFunction(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("matchFunctions.grpcmmf.hostname", "grpc-host")
+	cfg.Set("matchFunctions.grpcmmf.grpcport", "50502")
+	cfg.Set("matchFunctions.restmmf.hostname", "rest-host")
+	cfg.Set("matchFunctions.restmmf.httpport", 51502)
+	cfg.Set("matchFunctions.restmmf.type", "REST")
+	cfg.Set("matchFunctions.othermmf.hostname", "other-host")
+	cfg.Set("matchFunctions.othermmf.type", "other")
+	cfg.Set("matchFunctions.resolvedmmf.hostnames", []string{"a", "b"})
+	cfg.Set("matchFunctions.resolvedmmf.grpcport", 50502)
+	cfg.Set("matchFunctions.resolvedmmf.httpport", 51502)
+	cfg.Set("matchFunctions.resolvedmmf.resolve", true)
+
+	address := &pb.FunctionConfig{Host: "address", Port: 1}
+
+	for _, tt := range []struct {
+		name string
+		req  *pb.FetchMatchesRequest
+		want *matchFunction
+		code codes.Code
+	}{
+		{
+			name: "address",
+			req:  &pb.FetchMatchesRequest{Config: address},
+			want: &matchFunction{typ: pb.FunctionConfig_GRPC, hostnames: []string{"address"}, port: 1},
+		},
+		{
+			name: "grpc",
+			req:  &pb.FetchMatchesRequest{FunctionName: "grpcmmf"},
+			want: &matchFunction{typ: pb.FunctionConfig_GRPC, hostnames: []string{"grpc-host"}, port: 50502},
+		},
+		{
+			name: "rest",
+			req:  &pb.FetchMatchesRequest{FunctionName: "restmmf"},
+			want: &matchFunction{typ: pb.FunctionConfig_REST, hostnames: []string{"rest-host"}, port: 51502, healthPort: 51502},
+		},
+		{
+			name: "resolved",
+			req:  &pb.FetchMatchesRequest{FunctionName: "resolvedmmf"},
+			want: &matchFunction{typ: pb.FunctionConfig_GRPC, hostnames: []string{"a", "b"}, resolve: true, port: 50502, healthPort: 51502},
+		},
+		{
+			name: "missing",
+			req:  &pb.FetchMatchesRequest{},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "both",
+			req:  &pb.FetchMatchesRequest{Config: address, FunctionName: "grpcmmf"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown",
+			req:  &pb.FetchMatchesRequest{FunctionName: "unknown"},
+			code: codes.NotFound,
+		},
+		{
+			name: "nested",
+			req:  &pb.FetchMatchesRequest{FunctionName: "grpcmmf.hostname"},
+			code: codes.NotFound,
+		},
+		{
+			name: "unsupported type",
+			req:  &pb.FetchMatchesRequest{FunctionName: "othermmf"},
+			code: codes.FailedPrecondition,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getMatchFunction(cfg, tt.req)
+			require.Equal(t, tt.code, status.Code(err))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetMatchFunctionRequireNamed(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("requireNamedMatchFunctions", true)
+	cfg.Set("matchFunctions.mmf.hostname", "host")
+
+	_, err := getMatchFunction(cfg, &pb.FetchMatchesRequest{Config: &pb.FunctionConfig{Host: "address"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	got, err := getMatchFunction(cfg, &pb.FetchMatchesRequest{FunctionName: "mmf"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"host"}, got.hostnames)
+}
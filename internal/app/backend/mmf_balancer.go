@@ -0,0 +1,305 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/tag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// mmfEndpoint is a single server of a match function.
+type mmfEndpoint struct {
+	address string
+	// healthAddress is the http address of the endpoint's health checks, or
+	// empty if it is not known.
+	healthAddress string
+}
+
+// mmfEndpointState is what the balancer knows about an endpoint.
+type mmfEndpointState struct {
+	healthAddress string
+	// ejectedAt is when the endpoint last failed a call or health check, or
+	// zero if it is healthy.
+	ejectedAt time.Time
+	// lastUsed is when the endpoint was last returned by its match function,
+	// so that endpoints which no longer exist stop being health checked.
+	lastUsed time.Time
+}
+
+// mmfBalancer spreads match function calls across the match function's
+// endpoints.  Calls which fail as Unavailable before any proposal has been
+// streamed are retried on another endpoint, and the failing endpoint is
+// ejected: it is only used when no other endpoint is healthy, until it passes
+// a health check or the ejection time passes.
+type mmfBalancer struct {
+	cfg config.View
+	cc  *rpc.ClientCache
+	// call makes a single call to an endpoint, returning whether any proposals
+	// were streamed.
+	call func(ctx context.Context, cc *rpc.ClientCache, typ pb.FunctionConfig_Type, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) (bool, error)
+	// check health checks an endpoint.
+	check func(ctx context.Context, cc *rpc.ClientCache, healthAddress string) error
+
+	m         sync.Mutex
+	next      int
+	endpoints map[string]*mmfEndpointState
+}
+
+func newMmfBalancer(cfg config.View, cc *rpc.ClientCache) *mmfBalancer {
+	return &mmfBalancer{
+		cfg:       cfg,
+		cc:        cc,
+		call:      callMmfEndpoint,
+		check:     checkMmfEndpoint,
+		endpoints: make(map[string]*mmfEndpointState),
+	}
+}
+
+func getMatchFunctionMaxAttempts(cfg config.View) int {
+	const (
+		name       = "matchFunctionMaxAttempts"
+		defaultVal = 3
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultVal
+	}
+	return cfg.GetInt(name)
+}
+
+func getMatchFunctionEjectionTime(cfg config.View) time.Duration {
+	const (
+		name       = "matchFunctionEjectionTime"
+		defaultVal = 30 * time.Second
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultVal
+	}
+	return cfg.GetDuration(name)
+}
+
+func getMatchFunctionHealthCheckInterval(cfg config.View) time.Duration {
+	const (
+		name       = "matchFunctionHealthCheckInterval"
+		defaultVal = 10 * time.Second
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultVal
+	}
+	return cfg.GetDuration(name)
+}
+
+// run calls the match function, retrying on other endpoints while they are
+// unavailable.
+func (b *mmfBalancer) run(ctx context.Context, mf *matchFunction, profile *pb.MatchProfile, proposals chan<- *pb.Match) error {
+	endpoints, err := mf.endpoints(ctx)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return status.Error(codes.FailedPrecondition, "match function has no endpoints")
+	}
+
+	maxAttempts := getMatchFunctionMaxAttempts(b.cfg)
+	for attempt, e := range b.order(endpoints) {
+		var streamed bool
+		streamed, err = b.call(ctx, b.cc, mf.typ, profile, e.address, proposals)
+		if err == nil {
+			recordMmfEndpointCall(ctx, e.address, "ok")
+			return nil
+		}
+		if streamed || ctx.Err() != nil || status.Code(err) != codes.Unavailable {
+			recordMmfEndpointCall(ctx, e.address, "error")
+			return err
+		}
+		recordMmfEndpointCall(ctx, e.address, "unavailable")
+		b.eject(ctx, e.address)
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"function": e.address,
+		}).Warning("match function endpoint unavailable, ejecting endpoint")
+
+		if attempt+1 >= maxAttempts {
+			break
+		}
+	}
+	return err
+}
+
+// order returns the endpoints in the order they should be tried: healthy
+// endpoints first, rotating which comes first on each call, followed by the
+// ejected endpoints.
+func (b *mmfBalancer) order(endpoints []mmfEndpoint) []mmfEndpoint {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	now := time.Now()
+	ejectionTime := getMatchFunctionEjectionTime(b.cfg)
+	start := b.next % len(endpoints)
+	b.next++
+
+	var healthy, ejected []mmfEndpoint
+	for i := range endpoints {
+		e := endpoints[(start+i)%len(endpoints)]
+		s, ok := b.endpoints[e.address]
+		if !ok {
+			s = &mmfEndpointState{}
+			b.endpoints[e.address] = s
+		}
+		s.healthAddress = e.healthAddress
+		s.lastUsed = now
+
+		if !s.ejectedAt.IsZero() && now.Sub(s.ejectedAt) < ejectionTime {
+			ejected = append(ejected, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+	return append(healthy, ejected...)
+}
+
+func (b *mmfBalancer) eject(ctx context.Context, address string) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if s, ok := b.endpoints[address]; ok {
+		s.ejectedAt = time.Now()
+	}
+	telemetry.RecordUnitMeasurement(ctx, mmfEndpointEjections, tag.Upsert(endpointTag, address))
+}
+
+// healthCheck health checks every endpoint used recently which serves health
+// checks, ejecting the failing endpoints and restoring the passing ones.
+func (b *mmfBalancer) healthCheck(ctx context.Context) {
+	interval := getMatchFunctionHealthCheckInterval(b.cfg)
+	// Endpoints which haven't been used for a while may no longer exist.
+	staleTime := 2 * getMatchFunctionEjectionTime(b.cfg)
+	if staleTime < 2*interval {
+		staleTime = 2 * interval
+	}
+
+	b.m.Lock()
+	now := time.Now()
+	healthAddresses := make(map[string]string)
+	for address, s := range b.endpoints {
+		if now.Sub(s.lastUsed) > staleTime {
+			delete(b.endpoints, address)
+			continue
+		}
+		if s.healthAddress != "" {
+			healthAddresses[address] = s.healthAddress
+		}
+	}
+	b.m.Unlock()
+
+	var wg sync.WaitGroup
+	for address, healthAddress := range healthAddresses {
+		wg.Add(1)
+		go func(address, healthAddress string) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			defer cancel()
+			err := b.check(checkCtx, b.cc, healthAddress)
+
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"error":    err.Error(),
+					"function": address,
+				}).Warning("match function endpoint failed health check")
+				b.eject(ctx, address)
+				return
+			}
+
+			b.m.Lock()
+			defer b.m.Unlock()
+			if s, ok := b.endpoints[address]; ok {
+				s.ejectedAt = time.Time{}
+			}
+		}(address, healthAddress)
+	}
+	wg.Wait()
+}
+
+// start health checks the endpoints until the returned func is called.
+func (b *mmfBalancer) start() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(getMatchFunctionHealthCheckInterval(b.cfg)):
+				b.healthCheck(ctx)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// callMmfEndpoint makes a single call to a match function endpoint.
+func callMmfEndpoint(ctx context.Context, cc *rpc.ClientCache, typ pb.FunctionConfig_Type, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) (bool, error) {
+	switch typ {
+	case pb.FunctionConfig_GRPC:
+		return callGrpcMmf(ctx, cc, profile, address, proposals)
+	case pb.FunctionConfig_REST:
+		return callHTTPMmf(ctx, cc, profile, address, proposals)
+	default:
+		return false, status.Error(codes.InvalidArgument, "provided match function type is not supported")
+	}
+}
+
+// checkMmfEndpoint calls the health check of a match function endpoint.
+func checkMmfEndpoint(ctx context.Context, cc *rpc.ClientCache, healthAddress string) error {
+	client, baseURL, err := cc.GetHTTP(healthAddress)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("GET", baseURL+telemetry.HealthCheckEndpoint+"?readiness=true", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
+
+func recordMmfEndpointCall(ctx context.Context, address, result string) {
+	telemetry.RecordUnitMeasurement(ctx, mmfEndpointCalls, tag.Upsert(endpointTag, address), tag.Upsert(resultTag, result))
+}
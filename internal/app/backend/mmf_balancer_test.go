@@ -0,0 +1,165 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/rpc"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+var testMatchFunction = &matchFunction{
+	typ:        pb.FunctionConfig_GRPC,
+	hostnames:  []string{"a", "b", "c"},
+	port:       1,
+	healthPort: 2,
+}
+
+func TestMmfBalancerRoundRobin(t *testing.T) {
+	b, calls := newFakeMmfBalancer(viper.New(), nil)
+	ctx := utilTesting.NewContext(t)
+
+	for i := 0; i < 4; i++ {
+		require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	}
+	require.Equal(t, []string{"a:1", "b:1", "c:1", "a:1"}, calls.get())
+}
+
+func TestMmfBalancerRetriesUnavailable(t *testing.T) {
+	b, calls := newFakeMmfBalancer(viper.New(), map[string]fakeMmfResult{
+		"a:1": {err: status.Error(codes.Unavailable, "down")},
+	})
+	ctx := utilTesting.NewContext(t)
+
+	require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	require.Equal(t, []string{"a:1", "b:1"}, calls.get())
+
+	// The ejected endpoint is skipped, even when it's its turn.
+	for i := 0; i < 3; i++ {
+		require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	}
+	require.Equal(t, []string{"b:1", "c:1", "b:1"}, calls.get())
+}
+
+func TestMmfBalancerMaxAttempts(t *testing.T) {
+	unavailable := fakeMmfResult{err: status.Error(codes.Unavailable, "down")}
+	cfg := viper.New()
+	cfg.Set("matchFunctionMaxAttempts", 2)
+	b, calls := newFakeMmfBalancer(cfg, map[string]fakeMmfResult{
+		"a:1": unavailable,
+		"b:1": unavailable,
+		"c:1": unavailable,
+	})
+	ctx := utilTesting.NewContext(t)
+
+	err := b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, []string{"a:1", "b:1"}, calls.get())
+}
+
+func TestMmfBalancerDoesntRetry(t *testing.T) {
+	for _, result := range []fakeMmfResult{
+		{err: status.Error(codes.Unavailable, "down"), streamed: true},
+		{err: status.Error(codes.Internal, "failed")},
+	} {
+		b, calls := newFakeMmfBalancer(viper.New(), map[string]fakeMmfResult{
+			"a:1": result,
+		})
+		ctx := utilTesting.NewContext(t)
+
+		err := b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil)
+		require.Equal(t, result.err, err)
+		require.Equal(t, []string{"a:1"}, calls.get())
+	}
+}
+
+func TestMmfBalancerHealthCheck(t *testing.T) {
+	b, calls := newFakeMmfBalancer(viper.New(), nil)
+	ctx := utilTesting.NewContext(t)
+
+	require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	require.Equal(t, []string{"a:1"}, calls.get())
+
+	healthy := map[string]bool{"a:2": true, "b:2": false, "c:2": true}
+	checked := &sync.Map{}
+	b.check = func(ctx context.Context, cc *rpc.ClientCache, healthAddress string) error {
+		checked.Store(healthAddress, true)
+		if healthy[healthAddress] {
+			return nil
+		}
+		return errors.New("unhealthy")
+	}
+
+	b.healthCheck(ctx)
+	for address := range healthy {
+		_, ok := checked.Load(address)
+		require.True(t, ok, address)
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	}
+	require.Equal(t, []string{"c:1", "c:1", "a:1"}, calls.get())
+
+	// Endpoints which pass a health check are restored.
+	healthy["b:2"] = true
+	b.healthCheck(ctx)
+	require.Nil(t, b.run(ctx, testMatchFunction, &pb.MatchProfile{}, nil))
+	require.Equal(t, []string{"b:1"}, calls.get())
+}
+
+type fakeMmfResult struct {
+	streamed bool
+	err      error
+}
+
+type fakeMmfCalls struct {
+	m         sync.Mutex
+	addresses []string
+}
+
+// get returns the addresses called since the last get.
+func (c *fakeMmfCalls) get() []string {
+	c.m.Lock()
+	defer c.m.Unlock()
+	addresses := c.addresses
+	c.addresses = nil
+	return addresses
+}
+
+func newFakeMmfBalancer(cfg *viper.Viper, results map[string]fakeMmfResult) (*mmfBalancer, *fakeMmfCalls) {
+	calls := &fakeMmfCalls{}
+	b := newMmfBalancer(cfg, nil)
+	b.call = func(ctx context.Context, cc *rpc.ClientCache, typ pb.FunctionConfig_Type, profile *pb.MatchProfile, address string, proposals chan<- *pb.Match) (bool, error) {
+		calls.m.Lock()
+		defer calls.m.Unlock()
+		calls.addresses = append(calls.addresses, address)
+		r := results[address]
+		return r.streamed, r.err
+	}
+	b.check = func(ctx context.Context, cc *rpc.ClientCache, healthAddress string) error {
+		return nil
+	}
+	return b, calls
+}
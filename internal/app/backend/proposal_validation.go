@@ -0,0 +1,181 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/tag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// proposalValidationConfigName sets what happens to invalid match function
+	// proposals: "off" (the default) doesn't check them, "warn" logs them and
+	// still evaluates them, "drop" leaves them out of evaluation, and "fail"
+	// fails the FetchMatches call.  Every mode but off runs the same checks,
+	// looking up the proposals' tickets in state storage.
+	proposalValidationConfigName = "proposalValidation"
+
+	proposalValidationOff  = "off"
+	proposalValidationWarn = "warn"
+	proposalValidationDrop = "drop"
+	proposalValidationFail = "fail"
+)
+
+// Reasons a proposal is invalid, used as the reason tag of invalidProposals.
+const (
+	invalidProposalEmptyMatchID     = "empty_match_id"
+	invalidProposalDuplicateTicket  = "duplicate_ticket"
+	invalidProposalTicketNotFound   = "ticket_not_found"
+	invalidProposalTicketNotInPools = "ticket_not_in_pools"
+)
+
+// proposalValidator checks the proposals a match function returns for a
+// profile.
+type proposalValidator struct {
+	store statestore.Service
+	mode  string
+	// pools are the filters of the profile's pools.  Proposals for profiles
+	// without pools aren't checked against them.
+	pools []*filter.PoolFilter
+}
+
+func newProposalValidator(cfg config.View, store statestore.Service, profile *pb.MatchProfile) (*proposalValidator, error) {
+	mode, err := getProposalValidation(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &proposalValidator{
+		store: store,
+		mode:  mode,
+	}
+	for _, pool := range profile.GetPools() {
+		pf, err := filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+		v.pools = append(v.pools, pf)
+	}
+	return v, nil
+}
+
+func getProposalValidation(cfg config.View) (string, error) {
+	const defaultVal = proposalValidationOff
+
+	if !cfg.IsSet(proposalValidationConfigName) {
+		return defaultVal, nil
+	}
+	switch mode := strings.ToLower(cfg.GetString(proposalValidationConfigName)); mode {
+	case proposalValidationOff, proposalValidationWarn, proposalValidationDrop, proposalValidationFail:
+		return mode, nil
+	default:
+		return "", status.Errorf(codes.FailedPrecondition, "%s has unsupported value %q, must be off, warn, drop or fail", proposalValidationConfigName, mode)
+	}
+}
+
+// check validates the proposal, returning whether it should be evaluated, or
+// an error if the FetchMatches call should fail.
+func (v *proposalValidator) check(ctx context.Context, p *pb.Match) (bool, error) {
+	if v.mode == proposalValidationOff {
+		return true, nil
+	}
+
+	reason, detail, err := v.validate(ctx, p)
+	if err != nil {
+		return false, err
+	}
+	if reason == "" {
+		return true, nil
+	}
+
+	telemetry.RecordUnitMeasurement(ctx, invalidProposals, tag.Upsert(reasonTag, reason))
+	logger.WithFields(logrus.Fields{
+		"matchID": p.GetMatchId(),
+		"reason":  reason,
+		"mode":    v.mode,
+	}).Warningf("match function returned invalid proposal: %s", detail)
+
+	switch v.mode {
+	case proposalValidationDrop:
+		return false, nil
+	case proposalValidationFail:
+		return false, fmt.Errorf("MatchMakingFunction returned invalid proposal \"%s\": %s", p.GetMatchId(), detail)
+	default:
+		return true, nil
+	}
+}
+
+// validate returns the reason the proposal is invalid along with a
+// description, or an empty reason if it is valid.
+func (v *proposalValidator) validate(ctx context.Context, p *pb.Match) (string, string, error) {
+	if p.GetMatchId() == "" {
+		return invalidProposalEmptyMatchID, "match_id is empty", nil
+	}
+
+	ids := make([]string, 0, len(p.GetTickets()))
+	seen := make(map[string]struct{}, len(p.GetTickets()))
+	for _, t := range p.GetTickets() {
+		if _, ok := seen[t.GetId()]; ok {
+			return invalidProposalDuplicateTicket, fmt.Sprintf("ticket %s is in the match more than once", t.GetId()), nil
+		}
+		seen[t.GetId()] = struct{}{}
+		ids = append(ids, t.GetId())
+	}
+	if len(ids) == 0 {
+		return "", "", nil
+	}
+
+	tickets, err := v.store.GetTickets(ctx, ids)
+	if err != nil {
+		return "", "", err
+	}
+	found := make(map[string]*pb.Ticket, len(tickets))
+	for _, t := range tickets {
+		found[t.GetId()] = t
+	}
+
+	for _, id := range ids {
+		t, ok := found[id]
+		if !ok {
+			return invalidProposalTicketNotFound, fmt.Sprintf("ticket %s was not found", id), nil
+		}
+		// The stored ticket is checked rather than the proposal's copy, which
+		// the match function may have changed.
+		if len(v.pools) > 0 && !v.inPools(t) {
+			return invalidProposalTicketNotInPools, fmt.Sprintf("ticket %s is not in any of the profile's pools", id), nil
+		}
+	}
+	return "", "", nil
+}
+
+func (v *proposalValidator) inPools(t *pb.Ticket) bool {
+	for _, pf := range v.pools {
+		if pf.In(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestProposalValidatorValidate(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+
+	inPool := &pb.Ticket{
+		Id:           "in-pool",
+		SearchFields: &pb.SearchFields{Tags: []string{"mode.demo"}},
+	}
+	outOfPool := &pb.Ticket{Id: "out-of-pool"}
+	for _, ticket := range []*pb.Ticket{inPool, outOfPool} {
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+	}
+
+	profile := &pb.MatchProfile{
+		Pools: []*pb.Pool{{
+			TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.demo"}},
+		}},
+	}
+
+	tests := []struct {
+		description string
+		mode        string
+		profile     *pb.MatchProfile
+		match       *pb.Match
+		reason      string
+	}{
+		{
+			description: "valid",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{inPool}},
+		},
+		{
+			description: "no tickets",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1"},
+		},
+		{
+			description: "empty match id",
+			profile:     profile,
+			match:       &pb.Match{Tickets: []*pb.Ticket{inPool}},
+			reason:      invalidProposalEmptyMatchID,
+		},
+		{
+			description: "duplicate ticket",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{inPool, inPool}},
+			reason:      invalidProposalDuplicateTicket,
+		},
+		{
+			description: "ticket not found",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{inPool, {Id: "missing"}}},
+			reason:      invalidProposalTicketNotFound,
+		},
+		{
+			description: "ticket not in pools",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{outOfPool}},
+			reason:      invalidProposalTicketNotInPools,
+		},
+		{
+			description: "stored ticket is checked against pools",
+			profile:     profile,
+			match: &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{{
+				Id:           outOfPool.Id,
+				SearchFields: inPool.SearchFields,
+			}}},
+			reason: invalidProposalTicketNotInPools,
+		},
+		{
+			description: "profile without pools",
+			profile:     &pb.MatchProfile{},
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{outOfPool}},
+		},
+		{
+			description: "warn checks duplicate tickets",
+			mode:        "warn",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{inPool, inPool}},
+			reason:      invalidProposalDuplicateTicket,
+		},
+		{
+			description: "warn checks tickets are found",
+			mode:        "warn",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{inPool, {Id: "missing"}}},
+			reason:      invalidProposalTicketNotFound,
+		},
+		{
+			description: "warn checks tickets are in pools",
+			mode:        "warn",
+			profile:     profile,
+			match:       &pb.Match{MatchId: "1", Tickets: []*pb.Ticket{outOfPool}},
+			reason:      invalidProposalTicketNotInPools,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			cfg := viper.New()
+			cfg.Set("proposalValidation", "drop")
+			if test.mode != "" {
+				cfg.Set("proposalValidation", test.mode)
+			}
+			v, err := newProposalValidator(cfg, store, test.profile)
+			require.Nil(t, err)
+
+			reason, _, err := v.validate(ctx, test.match)
+			require.Nil(t, err)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func TestProposalValidatorModes(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+
+	invalid := &pb.Match{Tickets: []*pb.Ticket{{Id: "missing"}}}
+
+	tests := []struct {
+		mode     string
+		evaluate bool
+		fails    bool
+	}{
+		{mode: "", evaluate: true},
+		{mode: "off", evaluate: true},
+		{mode: "warn", evaluate: true},
+		{mode: "drop", evaluate: false},
+		{mode: "fail", fails: true},
+	}
+
+	for _, test := range tests {
+		cfg := viper.New()
+		if test.mode != "" {
+			cfg.Set("proposalValidation", test.mode)
+		}
+		v, err := newProposalValidator(cfg, store, &pb.MatchProfile{})
+		require.Nil(t, err)
+
+		evaluate, err := v.check(ctx, invalid)
+		require.Equal(t, test.evaluate, evaluate, test.mode)
+		if test.fails {
+			require.Contains(t, err.Error(), "MatchMakingFunction returned invalid proposal")
+		} else {
+			require.Nil(t, err, test.mode)
+		}
+
+		evaluate, err = v.check(ctx, &pb.Match{MatchId: "2"})
+		require.True(t, evaluate, test.mode)
+		require.Nil(t, err, test.mode)
+	}
+
+	// Warn runs the same checks as drop and fail, counting the proposals it
+	// still evaluates.
+	require.Nil(t, view.Register(invalidProposalsView))
+	defer view.Unregister(invalidProposalsView)
+	require.Nil(t, store.CreateTicket(ctx, &pb.Ticket{Id: "out-of-pool"}))
+	cfg := viper.New()
+	cfg.Set("proposalValidation", "warn")
+	v, err := newProposalValidator(cfg, store, &pb.MatchProfile{
+		Pools: []*pb.Pool{{
+			TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.demo"}},
+		}},
+	})
+	require.Nil(t, err)
+	for _, p := range []*pb.Match{
+		{MatchId: "3", Tickets: []*pb.Ticket{{Id: "missing"}}},
+		{MatchId: "4", Tickets: []*pb.Ticket{{Id: "out-of-pool"}}},
+	} {
+		evaluate, err := v.check(ctx, p)
+		require.True(t, evaluate)
+		require.Nil(t, err)
+	}
+	rows, err := view.RetrieveData(invalidProposalsView.Name)
+	require.Nil(t, err)
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			counts[tag.Value] = row.Data.(*view.CountData).Value
+		}
+	}
+	require.Equal(t, map[string]int64{
+		invalidProposalTicketNotFound:   1,
+		invalidProposalTicketNotInPools: 1,
+	}, counts)
+
+	cfg = viper.New()
+	cfg.Set("proposalValidation", "ignore")
+	_, err = newProposalValidator(cfg, store, &pb.MatchProfile{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -47,3 +47,11 @@ func (sc *synchronizerClient) synchronize(ctx context.Context) (synchronizerStre
 	}
 	return client.(ipb.SynchronizerClient).Synchronize(ctx)
 }
+
+func (sc *synchronizerClient) evaluate(ctx context.Context) (synchronizerStream, error) {
+	client, err := sc.cacher.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.(ipb.SynchronizerClient).Evaluate(ctx)
+}
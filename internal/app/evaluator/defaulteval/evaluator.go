@@ -99,7 +99,8 @@ func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error
 	sort.Sort(byScore(matches))
 
 	d := decollider{
-		ticketsUsed: make(map[string]*collidingMatch),
+		ticketsUsed:   make(map[string]*collidingMatch),
+		backfillsUsed: make(map[string]*collidingMatch),
 	}
 
 	for _, m := range matches {
@@ -121,11 +122,27 @@ type collidingMatch struct {
 }
 
 type decollider struct {
-	resultIDs   []string
-	ticketsUsed map[string]*collidingMatch
+	resultIDs     []string
+	ticketsUsed   map[string]*collidingMatch
+	backfillsUsed map[string]*collidingMatch
 }
 
 func (d *decollider) maybeAdd(m *matchInp) {
+	// Matches creating a new backfill have no id, so can't collide on it.
+	backfillID := m.match.GetBackfill().GetId()
+	if backfillID != "" {
+		if cm, ok := d.backfillsUsed[backfillID]; ok {
+			logger.WithFields(logrus.Fields{
+				"match_id":              m.match.GetMatchId(),
+				"backfill_id":           backfillID,
+				"match_score":           m.inp.GetScore(),
+				"colliding_match_id":    cm.id,
+				"colliding_match_score": cm.score,
+			}).Info("Higher quality match with colliding backfill found. Rejecting match.")
+			return
+		}
+	}
+
 	for _, t := range m.match.GetTickets() {
 		if cm, ok := d.ticketsUsed[t.Id]; ok {
 			logger.WithFields(logrus.Fields{
@@ -139,11 +156,15 @@ func (d *decollider) maybeAdd(m *matchInp) {
 		}
 	}
 
+	cm := &collidingMatch{
+		id:    m.match.GetMatchId(),
+		score: m.inp.GetScore(),
+	}
 	for _, t := range m.match.GetTickets() {
-		d.ticketsUsed[t.Id] = &collidingMatch{
-			id:    m.match.GetMatchId(),
-			score: m.inp.GetScore(),
-		}
+		d.ticketsUsed[t.Id] = cm
+	}
+	if backfillID != "" {
+		d.backfillsUsed[backfillID] = cm
 	}
 
 	d.resultIDs = append(d.resultIDs, m.match.GetMatchId())
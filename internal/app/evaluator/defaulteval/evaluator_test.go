@@ -78,6 +78,41 @@ func TestEvaluate(t *testing.T) {
 		},
 	}
 
+	backfill1 := &pb.Backfill{Id: "1"}
+
+	ticket1Backfill1Score5 := &pb.Match{
+		MatchId:  "ticket1Backfill1Score5",
+		Tickets:  []*pb.Ticket{ticket1},
+		Backfill: backfill1,
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 5,
+			}),
+		},
+	}
+
+	ticket3Backfill1Score10 := &pb.Match{
+		MatchId:  "ticket3Backfill1Score10",
+		Tickets:  []*pb.Ticket{ticket3},
+		Backfill: backfill1,
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 10,
+			}),
+		},
+	}
+
+	ticket2NewBackfillScore1 := &pb.Match{
+		MatchId:  "ticket2NewBackfillScore1",
+		Tickets:  []*pb.Ticket{ticket2},
+		Backfill: &pb.Backfill{},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 1,
+			}),
+		},
+	}
+
 	tests := []struct {
 		description  string
 		testMatches  []*pb.Match
@@ -108,6 +143,21 @@ func TestEvaluate(t *testing.T) {
 			testMatches:  []*pb.Match{ticket12Score1, ticket12Score10, ticket123Score5, ticket3Score50},
 			wantMatchIDs: []string{ticket12Score10.GetMatchId(), ticket3Score50.GetMatchId()},
 		},
+		{
+			description:  "test deduplicates matches updating the same backfill",
+			testMatches:  []*pb.Match{ticket1Backfill1Score5, ticket3Backfill1Score10},
+			wantMatchIDs: []string{ticket3Backfill1Score10.GetMatchId()},
+		},
+		{
+			description:  "test backfill matches collide with ticket matches",
+			testMatches:  []*pb.Match{ticket1Backfill1Score5, ticket12Score10},
+			wantMatchIDs: []string{ticket12Score10.GetMatchId()},
+		},
+		{
+			description:  "test matches creating new backfills don't collide on backfill",
+			testMatches:  []*pb.Match{ticket2NewBackfillScore1, ticket3Backfill1Score10, ticket1Backfill1Score5},
+			wantMatchIDs: []string{ticket2NewBackfillScore1.GetMatchId(), ticket3Backfill1Score10.GetMatchId()},
+		},
 	}
 
 	for _, test := range tests {
@@ -16,6 +16,8 @@ package frontend
 
 import (
 	"context"
+	"crypto/sha256"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -27,6 +29,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/pkg/pb"
 )
@@ -50,22 +53,233 @@ var (
 //   - If a TicketId exists in a Ticket request, an auto-generated TicketId will override this field.
 //   - If SearchFields exist in a Ticket, CreateTicket will also index these fields such that one can query the ticket with query.QueryTickets function.
 func (s *frontendService) CreateTicket(ctx context.Context, req *pb.CreateTicketRequest) (*pb.Ticket, error) {
-	// Perform input validation.
+	ttl, err := validateCreateTicketRequest(req, getTicketTTL(s.cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IdempotencyKey != "" {
+		return doCreateTicketIdempotent(ctx, req, s.store, ttl, getIdempotencyKeyWindow(s.cfg))
+	}
+	return doCreateTicket(ctx, req, s.store, ttl)
+}
+
+// validateCreateTicketRequest checks the request, and returns the time to live
+// of the ticket to create.  defaultTTL is used when the request doesn't specify
+// its own.
+func validateCreateTicketRequest(req *pb.CreateTicketRequest, defaultTTL time.Duration) (time.Duration, error) {
 	if req.Ticket == nil {
-		return nil, status.Errorf(codes.InvalidArgument, ".ticket is required")
+		return 0, status.Errorf(codes.InvalidArgument, ".ticket is required")
 	}
 	if req.Ticket.Assignment != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "tickets cannot be created with an assignment")
+		return 0, status.Errorf(codes.InvalidArgument, "tickets cannot be created with an assignment")
 	}
 	if req.Ticket.CreateTime != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "tickets cannot be created with create time set")
+		return 0, status.Errorf(codes.InvalidArgument, "tickets cannot be created with create time set")
+	}
+	if req.Ticket.ExpireTime != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "tickets cannot be created with expire time set, use .ttl instead")
+	}
+
+	if req.Ttl == nil {
+		return defaultTTL, nil
+	}
+	ttl, err := ptypes.Duration(req.Ttl)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid .ttl: %v", err)
+	}
+	if ttl <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, ".ttl must be positive")
+	}
+	return ttl, nil
+}
+
+// getTicketTTL returns the configured time to live of tickets which don't
+// specify their own, or zero if such tickets never expire.
+func getTicketTTL(cfg config.View) time.Duration {
+	const name = "ticketTTL"
+
+	if !cfg.IsSet(name) {
+		return 0
 	}
 
-	return doCreateTicket(ctx, req, s.store)
+	ttl := cfg.GetDuration(name)
+	if ttl < 0 {
+		logger.Infof("ticket ttl %v is negative, tickets will not expire", ttl)
+		return 0
+	}
+	return ttl
+}
+
+// getIdempotencyKeyWindow returns how long a ticket is returned for repeats of
+// the idempotency key it was created with.
+func getIdempotencyKeyWindow(cfg config.View) time.Duration {
+	const (
+		name          = "idempotencyKeyWindow"
+		defaultWindow = 10 * time.Minute
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultWindow
+	}
+
+	window := cfg.GetDuration(name)
+	if window <= 0 {
+		logger.Infof("idempotency key window %v is not positive, using default %v", window, defaultWindow)
+		return defaultWindow
+	}
+	return window
 }
 
-func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service) (*pb.Ticket, error) {
-	// Generate a ticket id and create a Ticket in state storage
+func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service, ttl time.Duration) (*pb.Ticket, error) {
+	ticket, err := newTicket(ctx, req, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = storeTicket(ctx, ticket, store)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// doCreateTicketIdempotent creates the ticket unless one was already created
+// with the request's idempotency key within the window, in which case that
+// ticket is returned instead.  The ticket is stored before the key is
+// reserved, so that a key is only ever recorded for a stored ticket, and
+// indexed after, so that a ticket which loses the key to an earlier request is
+// never matched.
+func doCreateTicketIdempotent(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service, ttl, window time.Duration) (*pb.Ticket, error) {
+	ticket, err := newTicket(ctx, req, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.CreateTicket(ctx, ticket)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"ticket": ticket,
+		}).Error("failed to create the ticket")
+		return nil, err
+	}
+
+	existing, err := reserveIdempotencyKey(ctx, req, ticket, store, window)
+	if err != nil {
+		deleteUnusedTickets(store, []string{ticket.Id})
+		return nil, err
+	}
+	if existing != nil {
+		deleteUnusedTickets(store, []string{ticket.Id})
+		return existing, nil
+	}
+
+	err = store.IndexTicket(ctx, ticket)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"ticket": ticket,
+		}).Error("failed to index the ticket")
+		releaseIdempotencyKeys(store, []string{req.IdempotencyKey})
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// reserveIdempotencyKey records the stored ticket for the request's
+// idempotency key, returning the ticket already recorded for it, if any.
+// Requests which repeat a key with a different ticket fail with
+// FailedPrecondition.
+func reserveIdempotencyKey(ctx context.Context, req *pb.CreateTicketRequest, ticket *pb.Ticket, store statestore.Service, window time.Duration) (*pb.Ticket, error) {
+	hash, err := hashCreateTicketRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := store.ReserveIdempotencyKey(ctx, req.IdempotencyKey, hash, ticket, window)
+	if err != nil {
+		if status.Code(err) != codes.FailedPrecondition {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"key":   req.IdempotencyKey,
+			}).Error("failed to reserve the idempotency key")
+		}
+		return nil, err
+	}
+	return existing, nil
+}
+
+// hashCreateTicketRequest returns a hash of the request, which is the same for
+// every retry of it.
+func hashCreateTicketRequest(req *pb.CreateTicketRequest) ([]byte, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	err := b.Marshal(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal the create ticket request: %v", err)
+	}
+	hash := sha256.Sum256(b.Bytes())
+	return hash[:], nil
+}
+
+// deleteUnusedTickets deletes tickets which were stored, but not indexed, for
+// requests whose idempotency keys could not be reserved.  The request's
+// context may be what failed, so a new one is used.
+func deleteUnusedTickets(store statestore.Service, ids []string) {
+	ctx, span := trace.StartSpan(context.Background(), "open-match/frontend.DeleteUnusedTickets")
+	defer span.End()
+	err := store.DeleteTickets(ctx, ids)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ids":   ids,
+		}).Error("failed to delete the unused tickets")
+	}
+}
+
+// releaseIdempotencyKeys removes the records of keys whose tickets failed to
+// be created, so retries create them.  The request's context may be what
+// failed, so a new one is used.
+func releaseIdempotencyKeys(store statestore.Service, keys []string) {
+	ctx, span := trace.StartSpan(context.Background(), "open-match/frontend.ReleaseIdempotencyKeys")
+	defer span.End()
+	for _, key := range keys {
+		err := store.DeleteIdempotencyKey(ctx, key)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"key":   key,
+			}).Error("failed to release the idempotency key")
+		}
+	}
+}
+
+// storeTicket creates and indexes the ticket.
+func storeTicket(ctx context.Context, ticket *pb.Ticket, store statestore.Service) error {
+	err := store.CreateTicket(ctx, ticket)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"ticket": ticket,
+		}).Error("failed to create the ticket")
+		return err
+	}
+
+	err = store.IndexTicket(ctx, ticket)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"ticket": ticket,
+		}).Error("failed to index the ticket")
+		return err
+	}
+	return nil
+}
+
+// newTicket generates the Ticket to store for the request, with a new id,
+// create time, and expire time if the ttl is positive.
+func newTicket(ctx context.Context, req *pb.CreateTicketRequest, ttl time.Duration) (*pb.Ticket, error) {
 	ticket, ok := proto.Clone(req.Ticket).(*pb.Ticket)
 	if !ok {
 		return nil, status.Error(codes.Internal, "failed to clone input ticket proto")
@@ -73,6 +287,16 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 
 	ticket.Id = xid.New().String()
 	ticket.CreateTime = ptypes.TimestampNow()
+	if ttl > 0 {
+		createTime, err := ptypes.Timestamp(ticket.CreateTime)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert create time: %v", err)
+		}
+		ticket.ExpireTime, err = ptypes.TimestampProto(createTime.Add(ttl))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ticket expire time: %v", err)
+		}
+	}
 
 	sfCount := 0
 	sfCount += len(ticket.GetSearchFields().GetDoubleArgs())
@@ -81,25 +305,97 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 	stats.Record(ctx, searchFieldsPerTicket.M(int64(sfCount)))
 	stats.Record(ctx, totalBytesPerTicket.M(int64(proto.Size(ticket))))
 
-	err := store.CreateTicket(ctx, ticket)
+	return ticket, nil
+}
+
+// CreateTickets creates multiple Tickets in a single call, as CreateTicket does for each of them.
+//   - Tickets which fail validation are reported as failures, and the rest are created.
+func (s *frontendService) CreateTickets(ctx context.Context, req *pb.CreateTicketsRequest) (*pb.CreateTicketsResponse, error) {
+	resp := &pb.CreateTicketsResponse{}
+	defaultTTL := getTicketTTL(s.cfg)
+	window := getIdempotencyKeyWindow(s.cfg)
+
+	// tickets are the new tickets for the valid requests, and positions the
+	// indexes of those requests.
+	tickets := make([]*pb.Ticket, 0, len(req.GetTickets()))
+	positions := make([]int, 0, len(req.GetTickets()))
+	for i, r := range req.GetTickets() {
+		ticket, err := newTicketForBatch(ctx, r, defaultTTL)
+		if err != nil {
+			resp.Failures = append(resp.Failures, newCreateTicketFailure(i, err))
+			continue
+		}
+		tickets = append(tickets, ticket)
+		positions = append(positions, i)
+	}
+
+	// As in CreateTicket, tickets are stored before their idempotency keys are
+	// reserved, and indexed after.
+	err := s.store.CreateTickets(ctx, tickets)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
-			"error":  err.Error(),
-			"ticket": ticket,
-		}).Error("failed to create the ticket")
+			"error": err.Error(),
+			"count": len(tickets),
+		}).Error("failed to create the tickets")
 		return nil, err
 	}
 
-	err = store.IndexTicket(ctx, ticket)
+	// indexed are the tickets to index, reserved the idempotency keys recorded
+	// for them, and unused the tickets not returned.
+	indexed := make([]*pb.Ticket, 0, len(tickets))
+	var reserved, unused []string
+	for j, ticket := range tickets {
+		i := positions[j]
+		r := req.GetTickets()[i]
+		if r.IdempotencyKey != "" {
+			existing, err := reserveIdempotencyKey(ctx, r, ticket, s.store, window)
+			if err != nil {
+				resp.Failures = append(resp.Failures, newCreateTicketFailure(i, err))
+				unused = append(unused, ticket.Id)
+				continue
+			}
+			if existing != nil {
+				resp.Tickets = append(resp.Tickets, existing)
+				unused = append(unused, ticket.Id)
+				continue
+			}
+			reserved = append(reserved, r.IdempotencyKey)
+		}
+		resp.Tickets = append(resp.Tickets, ticket)
+		indexed = append(indexed, ticket)
+	}
+	if len(unused) > 0 {
+		deleteUnusedTickets(s.store, unused)
+	}
+
+	err = s.store.IndexTickets(ctx, indexed)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
-			"error":  err.Error(),
-			"ticket": ticket,
-		}).Error("failed to index the ticket")
+			"error": err.Error(),
+			"count": len(indexed),
+		}).Error("failed to index the tickets")
+		releaseIdempotencyKeys(s.store, reserved)
 		return nil, err
 	}
 
-	return ticket, nil
+	return resp, nil
+}
+
+func newCreateTicketFailure(index int, err error) *pb.CreateTicketFailure {
+	return &pb.CreateTicketFailure{
+		Index:  int32(index),
+		Status: status.Convert(err).Proto(),
+	}
+}
+
+// newTicketForBatch validates one request of a CreateTickets call, and returns
+// the ticket for it.
+func newTicketForBatch(ctx context.Context, req *pb.CreateTicketRequest, defaultTTL time.Duration) (*pb.Ticket, error) {
+	ttl, err := validateCreateTicketRequest(req, defaultTTL)
+	if err != nil {
+		return nil, err
+	}
+	return newTicket(ctx, req, ttl)
 }
 
 // DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
@@ -107,21 +403,48 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 //   - If SearchFields exist in a Ticket, DeleteTicket will deindex the fields lazily.
 // Users may still be able to assign/get a ticket after calling DeleteTicket on it.
 func (s *frontendService) DeleteTicket(ctx context.Context, req *pb.DeleteTicketRequest) (*empty.Empty, error) {
-	err := doDeleteTicket(ctx, req.GetTicketId(), s.store)
+	err := doDeleteTickets(ctx, []string{req.GetTicketId()}, s.store)
 	if err != nil {
 		return nil, err
 	}
 	return &empty.Empty{}, nil
 }
 
-func doDeleteTicket(ctx context.Context, id string, store statestore.Service) error {
-	// Deindex this Ticket to remove it from matchmaking pool.
-	err := store.DeindexTicket(ctx, id)
+// DeleteTickets deletes multiple Tickets in a single call, as DeleteTicket does for each of them.
+func (s *frontendService) DeleteTickets(ctx context.Context, req *pb.DeleteTicketsRequest) (*pb.DeleteTicketsResponse, error) {
+	resp := &pb.DeleteTicketsResponse{}
+
+	ids := make([]string, 0, len(req.GetTicketIds()))
+	for _, id := range req.GetTicketIds() {
+		if id == "" {
+			resp.Failures = append(resp.Failures, &pb.DeleteTicketFailure{
+				TicketId: id,
+				Status:   status.New(codes.InvalidArgument, "ticket id is required").Proto(),
+			})
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	err := doDeleteTickets(ctx, ids, s.store)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func doDeleteTickets(ctx context.Context, ids []string, store statestore.Service) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Deindex these Tickets to remove them from matchmaking pool.
+	err := store.DeindexTickets(ctx, ids)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err.Error(),
-			"id":    id,
-		}).Error("failed to deindex the ticket")
+			"ids":   ids,
+		}).Error("failed to deindex the tickets")
 		return err
 	}
 
@@ -130,19 +453,19 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 	go func() {
 		ctx, span := trace.StartSpan(context.Background(), "open-match/frontend.DeleteTicketLazy")
 		defer span.End()
-		err := store.DeleteTicket(ctx, id)
+		err := store.DeleteTickets(ctx, ids)
 		if err != nil {
 			logger.WithFields(logrus.Fields{
 				"error": err.Error(),
-				"id":    id,
-			}).Error("failed to delete the ticket")
+				"ids":   ids,
+			}).Error("failed to delete the tickets")
 		}
-		err = store.DeleteTicketsFromPendingRelease(ctx, []string{id})
+		err = store.DeleteTicketsFromPendingRelease(ctx, ids)
 		if err != nil {
 			logger.WithFields(logrus.Fields{
 				"error": err.Error(),
-				"id":    id,
-			}).Error("failed to delete the ticket from pendingRelease")
+				"ids":   ids,
+			}).Error("failed to delete the tickets from pendingRelease")
 		}
 		// TODO: If other redis queues are implemented or we have custom index fields
 		// created by Open Match, those need to be cleaned up here.
@@ -150,6 +473,28 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 	return nil
 }
 
+// UpdateTicket replaces the SearchFields and extensions of an existing Ticket, keeping its id and create time.
+//   - Tickets which are assigned or pending release cannot be updated.
+func (s *frontendService) UpdateTicket(ctx context.Context, req *pb.UpdateTicketRequest) (*pb.Ticket, error) {
+	if req.Ticket == nil {
+		return nil, status.Errorf(codes.InvalidArgument, ".ticket is required")
+	}
+	if req.Ticket.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".ticket.id is required")
+	}
+
+	ticket, err := s.store.UpdateTicket(ctx, req.Ticket)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"ticket": req.Ticket,
+		}).Error("failed to update the ticket")
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
 // GetTicket get the Ticket associated with the specified TicketId.
 func (s *frontendService) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
 	return doGetTickets(ctx, req.GetTicketId(), s.store)
@@ -206,3 +551,177 @@ func doWatchAssignments(ctx context.Context, id string, sender func(*pb.Assignme
 
 	return store.GetAssignments(ctx, id, callback)
 }
+
+// StreamAssignments stream back Assignments of all the specified Tickets, over a single stream, as they are updated.
+//   - Tickets are selected by TicketId, by Pool, or both.
+//   - The stream is kept open until the client cancels it.
+func (s *frontendService) StreamAssignments(req *pb.StreamAssignmentsRequest, stream pb.FrontendService_StreamAssignmentsServer) error {
+	if len(req.GetTicketIds()) == 0 && req.GetPool() == nil {
+		return status.Error(codes.InvalidArgument, ".ticket_ids or .pool is required")
+	}
+
+	var pf *filter.PoolFilter
+	if req.GetPool() != nil {
+		var err error
+		pf, err = filter.NewPoolFilter(req.GetPool())
+		if err != nil {
+			return err
+		}
+	}
+
+	sender := func(id string, assignment *pb.Assignment) error {
+		return stream.Send(&pb.StreamAssignmentsResponse{TicketId: id, Assignment: assignment})
+	}
+	return doStreamAssignments(stream.Context(), req.GetTicketIds(), pf, sender, s.store)
+}
+
+func doStreamAssignments(ctx context.Context, ids []string, pf *filter.PoolFilter, sender func(string, *pb.Assignment) error, store statestore.Service) error {
+	// sent holds the last assignment sent for each of the requested ids, as the
+	// store may pass the same assignment more than once.
+	sent := make(map[string]*pb.Assignment, len(ids))
+	for _, id := range ids {
+		sent[id] = nil
+	}
+
+	callback := func(ticket *pb.Ticket) error {
+		last, requested := sent[ticket.GetId()]
+		if !requested && (pf == nil || !pf.In(ticket)) {
+			return nil
+		}
+		if requested {
+			if last != nil && proto.Equal(last, ticket.GetAssignment()) {
+				return nil
+			}
+			sent[ticket.GetId()] = ticket.GetAssignment()
+		}
+
+		err := sender(ticket.GetId(), ticket.GetAssignment())
+		if err != nil {
+			logger.WithError(err).Error("failed to send assignment update to grpc server")
+			return status.Errorf(codes.Aborted, err.Error())
+		}
+		return nil
+	}
+
+	return store.SubscribeAssignments(ctx, ids, pf != nil, callback)
+}
+
+// CreateBackfill assigns an unique BackfillId to the input Backfill and record it in state storage.
+// A backfill is considered as ready for matchmaking once it is created.
+//   - If a BackfillId exists in a Backfill request, an auto-generated BackfillId will override this field.
+//   - If SearchFields exist in a Backfill, CreateBackfill will also index these fields such that one can query the backfill with query.QueryBackfills function.
+func (s *frontendService) CreateBackfill(ctx context.Context, req *pb.CreateBackfillRequest) (*pb.Backfill, error) {
+	// Perform input validation.
+	if req.Backfill == nil {
+		return nil, status.Errorf(codes.InvalidArgument, ".backfill is required")
+	}
+	if req.Backfill.CreateTime != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "backfills cannot be created with create time set")
+	}
+
+	return doCreateBackfill(ctx, req.Backfill, s.store)
+}
+
+func doCreateBackfill(ctx context.Context, backfill *pb.Backfill, store statestore.Service) (*pb.Backfill, error) {
+	// Generate a backfill id and create a Backfill in state storage
+	backfill, ok := proto.Clone(backfill).(*pb.Backfill)
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to clone input backfill proto")
+	}
+
+	backfill.Id = xid.New().String()
+	backfill.CreateTime = ptypes.TimestampNow()
+	backfill.Generation = 1
+
+	err := store.CreateBackfill(ctx, backfill)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"backfill": backfill,
+		}).Error("failed to create the backfill")
+		return nil, err
+	}
+
+	err = store.IndexBackfill(ctx, backfill)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"backfill": backfill,
+		}).Error("failed to index the backfill")
+		return nil, err
+	}
+
+	return backfill, nil
+}
+
+// UpdateBackfill replaces the SearchFields and extensions of an existing Backfill, and increments its generation.
+//   - Matches proposed against the previous generation of the Backfill will be rejected.
+func (s *frontendService) UpdateBackfill(ctx context.Context, req *pb.UpdateBackfillRequest) (*pb.Backfill, error) {
+	if req.Backfill == nil {
+		return nil, status.Errorf(codes.InvalidArgument, ".backfill is required")
+	}
+	if req.Backfill.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, ".backfill.id is required")
+	}
+
+	current, err := s.store.GetBackfill(ctx, req.Backfill.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	backfill, ok := proto.Clone(req.Backfill).(*pb.Backfill)
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to clone input backfill proto")
+	}
+	backfill.CreateTime = current.CreateTime
+	backfill.Generation = current.Generation + 1
+
+	err = s.store.UpdateBackfill(ctx, backfill, current.Generation)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"backfill": backfill,
+		}).Error("failed to update the backfill")
+		return nil, err
+	}
+
+	return backfill, nil
+}
+
+// DeleteBackfill immediately stops Open Match from using the Backfill for matchmaking and removes the Backfill from state storage.
+func (s *frontendService) DeleteBackfill(ctx context.Context, req *pb.DeleteBackfillRequest) (*empty.Empty, error) {
+	id := req.GetBackfillId()
+	err := s.store.DeindexBackfill(ctx, id)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to deindex the backfill")
+		return nil, err
+	}
+
+	err = s.store.DeleteBackfill(ctx, id)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+		}).Error("failed to delete the backfill")
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetBackfill get the Backfill associated with the specified BackfillId.
+func (s *frontendService) GetBackfill(ctx context.Context, req *pb.GetBackfillRequest) (*pb.Backfill, error) {
+	backfill, err := s.store.GetBackfill(ctx, req.GetBackfillId())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    req.GetBackfillId(),
+		}).Error("failed to get the backfill")
+		return nil, err
+	}
+
+	return backfill, nil
+}
@@ -22,10 +22,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/filter"
 	"open-match.dev/open-match/internal/statestore"
 	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
 	utilTesting "open-match.dev/open-match/internal/util/testing"
@@ -39,6 +41,7 @@ func TestDoCreateTickets(t *testing.T) {
 		description string
 		preAction   func(cancel context.CancelFunc)
 		ticket      *pb.Ticket
+		ttl         time.Duration
 		wantCode    codes.Code
 	}{
 		{
@@ -65,6 +68,19 @@ func TestDoCreateTickets(t *testing.T) {
 			},
 			wantCode: codes.OK,
 		},
+		{
+			description: "expect expire time set with ttl",
+			preAction:   func(_ context.CancelFunc) {},
+			ticket: &pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					DoubleArgs: map[string]float64{
+						"test-arg": 1,
+					},
+				},
+			},
+			ttl:      time.Minute,
+			wantCode: codes.OK,
+		},
 	}
 
 	for _, test := range tests {
@@ -76,18 +92,68 @@ func TestDoCreateTickets(t *testing.T) {
 			ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
 			test.preAction(cancel)
 
-			res, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: test.ticket}, store)
+			res, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: test.ticket}, store, test.ttl)
 			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
 			if err == nil {
 				matched, err := regexp.MatchString(`[0-9a-v]{20}`, res.GetId())
 				require.True(t, matched)
 				require.Nil(t, err)
 				require.Equal(t, test.ticket.SearchFields.DoubleArgs["test-arg"], res.SearchFields.DoubleArgs["test-arg"])
+				if test.ttl == 0 {
+					require.Nil(t, res.ExpireTime)
+				} else {
+					createTime, err := ptypes.Timestamp(res.CreateTime)
+					require.Nil(t, err)
+					expireTime, err := ptypes.Timestamp(res.ExpireTime)
+					require.Nil(t, err)
+					require.Equal(t, test.ttl, expireTime.Sub(createTime))
+				}
 			}
 		})
 	}
 }
 
+func TestDoCreateTicketIdempotent(t *testing.T) {
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+
+	req := &pb.CreateTicketRequest{
+		Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"a": 1, "b": 2},
+			},
+		},
+		IdempotencyKey: "key",
+	}
+	first, err := doCreateTicketIdempotent(ctx, req, store, 0, time.Minute)
+	require.Nil(t, err)
+
+	// Retries return the first ticket, and leave no other ticket stored.
+	retry, err := doCreateTicketIdempotent(ctx, req, store, 0, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, first.Id, retry.Id)
+
+	// Retries with a changed ticket fail, rather than returning a ticket
+	// which isn't the one requested.
+	changed := &pb.CreateTicketRequest{
+		Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"a": 1, "b": 3},
+			},
+		},
+		IdempotencyKey: "key",
+	}
+	_, err = doCreateTicketIdempotent(ctx, changed, store, 0, time.Minute)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	ids, err := store.GetIndexedIDSet(ctx)
+	require.Nil(t, err)
+	require.Len(t, ids, 1)
+	require.Contains(t, ids, first.Id)
+}
+
 func TestDoWatchAssignments(t *testing.T) {
 	testTicket := &pb.Ticket{
 		Id: "test-id",
@@ -165,6 +231,67 @@ func TestDoWatchAssignments(t *testing.T) {
 	}
 }
 
+func TestDoStreamAssignments(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+
+	byID := &pb.Ticket{Id: "by-id"}
+	byPool := &pb.Ticket{Id: "by-pool", SearchFields: &pb.SearchFields{Tags: []string{"vip"}}}
+	other := &pb.Ticket{Id: "other"}
+	for _, ticket := range []*pb.Ticket{byID, byPool, other} {
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+	}
+
+	assign := func(id, connection string) *pb.AssignmentGroup {
+		return &pb.AssignmentGroup{TicketIds: []string{id}, Assignment: &pb.Assignment{Connection: connection}}
+	}
+	_, err := store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{assign(byID.Id, "a")},
+	})
+	require.Nil(t, err)
+
+	pf, err := filter.NewPoolFilter(&pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "vip"}},
+	})
+	require.Nil(t, err)
+
+	got := make(chan *pb.StreamAssignmentsResponse, 10)
+	sent := 0
+	sender := func(id string, assignment *pb.Assignment) error {
+		got <- &pb.StreamAssignmentsResponse{TicketId: id, Assignment: assignment}
+		sent++
+		if sent == 2 {
+			return errors.New("some error")
+		}
+		return nil
+	}
+	errs := make(chan error)
+	go func() {
+		errs <- doStreamAssignments(ctx, []string{byID.Id}, pf, sender, store)
+	}()
+
+	// The existing assignment is sent once the stream starts.
+	resp := <-got
+	require.Equal(t, byID.Id, resp.TicketId)
+	require.Equal(t, "a", resp.Assignment.Connection)
+
+	// Unchanged assignments and unselected tickets are not sent.
+	_, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{assign(byID.Id, "a"), assign(other.Id, "c")},
+	})
+	require.Nil(t, err)
+	_, err = store.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{assign(byPool.Id, "b")},
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, codes.Aborted, status.Code(<-errs))
+	resp = <-got
+	require.Equal(t, byPool.Id, resp.TicketId)
+	require.Equal(t, "b", resp.Assignment.Connection)
+}
+
 func TestDoDeleteTicket(t *testing.T) {
 	fakeTicket := &pb.Ticket{
 		Id: "1",
@@ -210,7 +337,7 @@ func TestDoDeleteTicket(t *testing.T) {
 
 			test.preAction(ctx, cancel, store)
 
-			err := doDeleteTicket(ctx, fakeTicket.GetId(), store)
+			err := doDeleteTickets(ctx, []string{fakeTicket.GetId()}, store)
 			require.Equal(t, test.wantCode.String(), status.Convert(err).Code().String())
 		})
 	}
@@ -13,4 +13,7 @@
 // limitations under the License.
 
 // Package minimatch is an merger of all the Open Match services in a single binary. Useful for testing.
+//
+// Setting "statestore.backend: memory" in the configuration keeps all state
+// in process, so minimatch can run without a Redis.
 package minimatch
@@ -0,0 +1,358 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"math"
+	"sort"
+
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// ticketIndex holds the cached tickets, along with secondary indexes on their
+// search fields.  Queries use the most selective index to find candidate
+// tickets, and then check each candidate against the full pool filter, so
+// results are always the same as scanning every ticket.
+type ticketIndex struct {
+	tickets map[string]*pb.Ticket
+
+	doubles map[string]*doubleIndex
+	// strings maps string arg name to value to ticket ids.
+	strings map[string]map[string]map[string]struct{}
+	// tags maps tag to ticket ids.
+	tags map[string]map[string]struct{}
+}
+
+func newTicketIndex() *ticketIndex {
+	return &ticketIndex{
+		tickets: make(map[string]*pb.Ticket),
+		doubles: make(map[string]*doubleIndex),
+		strings: make(map[string]map[string]map[string]struct{}),
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+// add inserts the ticket into the index.  The ticket's id must not already be
+// in the index.
+func (ti *ticketIndex) add(t *pb.Ticket) {
+	ti.tickets[t.Id] = t
+
+	s := t.GetSearchFields()
+	for arg, v := range s.GetDoubleArgs() {
+		di, ok := ti.doubles[arg]
+		if !ok {
+			di = newDoubleIndex()
+			ti.doubles[arg] = di
+		}
+		di.add(t.Id, v)
+	}
+
+	for arg, v := range s.GetStringArgs() {
+		values, ok := ti.strings[arg]
+		if !ok {
+			values = make(map[string]map[string]struct{})
+			ti.strings[arg] = values
+		}
+		addToSet(values, v, t.Id)
+	}
+
+	for _, tag := range s.GetTags() {
+		addToSet(ti.tags, tag, t.Id)
+	}
+}
+
+// remove deletes the ticket with the given id from the index, if present.
+func (ti *ticketIndex) remove(id string) {
+	t, ok := ti.tickets[id]
+	if !ok {
+		return
+	}
+	delete(ti.tickets, id)
+
+	s := t.GetSearchFields()
+	for arg := range s.GetDoubleArgs() {
+		di := ti.doubles[arg]
+		di.remove(id)
+		if len(di.values) == 0 {
+			delete(ti.doubles, arg)
+		}
+	}
+
+	for arg, v := range s.GetStringArgs() {
+		values := ti.strings[arg]
+		removeFromSet(values, v, id)
+		if len(values) == 0 {
+			delete(ti.strings, arg)
+		}
+	}
+
+	for _, tag := range s.GetTags() {
+		removeFromSet(ti.tags, tag, id)
+	}
+}
+
+// sort brings the double arg indexes up to date with the added and removed
+// tickets.  It must be called after modifying the index and before querying
+// it, as queries may run concurrently.
+func (ti *ticketIndex) sort() {
+	for _, di := range ti.doubles {
+		di.sort()
+	}
+}
+
+// query calls f with each ticket which is in the pool filter.
+func (ti *ticketIndex) query(pf *filter.PoolFilter, f func(*pb.Ticket)) {
+	c := ti.bestCandidates(pf)
+	if c == nil {
+		for _, t := range ti.tickets {
+			if pf.In(t) {
+				f(t)
+			}
+		}
+		return
+	}
+
+	c.each(func(id string) {
+		t := ti.tickets[id]
+		if pf.In(t) {
+			f(t)
+		}
+	})
+}
+
+// candidates is a superset of the tickets in a pool, found by a single index
+// lookup.
+type candidates struct {
+	size int
+	each func(func(id string))
+}
+
+// bestCandidates returns the smallest set of candidates found by looking up
+// each of the pool filter's indexable filters, or nil if there are none.
+func (ti *ticketIndex) bestCandidates(pf *filter.PoolFilter) *candidates {
+	var best *candidates
+	consider := func(c *candidates) {
+		if best == nil || c.size < best.size {
+			best = c
+		}
+	}
+
+	for _, f := range pf.DoubleRangeFilters {
+		di, ok := ti.doubles[f.DoubleArg]
+		if !ok {
+			return &candidates{each: func(func(string)) {}}
+		}
+		consider(di.lookup(f.Min, f.Max))
+	}
+
+	for _, f := range pf.StringEqualsFilters {
+		consider(setCandidates(ti.strings[f.StringArg][f.Value]))
+	}
+
+	for _, f := range pf.StringInFilters {
+		values := ti.strings[f.StringArg]
+		c := &candidates{}
+		var sets []map[string]struct{}
+		seen := make(map[string]struct{}, len(f.Values))
+		for _, v := range f.Values {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			sets = append(sets, values[v])
+			c.size += len(values[v])
+		}
+		c.each = func(visit func(string)) {
+			for _, set := range sets {
+				for id := range set {
+					visit(id)
+				}
+			}
+		}
+		consider(c)
+	}
+
+	for _, f := range pf.TagPresentFilters {
+		consider(setCandidates(ti.tags[f.Tag]))
+	}
+
+	return best
+}
+
+func setCandidates(set map[string]struct{}) *candidates {
+	return &candidates{
+		size: len(set),
+		each: func(visit func(string)) {
+			for id := range set {
+				visit(id)
+			}
+		},
+	}
+}
+
+func addToSet(sets map[string]map[string]struct{}, key, id string) {
+	set, ok := sets[key]
+	if !ok {
+		set = make(map[string]struct{})
+		sets[key] = set
+	}
+	set[id] = struct{}{}
+}
+
+func removeFromSet(sets map[string]map[string]struct{}, key, id string) {
+	set := sets[key]
+	delete(set, id)
+	if len(set) == 0 {
+		delete(sets, key)
+	}
+}
+
+// doubleIndex keeps the values of a single double arg sorted by value and then
+// ticket id, so that range lookups are a binary search.  Changes are applied to
+// the sorted entries at the end of a cache update, as an update adds and
+// removes many tickets at once.
+type doubleIndex struct {
+	values map[string]float64
+	sorted []doubleEntry
+	// added holds the ids of tickets not yet inserted into sorted, and removed
+	// the entries not yet deleted from it.
+	added   map[string]struct{}
+	removed []doubleEntry
+}
+
+type doubleEntry struct {
+	value float64
+	id    string
+}
+
+func newDoubleIndex() *doubleIndex {
+	return &doubleIndex{
+		values: make(map[string]float64),
+		added:  make(map[string]struct{}),
+	}
+}
+
+func (di *doubleIndex) add(id string, v float64) {
+	di.values[id] = v
+	di.added[id] = struct{}{}
+}
+
+func (di *doubleIndex) remove(id string) {
+	v, ok := di.values[id]
+	if !ok {
+		return
+	}
+	delete(di.values, id)
+	if _, ok := di.added[id]; ok {
+		delete(di.added, id)
+		return
+	}
+	di.removed = append(di.removed, doubleEntry{value: v, id: id})
+}
+
+// sort deletes the removed entries from, and inserts the added entries into,
+// the sorted entries.  Both find their positions with a binary search, and
+// then move each of the entries between positions once, so a refresh costs
+// the same whether it changes one ticket or many.
+func (di *doubleIndex) sort() {
+	var positions []int
+	for _, e := range di.removed {
+		// NaN values are never within a range, so aren't sorted.
+		if math.IsNaN(e.value) {
+			continue
+		}
+		i := searchEntries(di.sorted, e)
+		if i < len(di.sorted) && di.sorted[i].id == e.id {
+			positions = append(positions, i)
+		}
+	}
+	di.removed = nil
+	if len(positions) > 0 {
+		sort.Ints(positions)
+		n := positions[0]
+		for p, i := range positions {
+			next := len(di.sorted)
+			if p+1 < len(positions) {
+				next = positions[p+1]
+			}
+			n += copy(di.sorted[n:], di.sorted[i+1:next])
+		}
+		for i := n; i < len(di.sorted); i++ {
+			di.sorted[i] = doubleEntry{}
+		}
+		di.sorted = di.sorted[:n]
+	}
+
+	added := make([]doubleEntry, 0, len(di.added))
+	for id := range di.added {
+		if v := di.values[id]; !math.IsNaN(v) {
+			added = append(added, doubleEntry{value: v, id: id})
+		}
+	}
+	di.added = make(map[string]struct{})
+	if len(added) == 0 {
+		return
+	}
+	sort.Slice(added, func(i, j int) bool {
+		return added[i].less(added[j])
+	})
+
+	// Insert from the largest entry down, moving the entries after each
+	// position to the end of the space left for them.
+	n := len(di.sorted)
+	di.sorted = append(di.sorted, added...)
+	end := len(di.sorted)
+	for j := len(added) - 1; j >= 0; j-- {
+		i := searchEntries(di.sorted[:n], added[j])
+		end -= copy(di.sorted[end-(n-i):end], di.sorted[i:n]) + 1
+		di.sorted[end] = added[j]
+		n = i
+	}
+}
+
+func (e doubleEntry) less(o doubleEntry) bool {
+	return e.value < o.value || (e.value == o.value && e.id < o.id)
+}
+
+// searchEntries returns the position of the first entry which isn't before e.
+func searchEntries(entries []doubleEntry, e doubleEntry) int {
+	return sort.Search(len(entries), func(i int) bool {
+		return !entries[i].less(e)
+	})
+}
+
+func (di *doubleIndex) lookup(min, max float64) *candidates {
+	// Not simplified so that NaN bounds find no candidates.
+	if !(min <= max) {
+		return &candidates{each: func(func(string)) {}}
+	}
+
+	lo := sort.Search(len(di.sorted), func(i int) bool {
+		return di.sorted[i].value >= min
+	})
+	hi := sort.Search(len(di.sorted), func(i int) bool {
+		return di.sorted[i].value > max
+	})
+	entries := di.sorted[lo:hi]
+	return &candidates{
+		size: len(entries),
+		each: func(visit func(string)) {
+			for _, e := range entries {
+				visit(e.id)
+			}
+		},
+	}
+}
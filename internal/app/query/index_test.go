@@ -0,0 +1,328 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/filter/testcases"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestTicketIndexMeetsCriteria(t *testing.T) {
+	check := func(t *testing.T, tc testcases.TestCase, wantIncluded bool) {
+		pf, err := filter.NewPoolFilter(tc.Pool)
+		require.Nil(t, err)
+
+		tc.Ticket.Id = "test"
+		tc.Ticket.CreateTime = ptypes.TimestampNow()
+		ti := newTicketIndex()
+		ti.add(tc.Ticket)
+		ti.sort()
+
+		ids := queryIndex(ti, pf)
+		if wantIncluded {
+			require.Equal(t, []string{"test"}, ids)
+		} else {
+			require.Empty(t, ids)
+		}
+	}
+
+	for _, tc := range testcases.IncludedTestCases() {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			check(t, tc, true)
+		})
+	}
+
+	for _, tc := range testcases.ExcludedTestCases() {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			check(t, tc, false)
+		})
+	}
+}
+
+func TestTicketIndexRemove(t *testing.T) {
+	ti := newTicketIndex()
+	tickets := generateTickets(rand.New(rand.NewSource(1)), 100)
+	for _, ticket := range tickets {
+		ti.add(ticket)
+	}
+	ti.sort()
+
+	for _, ticket := range tickets {
+		ti.remove(ticket.Id)
+	}
+	ti.remove("missing")
+	ti.sort()
+
+	require.Empty(t, ti.tickets)
+	require.Empty(t, ti.doubles)
+	require.Empty(t, ti.strings)
+	require.Empty(t, ti.tags)
+}
+
+func TestDoubleIndexChangesBeforeSort(t *testing.T) {
+	di := newDoubleIndex()
+	di.add("a", 3)
+	di.add("b", 1)
+	di.add("c", 2)
+	di.sort()
+
+	// Changed value.
+	di.remove("a")
+	di.add("a", 0)
+	// Added and removed before sorting.
+	di.add("d", 5)
+	di.remove("d")
+	// NaN values aren't sorted.
+	di.add("e", math.NaN())
+	di.remove("c")
+	di.add("c", 1)
+	di.sort()
+
+	require.Equal(t, []doubleEntry{{0, "a"}, {1, "b"}, {1, "c"}}, di.sorted)
+
+	di.remove("e")
+	di.remove("b")
+	di.sort()
+	require.Equal(t, []doubleEntry{{0, "a"}, {1, "c"}}, di.sorted)
+	require.Len(t, di.values, 2)
+}
+
+// TestTicketIndexMatchesScan checks that indexed queries return the same
+// tickets as scanning every ticket, as tickets are added and removed.
+func TestTicketIndexMatchesScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ti := newTicketIndex()
+	tickets := generateTickets(r, 2000)
+
+	for round := 0; round < 5; round++ {
+		for _, ticket := range tickets[round*400 : (round+1)*400] {
+			ti.add(ticket)
+		}
+		for id := range ti.tickets {
+			if r.Intn(4) == 0 {
+				ti.remove(id)
+			}
+		}
+		ti.sort()
+
+		for _, di := range ti.doubles {
+			require.Len(t, di.sorted, len(di.values))
+			require.True(t, sort.SliceIsSorted(di.sorted, func(i, j int) bool {
+				return di.sorted[i].less(di.sorted[j])
+			}))
+		}
+
+		for i := 0; i < 50; i++ {
+			pf, err := filter.NewPoolFilter(generatePool(r))
+			require.Nil(t, err)
+
+			want := []string{}
+			for id, ticket := range ti.tickets {
+				if pf.In(ticket) {
+					want = append(want, id)
+				}
+			}
+			sort.Strings(want)
+
+			require.Equal(t, want, queryIndex(ti, pf))
+		}
+	}
+}
+
+func BenchmarkQueryScan(b *testing.B) {
+	benchmarkQuery(b, func(ti *ticketIndex, pf *filter.PoolFilter) int {
+		count := 0
+		for _, ticket := range ti.tickets {
+			if pf.In(ticket) {
+				count++
+			}
+		}
+		return count
+	})
+}
+
+func BenchmarkQueryIndex(b *testing.B) {
+	benchmarkQuery(b, func(ti *ticketIndex, pf *filter.PoolFilter) int {
+		count := 0
+		ti.query(pf, func(*pb.Ticket) {
+			count++
+		})
+		return count
+	})
+}
+
+// BenchmarkIndexRefresh measures cache refreshes which remove and re-add some
+// of the tickets in a large index.
+func BenchmarkIndexRefresh(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	tickets := generateTickets(r, 100000)
+	ti := newTicketIndex()
+	for _, ticket := range tickets {
+		ti.add(ticket)
+	}
+	ti.sort()
+
+	for _, changes := range []int{10, 1000, 10000} {
+		b.Run(fmt.Sprintf("%dChanges", changes), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				changed := tickets[(i*changes)%len(tickets):]
+				if len(changed) > changes {
+					changed = changed[:changes]
+				}
+				for _, ticket := range changed {
+					ti.remove(ticket.Id)
+				}
+				for _, ticket := range changed {
+					ti.add(ticket)
+				}
+				ti.sort()
+			}
+		})
+	}
+}
+
+func benchmarkQuery(b *testing.B, query func(*ticketIndex, *filter.PoolFilter) int) {
+	r := rand.New(rand.NewSource(1))
+	ti := newTicketIndex()
+	for _, ticket := range generateTickets(r, 100000) {
+		ti.add(ticket)
+	}
+	ti.sort()
+
+	pools := []struct {
+		name string
+		pool *pb.Pool
+	}{
+		{
+			"narrowDoubleRange",
+			&pb.Pool{
+				DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "skill", Min: 40, Max: 42}},
+			},
+		},
+		{
+			"stringEqualsAndTag",
+			&pb.Pool{
+				StringEqualsFilters: []*pb.StringEqualsFilter{{StringArg: "region", Value: "region2"}},
+				TagPresentFilters:   []*pb.TagPresentFilter{{Tag: "mode3"}},
+			},
+		},
+		{
+			"wideDoubleRangeStringInAndTag",
+			&pb.Pool{
+				DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "skill", Min: 0, Max: 100}},
+				StringInFilters:    []*pb.StringInFilter{{StringArg: "region", Values: []string{"region0", "region1"}}},
+				TagPresentFilters:  []*pb.TagPresentFilter{{Tag: "mode1"}},
+			},
+		},
+	}
+	for _, p := range pools {
+		pf, err := filter.NewPoolFilter(p.pool)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(p.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				query(ti, pf)
+			}
+		})
+	}
+}
+
+func queryIndex(ti *ticketIndex, pf *filter.PoolFilter) []string {
+	ids := []string{}
+	ti.query(pf, func(ticket *pb.Ticket) {
+		ids = append(ids, ticket.Id)
+	})
+	sort.Strings(ids)
+	return ids
+}
+
+func generateTickets(r *rand.Rand, n int) []*pb.Ticket {
+	tickets := make([]*pb.Ticket, n)
+	for i := range tickets {
+		s := &pb.SearchFields{
+			DoubleArgs: map[string]float64{
+				"skill": float64(r.Intn(100)),
+			},
+			StringArgs: map[string]string{
+				"region": fmt.Sprintf("region%d", r.Intn(4)),
+			},
+			Tags: []string{fmt.Sprintf("mode%d", r.Intn(5))},
+		}
+		if r.Intn(2) == 0 {
+			s.DoubleArgs["latency"] = r.Float64() * 100
+		}
+		if r.Intn(2) == 0 {
+			s.Tags = append(s.Tags, "beginner")
+		}
+		tickets[i] = &pb.Ticket{
+			Id:           fmt.Sprintf("ticket%d", i),
+			SearchFields: s,
+		}
+	}
+	return tickets
+}
+
+func generatePool(r *rand.Rand) *pb.Pool {
+	pool := &pb.Pool{}
+	if r.Intn(2) == 0 {
+		min := float64(r.Intn(100))
+		pool.DoubleRangeFilters = append(pool.DoubleRangeFilters, &pb.DoubleRangeFilter{
+			DoubleArg: []string{"skill", "latency", "missing"}[r.Intn(3)],
+			Min:       min,
+			Max:       min + float64(r.Intn(20)),
+		})
+	}
+	if r.Intn(2) == 0 {
+		pool.StringEqualsFilters = append(pool.StringEqualsFilters, &pb.StringEqualsFilter{
+			StringArg: "region",
+			Value:     fmt.Sprintf("region%d", r.Intn(5)),
+		})
+	}
+	if r.Intn(3) == 0 {
+		pool.StringInFilters = append(pool.StringInFilters, &pb.StringInFilter{
+			StringArg: "region",
+			Values:    []string{fmt.Sprintf("region%d", r.Intn(5)), fmt.Sprintf("region%d", r.Intn(5))},
+		})
+	}
+	if r.Intn(3) == 0 {
+		pool.StringNotEqualsFilters = append(pool.StringNotEqualsFilters, &pb.StringNotEqualsFilter{
+			StringArg: "region",
+			Value:     fmt.Sprintf("region%d", r.Intn(5)),
+		})
+	}
+	if r.Intn(2) == 0 {
+		pool.TagPresentFilters = append(pool.TagPresentFilters, &pb.TagPresentFilter{
+			Tag: fmt.Sprintf("mode%d", r.Intn(6)),
+		})
+	}
+	if r.Intn(3) == 0 {
+		pool.TagAbsentFilters = append(pool.TagAbsentFilters, &pb.TagAbsentFilter{
+			Tag: "beginner",
+		})
+	}
+	return pool
+}
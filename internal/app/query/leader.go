@@ -0,0 +1,331 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opencensus.io/stats"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/ipb"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// ticketCacheSourceConfigName sets where the ticket cache is read from:
+	// "statestore" (the default) reads state storage, and "leader" follows the
+	// ticket cache of the query service at api.queryleader, so that state
+	// storage is read once however many query services there are.
+	ticketCacheSourceConfigName = "ticketCacheSource"
+
+	ticketCacheSourceStatestore = "statestore"
+	ticketCacheSourceLeader     = "leader"
+
+	// ticketCacheLeaderConfigName sets whether the query service serves the
+	// TicketCache service, for query services following its ticket cache.  It
+	// is off by default, as the TicketCache service is internal and would
+	// otherwise be served alongside the public QueryService.
+	ticketCacheLeaderConfigName = "ticketCacheLeader"
+)
+
+func getTicketCacheSource(cfg config.View) (string, error) {
+	const defaultVal = ticketCacheSourceStatestore
+
+	if !cfg.IsSet(ticketCacheSourceConfigName) {
+		return defaultVal, nil
+	}
+	switch source := strings.ToLower(cfg.GetString(ticketCacheSourceConfigName)); source {
+	case ticketCacheSourceStatestore, ticketCacheSourceLeader:
+		return source, nil
+	default:
+		return "", status.Errorf(codes.FailedPrecondition, "%s has unsupported value %q, must be statestore or leader", ticketCacheSourceConfigName, source)
+	}
+}
+
+func getTicketCacheLeader(cfg config.View) bool {
+	const defaultVal = false
+
+	if !cfg.IsSet(ticketCacheLeaderConfigName) {
+		return defaultVal
+	}
+	return cfg.GetBool(ticketCacheLeaderConfigName)
+}
+
+func getTicketCacheHistoryLength(cfg config.View) int {
+	const (
+		name = "ticketCacheHistoryLength"
+		// Default number of ticket cache versions whose changes are kept, so
+		// that query services following this one can be sent the changes
+		// since their version instead of every ticket.
+		defaultVal = 100
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultVal
+	}
+	return cfg.GetInt(name)
+}
+
+func getTicketCacheLeaderTimeout(cfg config.View) time.Duration {
+	const (
+		name = "ticketCacheLeaderTimeout"
+		// Default time to wait for the leader's changes, after which the
+		// update fails and the next one starts over from a snapshot.
+		defaultVal = 10 * time.Second
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultVal
+	}
+	return cfg.GetDuration(name)
+}
+
+// GetTicketChanges refreshes the ticket cache, unless it is fresh enough for
+// the caller's max staleness, and sends the changes since the caller's
+// version, or every ticket if the caller's version is too old or from another
+// cache.  Callers refreshing at the same time share a single refresh.
+func (s *queryService) GetTicketChanges(req *ipb.GetTicketChangesRequest, stream ipb.TicketCache_GetTicketChangesServer) error {
+	ctx := stream.Context()
+	maxStaleness, err := getMaxStaleness(req)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	var version int64
+	var snapshot bool
+	var tickets []*pb.Ticket
+	var removed []string
+	updateTime, err := s.tc.request(ctx, maxStaleness, func(ti *ticketIndex) {
+		id, version = s.tc.id, s.tc.version
+		snapshot, tickets, removed = s.tc.changesSince(ti, req.GetCacheId(), req.GetVersion())
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to run request.")
+		return err
+	}
+	updateTimeProto, err := ptypes.TimestampProto(updateTime)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid update time: %v", err)
+	}
+
+	pSize := getPageSize(s.cfg)
+	start := 0
+	for {
+		end := start + pSize
+		if end > len(tickets) {
+			end = len(tickets)
+		}
+
+		err := stream.Send(&ipb.GetTicketChangesResponse{
+			CacheId:    id,
+			Version:    version,
+			Snapshot:   snapshot,
+			Tickets:    tickets[start:end],
+			RemovedIds: removed,
+			UpdateTime: updateTimeProto,
+			Complete:   end == len(tickets),
+		})
+		if err != nil {
+			return err
+		}
+		if end == len(tickets) {
+			return nil
+		}
+		start = end
+		removed = nil
+	}
+}
+
+// cacheVersion holds the ids of the tickets changed by the update which
+// brought the ticket cache to the version.
+type cacheVersion struct {
+	version int64
+	changed map[string]struct{}
+}
+
+// recordVersion starts a new version of the cache if the update changed any
+// tickets, keeping the ids of the changed tickets in the history.
+func (tc *ticketCache) recordVersion(changed map[string]struct{}) {
+	if len(changed) == 0 {
+		return
+	}
+
+	tc.version++
+	tc.history = append(tc.history, cacheVersion{version: tc.version, changed: changed})
+	if l := getTicketCacheHistoryLength(tc.cfg); len(tc.history) > l {
+		tc.history = tc.history[len(tc.history)-l:]
+	}
+}
+
+// changesSince returns the tickets added or updated, and the ids of the
+// tickets removed, since the given version of the cache.  If the changes are
+// no longer in the history, every ticket is returned as a snapshot.
+func (tc *ticketCache) changesSince(ti *ticketIndex, id string, version int64) (bool, []*pb.Ticket, []string) {
+	if id != tc.id || version > tc.version || len(tc.history) == 0 || version < tc.history[0].version-1 {
+		tickets := make([]*pb.Ticket, 0, len(ti.tickets))
+		for _, t := range ti.tickets {
+			tickets = append(tickets, t)
+		}
+		return true, tickets, nil
+	}
+
+	changed := make(map[string]struct{})
+	for _, v := range tc.history {
+		if v.version > version {
+			for id := range v.changed {
+				changed[id] = struct{}{}
+			}
+		}
+	}
+
+	var tickets []*pb.Ticket
+	var removed []string
+	for id := range changed {
+		if t, ok := ti.tickets[id]; ok {
+			tickets = append(tickets, t)
+		} else {
+			removed = append(removed, id)
+		}
+	}
+	return false, tickets, removed
+}
+
+// updateFromLeader brings the cache up to date with the leader's cache,
+// returning the ids of the tickets it changed.  The leader only refreshes its
+// own cache if it is staler than maxStaleness, and fails the update if it
+// doesn't send every change within ticketCacheLeaderTimeout.
+func (tc *ticketCache) updateFromLeader(maxStaleness time.Duration) map[string]struct{} {
+	st := time.Now()
+	previousCount := len(tc.tickets.tickets)
+	changed := make(map[string]struct{})
+
+	// The changes of a failed update may be partially applied, so the next
+	// update starts over from a snapshot.
+	fail := func(err error) map[string]struct{} {
+		tc.leaderID = ""
+		tc.leaderVersion = 0
+		tc.err = err
+		return changed
+	}
+
+	req := &ipb.GetTicketChangesRequest{
+		CacheId: tc.leaderID,
+		Version: tc.leaderVersion,
+	}
+	if maxStaleness > 0 {
+		req.MaxStaleness = ptypes.DurationProto(maxStaleness)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), getTicketCacheLeaderTimeout(tc.cfg))
+	defer cancel()
+	stream, err := tc.leader.getTicketChanges(ctx, req)
+	if err != nil {
+		return fail(err)
+	}
+
+	fetched := 0
+	first := true
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return fail(status.Error(codes.Unavailable, "ticket cache leader ended the changes before they were complete"))
+		}
+		if err != nil {
+			return fail(err)
+		}
+
+		if first && resp.GetSnapshot() {
+			for id := range tc.tickets.tickets {
+				changed[id] = struct{}{}
+			}
+			tc.tickets = newTicketIndex()
+			stats.Record(context.Background(), cacheResyncs.M(1))
+		}
+		first = false
+
+		for _, t := range resp.GetTickets() {
+			tc.tickets.remove(t.GetId())
+			tc.tickets.add(t)
+			changed[t.GetId()] = struct{}{}
+		}
+		for _, id := range resp.GetRemovedIds() {
+			tc.tickets.remove(id)
+			changed[id] = struct{}{}
+		}
+		fetched += len(resp.GetTickets())
+
+		if resp.GetComplete() {
+			updateTime, err := ptypes.Timestamp(resp.GetUpdateTime())
+			if err != nil {
+				return fail(status.Errorf(codes.Internal, "invalid update time from ticket cache leader: %v", err))
+			}
+			tc.leaderID = resp.GetCacheId()
+			tc.leaderVersion = resp.GetVersion()
+			tc.updatedAt = updateTime
+			break
+		}
+	}
+
+	stats.Record(context.Background(), cacheTotalItems.M(int64(previousCount)))
+	stats.Record(context.Background(), cacheFetchedItems.M(int64(fetched)))
+	stats.Record(context.Background(), cacheUpdateLatency.M(float64(time.Since(st))/float64(time.Millisecond)))
+
+	logger.Debugf("Ticket Cache update from leader: Previous %d, Changed %d, Fetched %d, Current %d", previousCount, len(changed), fetched, len(tc.tickets.tickets))
+	tc.err = nil
+	return changed
+}
+
+// leaderClient calls the TicketCache service of the query service which the
+// ticket cache follows.
+type leaderClient struct {
+	cacher *config.Cacher
+}
+
+func newLeaderClient(cfg config.View) *leaderClient {
+	newInstance := func(cfg config.View) (interface{}, func(), error) {
+		conn, err := rpc.GRPCClientFromConfig(cfg, "api.queryleader")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		close := func() {
+			err := conn.Close()
+			if err != nil {
+				logger.WithError(err).Warning("Error closing ticket cache leader client.")
+			}
+		}
+
+		return ipb.NewTicketCacheClient(conn), close, nil
+	}
+
+	return &leaderClient{
+		cacher: config.NewCacher(cfg, newInstance),
+	}
+}
+
+func (lc *leaderClient) getTicketChanges(ctx context.Context, req *ipb.GetTicketChangesRequest) (ipb.TicketCache_GetTicketChangesClient, error) {
+	client, err := lc.cacher.Get()
+	if err != nil {
+		return nil, err
+	}
+	return client.(ipb.TicketCacheClient).GetTicketChanges(ctx, req)
+}
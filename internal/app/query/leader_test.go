@@ -0,0 +1,259 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/ipb"
+	"open-match.dev/open-match/internal/statestore"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestGetTicketCacheSource(t *testing.T) {
+	cfg := viper.New()
+	source, err := getTicketCacheSource(cfg)
+	require.Nil(t, err)
+	require.Equal(t, ticketCacheSourceStatestore, source)
+
+	cfg.Set("ticketCacheSource", "Leader")
+	source, err = getTicketCacheSource(cfg)
+	require.Nil(t, err)
+	require.Equal(t, ticketCacheSourceLeader, source)
+
+	cfg.Set("ticketCacheSource", "redis")
+	_, err = getTicketCacheSource(cfg)
+	require.NotNil(t, err)
+}
+
+func TestGetTicketCacheLeader(t *testing.T) {
+	cfg := viper.New()
+	require.False(t, getTicketCacheLeader(cfg))
+
+	cfg.Set("ticketCacheLeader", true)
+	require.True(t, getTicketCacheLeader(cfg))
+}
+
+func TestGetTicketCacheLeaderTimeout(t *testing.T) {
+	cfg := viper.New()
+	require.Equal(t, 10*time.Second, getTicketCacheLeaderTimeout(cfg))
+
+	cfg.Set("ticketCacheLeaderTimeout", "1s")
+	require.Equal(t, time.Second, getTicketCacheLeaderTimeout(cfg))
+}
+
+func TestTicketCacheChangesSince(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("ticketCacheHistoryLength", 2)
+	tc := &ticketCache{
+		cfg:     cfg,
+		tickets: newTicketIndex(),
+		id:      "leader",
+	}
+
+	changes := func(id string, version int64) (bool, []string, []string) {
+		snapshot, tickets, removed := tc.changesSince(tc.tickets, id, version)
+		ids := []string{}
+		for _, t := range tickets {
+			ids = append(ids, t.Id)
+		}
+		sort.Strings(ids)
+		sort.Strings(removed)
+		return snapshot, ids, append([]string{}, removed...)
+	}
+	set := func(ids ...string) map[string]struct{} {
+		s := make(map[string]struct{})
+		for _, id := range ids {
+			s[id] = struct{}{}
+		}
+		return s
+	}
+
+	tc.tickets.add(&pb.Ticket{Id: "1"})
+	tc.tickets.add(&pb.Ticket{Id: "2"})
+	tc.recordVersion(set("1", "2"))
+	// Updates which change nothing don't start a version.
+	tc.recordVersion(set())
+	require.Equal(t, int64(1), tc.version)
+
+	snapshot, tickets, removed := changes("", 0)
+	require.True(t, snapshot)
+	require.Equal(t, []string{"1", "2"}, tickets)
+	require.Equal(t, []string{}, removed)
+
+	tc.tickets.remove("1")
+	tc.tickets.add(&pb.Ticket{Id: "3"})
+	tc.recordVersion(set("1", "3"))
+
+	snapshot, tickets, removed = changes("leader", 1)
+	require.False(t, snapshot)
+	require.Equal(t, []string{"3"}, tickets)
+	require.Equal(t, []string{"1"}, removed)
+
+	snapshot, tickets, removed = changes("leader", 2)
+	require.False(t, snapshot)
+	require.Equal(t, []string{}, tickets)
+	require.Equal(t, []string{}, removed)
+
+	// Versions from another cache, or whose changes have left the history,
+	// are sent a snapshot.
+	snapshot, tickets, _ = changes("other", 1)
+	require.True(t, snapshot)
+	require.Equal(t, []string{"2", "3"}, tickets)
+
+	tc.tickets.add(&pb.Ticket{Id: "4"})
+	tc.recordVersion(set("4"))
+	require.Len(t, tc.history, 2)
+
+	snapshot, _, _ = changes("leader", 0)
+	require.True(t, snapshot)
+	snapshot, tickets, removed = changes("leader", 1)
+	require.False(t, snapshot)
+	require.Equal(t, []string{"3", "4"}, tickets)
+	require.Equal(t, []string{"1"}, removed)
+}
+
+func TestTicketCacheFollowsLeader(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+	cfg.Set("queryPageSize", 10)
+
+	store := statestore.New(cfg)
+	defer store.Close()
+	leader := &ticketCache{
+		store:           store,
+		cfg:             cfg,
+		requests:        make(chan *cacheRequest),
+		startRunRequest: make(chan struct{}, 1),
+		tickets:         newTicketIndex(),
+		id:              "leader",
+	}
+	leader.startRunRequest <- struct{}{}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err)
+	s := grpc.NewServer()
+	ipb.RegisterTicketCacheServer(s, &queryService{cfg: cfg, tc: leader, store: store})
+	go s.Serve(l)
+	defer s.Stop()
+
+	followerCfg := viper.New()
+	followerCfg.Set("api.queryleader.hostname", "localhost")
+	followerCfg.Set("api.queryleader.grpcport", l.Addr().(*net.TCPAddr).Port)
+	follower := &ticketCache{
+		cfg:     followerCfg,
+		tickets: newTicketIndex(),
+		leader:  newLeaderClient(followerCfg),
+	}
+
+	create := func(id string, tags ...string) {
+		ticket := &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{Tags: tags}}
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+		require.Nil(t, store.IndexTicket(ctx, ticket))
+	}
+	requireFollows := func(ids ...string) {
+		follower.update(0)
+		require.Nil(t, follower.err)
+		cached := []string{}
+		for id := range follower.tickets.tickets {
+			cached = append(cached, id)
+		}
+		sort.Strings(cached)
+		require.Equal(t, ids, cached)
+		require.Equal(t, leader.version, follower.leaderVersion)
+		require.False(t, follower.updatedAt.IsZero())
+	}
+
+	// Enough tickets to be sent over several pages.
+	ids := []string{}
+	for i := 0; i < 25; i++ {
+		id := string(rune('a' + i))
+		create(id)
+		ids = append(ids, id)
+	}
+	requireFollows(ids...)
+	require.Equal(t, "leader", follower.leaderID)
+
+	// Changes are applied to the follower's index, which queries read.
+	create("z1", "ranked")
+	require.Nil(t, store.DeindexTicket(ctx, "a"))
+	requireFollows(append(ids[1:], "z1")...)
+	require.Len(t, follower.tickets.tags["ranked"], 1)
+
+	_, err = store.UpdateTicket(ctx, &pb.Ticket{Id: "z1"})
+	require.Nil(t, err)
+	requireFollows(append(ids[1:], "z1")...)
+	require.Empty(t, follower.tickets.tags["ranked"])
+
+	// A restarted leader has a new cache id, so the follower starts over.
+	leader.id = "restarted"
+	require.Nil(t, store.DeindexTicket(ctx, "b"))
+	requireFollows(append(ids[2:], "z1")...)
+	require.Equal(t, "restarted", follower.leaderID)
+
+	// The leader isn't refreshed for followers whose queries allow its cache's
+	// staleness.
+	create("z2")
+	follower.update(time.Minute)
+	require.Nil(t, follower.err)
+	require.NotContains(t, follower.tickets.tickets, "z2")
+	requireFollows(append(ids[2:], "z1", "z2")...)
+}
+
+// hungLeader is a ticket cache leader which never sends any changes.
+type hungLeader struct{}
+
+func (hungLeader) GetTicketChanges(req *ipb.GetTicketChangesRequest, stream ipb.TicketCache_GetTicketChangesServer) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestTicketCacheLeaderTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	s := grpc.NewServer()
+	ipb.RegisterTicketCacheServer(s, hungLeader{})
+	go s.Serve(l)
+	defer s.Stop()
+
+	cfg := viper.New()
+	cfg.Set("api.queryleader.hostname", "127.0.0.1")
+	cfg.Set("api.queryleader.grpcport", l.Addr().(*net.TCPAddr).Port)
+	cfg.Set("ticketCacheLeaderTimeout", "100ms")
+	follower := &ticketCache{
+		cfg:           cfg,
+		tickets:       newTicketIndex(),
+		leader:        newLeaderClient(cfg),
+		leaderID:      "leader",
+		leaderVersion: 3,
+	}
+
+	// The update fails once the timeout passes, and the next one starts over
+	// from a snapshot.
+	follower.update(0)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(follower.err))
+	require.Empty(t, follower.leaderID)
+	require.Zero(t, follower.leaderVersion)
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// GetPoolStats counts the tickets in each pool, along with the oldest ticket's
+// create time and any requested histograms.  Every pool is counted under the
+// same ticket cache request, so the statistics are consistent with each other.
+func (s *queryService) GetPoolStats(ctx context.Context, req *pb.GetPoolStatsRequest) (*pb.GetPoolStatsResponse, error) {
+	if len(req.GetPools()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, ".pools is required")
+	}
+
+	pfs := make([]*filter.PoolFilter, 0, len(req.GetPools()))
+	for _, pool := range req.GetPools() {
+		pf, err := filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+		pfs = append(pfs, pf)
+	}
+	for i, h := range req.GetHistograms() {
+		if h.GetDoubleArg() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, ".histograms[%d].double_arg is required", i)
+		}
+		for j := 1; j < len(h.GetBounds()); j++ {
+			if !(h.GetBounds()[j-1] < h.GetBounds()[j]) {
+				return nil, status.Errorf(codes.InvalidArgument, ".histograms[%d].bounds must be in ascending order", i)
+			}
+		}
+	}
+
+	resp := &pb.GetPoolStatsResponse{}
+	_, err := s.tc.request(ctx, 0, func(ti *ticketIndex) {
+		for i, pf := range pfs {
+			resp.Pools = append(resp.Pools, poolStats(ti, req.GetPools()[i].GetName(), pf, req.GetHistograms()))
+		}
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to run request.")
+		return nil, err
+	}
+	return resp, nil
+}
+
+// poolStats computes the statistics of the tickets in the index which are in
+// the pool filter.
+func poolStats(ti *ticketIndex, name string, pf *filter.PoolFilter, histograms []*pb.HistogramRequest) *pb.PoolStats {
+	ps := &pb.PoolStats{PoolName: name}
+	for _, h := range histograms {
+		ps.Histograms = append(ps.Histograms, &pb.Histogram{
+			DoubleArg: h.GetDoubleArg(),
+			Bounds:    h.GetBounds(),
+			Counts:    make([]int32, len(h.GetBounds())+1),
+		})
+	}
+
+	ti.query(pf, func(t *pb.Ticket) {
+		ps.TicketCount++
+
+		if ct := t.GetCreateTime(); ct != nil {
+			oldest := ps.OldestCreateTime
+			if oldest == nil || ct.GetSeconds() < oldest.GetSeconds() ||
+				(ct.GetSeconds() == oldest.GetSeconds() && ct.GetNanos() < oldest.GetNanos()) {
+				ps.OldestCreateTime = ct
+			}
+		}
+
+		for _, h := range ps.Histograms {
+			v, ok := t.GetSearchFields().GetDoubleArgs()[h.DoubleArg]
+			if !ok {
+				h.Missing++
+				continue
+			}
+			// The number of bounds at or below v is the index of its bucket.
+			h.Counts[sort.Search(len(h.Bounds), func(i int) bool { return v < h.Bounds[i] })]++
+		}
+	})
+	return ps
+}
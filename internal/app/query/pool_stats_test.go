@@ -0,0 +1,121 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestPoolStats(t *testing.T) {
+	ti := newTicketIndex()
+	for _, ticket := range []*pb.Ticket{
+		{
+			Id:           "a",
+			CreateTime:   &timestamp.Timestamp{Seconds: 3},
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 5}, Tags: []string{"ranked"}},
+		},
+		{
+			Id:           "b",
+			CreateTime:   &timestamp.Timestamp{Seconds: 1, Nanos: 5},
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 10}, Tags: []string{"ranked"}},
+		},
+		{
+			Id:           "c",
+			CreateTime:   &timestamp.Timestamp{Seconds: 1},
+			SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 25}},
+		},
+		{
+			Id:           "d",
+			CreateTime:   &timestamp.Timestamp{Seconds: 2},
+			SearchFields: &pb.SearchFields{Tags: []string{"ranked"}},
+		},
+	} {
+		ti.add(ticket)
+	}
+	ti.sort()
+
+	histograms := []*pb.HistogramRequest{
+		{DoubleArg: "mmr", Bounds: []float64{10, 20}},
+		{DoubleArg: "mmr"},
+	}
+
+	tests := []struct {
+		description string
+		pool        *pb.Pool
+		expected    *pb.PoolStats
+	}{
+		{
+			description: "every ticket",
+			pool:        &pb.Pool{Name: "all"},
+			expected: &pb.PoolStats{
+				PoolName:         "all",
+				TicketCount:      4,
+				OldestCreateTime: &timestamp.Timestamp{Seconds: 1},
+				Histograms: []*pb.Histogram{
+					{DoubleArg: "mmr", Bounds: []float64{10, 20}, Counts: []int32{1, 1, 1}, Missing: 1},
+					{DoubleArg: "mmr", Counts: []int32{3}, Missing: 1},
+				},
+			},
+		},
+		{
+			description: "filtered",
+			pool: &pb.Pool{
+				Name:              "ranked",
+				TagPresentFilters: []*pb.TagPresentFilter{{Tag: "ranked"}},
+			},
+			expected: &pb.PoolStats{
+				PoolName:         "ranked",
+				TicketCount:      3,
+				OldestCreateTime: &timestamp.Timestamp{Seconds: 1, Nanos: 5},
+				Histograms: []*pb.Histogram{
+					{DoubleArg: "mmr", Bounds: []float64{10, 20}, Counts: []int32{1, 1, 0}, Missing: 1},
+					{DoubleArg: "mmr", Counts: []int32{2}, Missing: 1},
+				},
+			},
+		},
+		{
+			description: "empty",
+			pool: &pb.Pool{
+				Name:              "empty",
+				TagPresentFilters: []*pb.TagPresentFilter{{Tag: "casual"}},
+			},
+			expected: &pb.PoolStats{
+				PoolName:    "empty",
+				TicketCount: 0,
+				Histograms: []*pb.Histogram{
+					{DoubleArg: "mmr", Bounds: []float64{10, 20}, Counts: []int32{0, 0, 0}},
+					{DoubleArg: "mmr", Counts: []int32{0}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			pf, err := filter.NewPoolFilter(test.pool)
+			require.Nil(t, err)
+
+			actual := poolStats(ti, test.pool.Name, pf, histograms)
+			require.True(t, proto.Equal(test.expected, actual), "expected %v, got %v", test.expected, actual)
+		})
+	}
+}
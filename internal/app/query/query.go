@@ -19,16 +19,20 @@ import (
 	"go.opencensus.io/stats/view"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
+	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/telemetry"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 var (
 	ticketsPerQuery     = stats.Int64("open-match.dev/query/tickets_per_query", "Number of tickets per query", stats.UnitDimensionless)
+	backfillsPerQuery   = stats.Int64("open-match.dev/query/backfills_per_query", "Number of backfills per query", stats.UnitDimensionless)
 	cacheTotalItems     = stats.Int64("open-match.dev/query/total_cache_items", "Total number of tickets query service cached", stats.UnitDimensionless)
 	cacheFetchedItems   = stats.Int64("open-match.dev/query/fetched_items", "Number of fetched items in total", stats.UnitDimensionless)
 	cacheWaitingQueries = stats.Int64("open-match.dev/query/waiting_queries", "Number of waiting queries in the last update", stats.UnitDimensionless)
 	cacheUpdateLatency  = stats.Float64("open-match.dev/query/update_latency", "Time elapsed of each query cache update", stats.UnitMilliseconds)
+	cacheResyncs        = stats.Int64("open-match.dev/query/cache_resyncs", "Number of times the ticket cache was rebuilt from a snapshot", stats.UnitDimensionless)
+	cacheSkippedUpdates = stats.Int64("open-match.dev/query/skipped_cache_updates", "Number of times requests were served from a cache fresh enough for their max staleness", stats.UnitDimensionless)
 
 	ticketsPerQueryView = &view.View{
 		Measure:     ticketsPerQuery,
@@ -36,6 +40,12 @@ var (
 		Description: "Tickets per query",
 		Aggregation: telemetry.DefaultCountDistribution,
 	}
+	backfillsPerQueryView = &view.View{
+		Measure:     backfillsPerQuery,
+		Name:        "open-match.dev/query/backfills_per_query",
+		Description: "Backfills per query",
+		Aggregation: telemetry.DefaultCountDistribution,
+	}
 	cacheTotalItemsView = &view.View{
 		Measure:     cacheTotalItems,
 		Name:        "open-match.dev/query/total_cached_items",
@@ -66,25 +76,50 @@ var (
 		Description: "Time elapsed of each query cache update",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
+	cacheResyncsView = &view.View{
+		Measure:     cacheResyncs,
+		Name:        "open-match.dev/query/cache_resyncs",
+		Description: "Total number of times the ticket cache was rebuilt from a snapshot",
+		Aggregation: view.Sum(),
+	}
+	cacheSkippedUpdatesView = &view.View{
+		Measure:     cacheSkippedUpdates,
+		Name:        "open-match.dev/query/skipped_cache_updates",
+		Description: "Total number of times requests were served from a cache fresh enough for their max staleness",
+		Aggregation: view.Sum(),
+	}
 )
 
 // BindService creates the query service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	tc, err := newTicketCache(b, p.Config())
+	if err != nil {
+		return err
+	}
 	service := &queryService{
-		cfg: p.Config(),
-		tc:  newTicketCache(b, p.Config()),
+		cfg:   p.Config(),
+		tc:    tc,
+		store: tc.store,
 	}
 
 	b.AddHandleFunc(func(s *grpc.Server) {
 		pb.RegisterQueryServiceServer(s, service)
 	}, pb.RegisterQueryServiceHandlerFromEndpoint)
+	if getTicketCacheLeader(p.Config()) {
+		b.AddHandleFunc(func(s *grpc.Server) {
+			ipb.RegisterTicketCacheServer(s, service)
+		}, nil)
+	}
 	b.RegisterViews(
 		ticketsPerQueryView,
+		backfillsPerQueryView,
 		cacheTotalItemsView,
 		cacheUpdateView,
 		cacheFetchedItemsView,
 		cacheWaitingQueriesView,
 		cacheUpdateLatencyView,
+		cacheResyncsView,
+		cacheSkippedUpdatesView,
 	)
 	return nil
 }
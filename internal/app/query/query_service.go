@@ -21,7 +21,10 @@ import (
 
 	"go.opencensus.io/stats"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/pkg/errors"
+	"github.com/rs/xid"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -42,8 +45,9 @@ var (
 // queryService API provides utility functions for common MMF functionality such
 // as retreiving Tickets from state storage.
 type queryService struct {
-	cfg config.View
-	tc  *ticketCache
+	cfg   config.View
+	tc    *ticketCache
+	store statestore.Service
 }
 
 func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer pb.QueryService_QueryTicketsServer) error {
@@ -57,20 +61,31 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 	if err != nil {
 		return err
 	}
+	selection, err := newTicketSelection(req)
+	if err != nil {
+		return err
+	}
+	maxStaleness, err := getMaxStaleness(req)
+	if err != nil {
+		return err
+	}
 
 	var results []*pb.Ticket
-	err = s.tc.request(ctx, func(tickets map[string]*pb.Ticket) {
-		for _, ticket := range tickets {
-			if pf.In(ticket) {
-				results = append(results, ticket)
-			}
-		}
+	snapshotTime, err := s.tc.request(ctx, maxStaleness, func(ti *ticketIndex) {
+		ti.query(pf, func(ticket *pb.Ticket) {
+			results = append(results, ticket)
+		})
 	})
 	if err != nil {
 		logger.WithError(err).Error("Failed to run request.")
 		return err
 	}
+	results = selection.apply(results)
 	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	snapshotTimeProto, err := ptypes.TimestampProto(snapshotTime)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid snapshot time: %v", err)
+	}
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -80,7 +95,8 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 		}
 
 		err := responseServer.Send(&pb.QueryTicketsResponse{
-			Tickets: results[start:end],
+			Tickets:      results[start:end],
+			SnapshotTime: snapshotTimeProto,
 		})
 		if err != nil {
 			return err
@@ -101,20 +117,35 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 	if err != nil {
 		return err
 	}
+	selection, err := newTicketSelection(req)
+	if err != nil {
+		return err
+	}
+	maxStaleness, err := getMaxStaleness(req)
+	if err != nil {
+		return err
+	}
 
-	var results []string
-	err = s.tc.request(ctx, func(tickets map[string]*pb.Ticket) {
-		for id, ticket := range tickets {
-			if pf.In(ticket) {
-				results = append(results, id)
-			}
-		}
+	var tickets []*pb.Ticket
+	snapshotTime, err := s.tc.request(ctx, maxStaleness, func(ti *ticketIndex) {
+		ti.query(pf, func(ticket *pb.Ticket) {
+			tickets = append(tickets, ticket)
+		})
 	})
 	if err != nil {
 		logger.WithError(err).Error("Failed to run request.")
 		return err
 	}
+	tickets = selection.apply(tickets)
+	results := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		results = append(results, ticket.Id)
+	}
 	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	snapshotTimeProto, err := ptypes.TimestampProto(snapshotTime)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid snapshot time: %v", err)
+	}
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -124,7 +155,64 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 		}
 
 		err := responseServer.Send(&pb.QueryTicketIdsResponse{
-			Ids: results[start:end],
+			Ids:          results[start:end],
+			SnapshotTime: snapshotTimeProto,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryBackfills gets a list of Backfills that match all Filters of the input
+// Pool.  Backfills are read directly from state storage rather than the ticket
+// cache.
+func (s *queryService) QueryBackfills(req *pb.QueryBackfillsRequest, responseServer pb.QueryService_QueryBackfillsServer) error {
+	ctx := responseServer.Context()
+	pool := req.GetPool()
+	if pool == nil {
+		return status.Error(codes.InvalidArgument, ".pool is required")
+	}
+
+	pf, err := filter.NewPoolFilter(pool)
+	if err != nil {
+		return err
+	}
+
+	idSet, err := s.store.GetIndexedBackfillIDSet(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get indexed backfills.")
+		return err
+	}
+	ids := make([]string, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	backfills, err := s.store.GetBackfills(ctx, ids)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get backfills.")
+		return err
+	}
+
+	var results []*pb.Backfill
+	for _, backfill := range backfills {
+		if pf.In(backfill) {
+			results = append(results, backfill)
+		}
+	}
+	stats.Record(ctx, backfillsPerQuery.M(int64(len(results))))
+
+	pSize := getPageSize(s.cfg)
+	for start := 0; start < len(results); start += pSize {
+		end := start + pSize
+		if end > len(results) {
+			end = len(results)
+		}
+
+		err := responseServer.Send(&pb.QueryBackfillsResponse{
+			Backfills: results[start:end],
 		})
 		if err != nil {
 			return err
@@ -134,6 +222,54 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 	return nil
 }
 
+// ExplainTicket reports which of the profile's pools the ticket falls into,
+// which filter excludes it from each of the other pools, and whether it is
+// pending release.  It reads the ticket from state storage rather than the
+// cache, so it reflects changes the cache hasn't picked up yet.
+func (s *queryService) ExplainTicket(ctx context.Context, req *pb.ExplainTicketRequest) (*pb.ExplainTicketResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".ticket_id is required")
+	}
+	if req.GetProfile() == nil {
+		return nil, status.Error(codes.InvalidArgument, ".profile is required")
+	}
+
+	ticket, err := s.store.GetTicket(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ExplainTicketResponse{Ticket: ticket}
+	for _, pool := range req.GetProfile().GetPools() {
+		pf, err := filter.NewPoolFilter(pool)
+		if err != nil {
+			return nil, err
+		}
+		excludedBy := pf.Explain(ticket)
+		resp.Pools = append(resp.Pools, &pb.PoolExplanation{
+			PoolName:   pool.GetName(),
+			Included:   excludedBy == "",
+			ExcludedBy: excludedBy,
+		})
+	}
+
+	proposed, ok, err := s.store.GetPendingReleaseTime(ctx, ticket.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// The ticket stays in pending release after the timeout until it is
+	// released, but is active again.
+	if expireTime := proposed.Add(s.cfg.GetDuration("pendingReleaseTimeout")); ok && time.Now().Before(expireTime) {
+		resp.PendingRelease = true
+		resp.PendingReleaseExpireTime, err = ptypes.TimestampProto(expireTime)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid pending release expire time: %v", err)
+		}
+	}
+
+	return resp, nil
+}
+
 func getPageSize(cfg config.View) int {
 	const (
 		name = "queryPageSize"
@@ -166,6 +302,23 @@ func getPageSize(cfg config.View) int {
 	return pSize
 }
 
+// getMaxStaleness returns the request's max_staleness, or 0 if it has none.
+func getMaxStaleness(req interface {
+	GetMaxStaleness() *duration.Duration
+}) (time.Duration, error) {
+	if req.GetMaxStaleness() == nil {
+		return 0, nil
+	}
+	maxStaleness, err := ptypes.Duration(req.GetMaxStaleness())
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid .max_staleness: %v", err)
+	}
+	if maxStaleness < 0 {
+		return 0, status.Error(codes.InvalidArgument, ".max_staleness must not be negative")
+	}
+	return maxStaleness, nil
+}
+
 /////////////////////////////////////////////////////////////////////
 /////////////////////////////////////////////////////////////////////
 
@@ -173,6 +326,7 @@ func getPageSize(cfg config.View) int {
 // gives a safe view into that map cache.
 type ticketCache struct {
 	store statestore.Service
+	cfg   config.View
 
 	requests chan *cacheRequest
 
@@ -185,40 +339,88 @@ type ticketCache struct {
 
 	// Mutlithreaded unsafe fields, only to be written by update, and read when
 	// request given the ok.
-	tickets map[string]*pb.Ticket
+	tickets *ticketIndex
 	err     error
+	// updatedAt is the time the last successful update started, which the
+	// cached tickets are at least as fresh as.
+	updatedAt time.Time
+
+	// cursor is the position in the store's ticket change log which indexed
+	// and pending reflect, or empty if the cache must resync from a snapshot.
+	cursor  string
+	indexed map[string]struct{}
+	pending map[string]time.Time
+	// expiring holds the cached tickets which have an expire time.  The
+	// synchronizer deletes expired tickets periodically, so the cache drops
+	// them itself once they expire rather than waiting for the deletion.
+	expiring map[string]*pb.Ticket
+
+	watchersLock sync.Mutex
+	// watchers are told the ids of the tickets each update changes.
+	watchers map[*poolWatcher]struct{}
+
+	// id identifies this cache to the query services which follow it, and
+	// version counts the updates which changed it.  history holds the ids of
+	// the tickets changed by the latest updates.
+	id      string
+	version int64
+	history []cacheVersion
+
+	// leader is the query service this cache follows, or nil if the cache
+	// reads state storage itself.  leaderID and leaderVersion are the
+	// leader's cache id and version which the cache reflects.
+	leader        *leaderClient
+	leaderID      string
+	leaderVersion int64
 }
 
-func newTicketCache(b *appmain.Bindings, cfg config.View) *ticketCache {
+func newTicketCache(b *appmain.Bindings, cfg config.View) (*ticketCache, error) {
+	source, err := getTicketCacheSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	tc := &ticketCache{
 		store:           statestore.New(cfg),
+		cfg:             cfg,
 		requests:        make(chan *cacheRequest),
 		startRunRequest: make(chan struct{}, 1),
-		tickets:         make(map[string]*pb.Ticket),
+		tickets:         newTicketIndex(),
+		watchers:        make(map[*poolWatcher]struct{}),
+		id:              xid.New().String(),
+	}
+	if source == ticketCacheSourceLeader {
+		tc.leader = newLeaderClient(cfg)
 	}
 
 	tc.startRunRequest <- struct{}{}
 	b.AddHealthCheckFunc(tc.store.HealthCheck)
 
-	return tc
+	return tc, nil
 }
 
 type cacheRequest struct {
-	ctx    context.Context
-	runNow chan struct{}
+	ctx context.Context
+	// maxStaleness is how long ago the cache may have been updated for the
+	// request to be served without updating it.  0 always updates the cache.
+	maxStaleness time.Duration
+	runNow       chan struct{}
 }
 
-func (tc *ticketCache) request(ctx context.Context, f func(map[string]*pb.Ticket)) error {
+// request calls f with the cached tickets once the cache is fresh enough for
+// maxStaleness, returning the time the cache was updated.
+func (tc *ticketCache) request(ctx context.Context, maxStaleness time.Duration, f func(*ticketIndex)) (time.Time, error) {
 	cr := &cacheRequest{
-		ctx:    ctx,
-		runNow: make(chan struct{}),
+		ctx:          ctx,
+		maxStaleness: maxStaleness,
+		runNow:       make(chan struct{}),
 	}
 
 sendRequest:
 	for {
 		select {
 		case <-ctx.Done():
-			return errors.Wrap(ctx.Err(), "ticket cache request canceled before reuest sent.")
+			return time.Time{}, errors.Wrap(ctx.Err(), "ticket cache request canceled before reuest sent.")
 		case <-tc.startRunRequest:
 			go tc.runRequest()
 		case tc.requests <- cr:
@@ -228,17 +430,17 @@ sendRequest:
 
 	select {
 	case <-ctx.Done():
-		return errors.Wrap(ctx.Err(), "ticket cache request canceled waiting for access.")
+		return time.Time{}, errors.Wrap(ctx.Err(), "ticket cache request canceled waiting for access.")
 	case <-cr.runNow:
 		defer tc.wg.Done()
 	}
 
 	if tc.err != nil {
-		return tc.err
+		return time.Time{}, tc.err
 	}
 
 	f(tc.tickets)
-	return nil
+	return tc.updatedAt, nil
 }
 
 func (tc *ticketCache) runRequest() {
@@ -260,8 +462,12 @@ collectAllWaiting:
 		}
 	}
 
-	tc.update()
-	stats.Record(context.Background(), cacheWaitingQueries.M(int64(len(reqs))))
+	if tc.freshFor(reqs) {
+		stats.Record(context.Background(), cacheSkippedUpdates.M(1))
+	} else {
+		tc.update(minStaleness(reqs))
+		stats.Record(context.Background(), cacheWaitingQueries.M(int64(len(reqs))))
+	}
 
 	// Send WaitGroup to query calls, letting them run their query on the ticket
 	// cache.
@@ -278,46 +484,239 @@ collectAllWaiting:
 	tc.wg.Wait()
 }
 
-func (tc *ticketCache) update() {
+// freshFor reports whether the cache was updated recently enough to serve
+// every one of the requests without updating it again.
+func (tc *ticketCache) freshFor(reqs []*cacheRequest) bool {
+	if tc.err != nil || tc.updatedAt.IsZero() {
+		return false
+	}
+	staleness := time.Since(tc.updatedAt)
+	for _, req := range reqs {
+		if req.maxStaleness <= 0 || staleness > req.maxStaleness {
+			return false
+		}
+	}
+	return true
+}
+
+// minStaleness returns the smallest staleness any of the requests allow.
+func minStaleness(reqs []*cacheRequest) time.Duration {
+	var min time.Duration
+	for i, req := range reqs {
+		if req.maxStaleness <= 0 {
+			return 0
+		}
+		if i == 0 || req.maxStaleness < min {
+			min = req.maxStaleness
+		}
+	}
+	return min
+}
+
+func (tc *ticketCache) addWatcher(w *poolWatcher) {
+	tc.watchersLock.Lock()
+	defer tc.watchersLock.Unlock()
+	tc.watchers[w] = struct{}{}
+}
+
+func (tc *ticketCache) removeWatcher(w *poolWatcher) {
+	tc.watchersLock.Lock()
+	defer tc.watchersLock.Unlock()
+	delete(tc.watchers, w)
+}
+
+// notifyWatchers tells the watchers the ids of the tickets an update changed.
+func (tc *ticketCache) notifyWatchers(changed map[string]struct{}) {
+	tc.watchersLock.Lock()
+	defer tc.watchersLock.Unlock()
+	for w := range tc.watchers {
+		w.update(changed)
+	}
+}
+
+// update refreshes the cache.  maxStaleness is the staleness the requests
+// being served allow, which is passed on to the leader when following one.
+func (tc *ticketCache) update(maxStaleness time.Duration) {
+	var changed map[string]struct{}
+	if tc.leader != nil {
+		changed = tc.updateFromLeader(maxStaleness)
+	} else {
+		changed = tc.updateFromStore()
+	}
+
+	// Keep the index consistent even if the update failed partway, and pass on
+	// the changes which were applied.
+	tc.tickets.sort()
+	tc.recordVersion(changed)
+	tc.notifyWatchers(changed)
+}
+
+// updateFromStore brings the cache up to date with state storage, returning
+// the ids of the tickets it changed.
+func (tc *ticketCache) updateFromStore() map[string]struct{} {
 	st := time.Now()
-	previousCount := len(tc.tickets)
+	previousCount := len(tc.tickets.tickets)
+
+	if tc.expiring == nil {
+		tc.expiring = make(map[string]*pb.Ticket)
+	}
 
-	currentAll, err := tc.store.GetIndexedIDSet(context.Background())
+	changed, err := tc.applyChanges(context.Background())
 	if err != nil {
 		tc.err = err
-		return
+		return nil
 	}
 
-	deletedCount := 0
-	for id := range tc.tickets {
-		if _, ok := currentAll[id]; !ok {
-			delete(tc.tickets, id)
-			deletedCount++
+	// Tickets leave pending release once the timeout passes, without an entry
+	// in the change log.
+	releaseTime := st.Add(-tc.cfg.GetDuration("pendingReleaseTimeout"))
+	for id, proposed := range tc.pending {
+		if !proposed.After(releaseTime) {
+			delete(tc.pending, id)
+			changed[id] = struct{}{}
 		}
 	}
 
+	deletedCount := 0
 	toFetch := []string{}
-
-	for id := range currentAll {
-		if _, ok := tc.tickets[id]; !ok {
+	for id := range changed {
+		_, indexed := tc.indexed[id]
+		_, pending := tc.pending[id]
+		_, cached := tc.tickets.tickets[id]
+		switch {
+		case cached && (!indexed || pending):
+			tc.tickets.remove(id)
+			deletedCount++
+		case !cached && indexed && !pending:
 			toFetch = append(toFetch, id)
 		}
 	}
 
 	newTickets, err := tc.store.GetTickets(context.Background(), toFetch)
 	if err != nil {
+		// The changes have already been applied, so the tickets wouldn't be
+		// fetched by the next update.
+		tc.cursor = ""
 		tc.err = err
-		return
+		return changed
 	}
 
 	for _, t := range newTickets {
-		tc.tickets[t.Id] = t
+		tc.tickets.add(t)
+		if t.GetExpireTime() != nil {
+			tc.expiring[t.GetId()] = t
+		}
 	}
+	deletedCount += tc.removeExpired(st, changed)
 
 	stats.Record(context.Background(), cacheTotalItems.M(int64(previousCount)))
 	stats.Record(context.Background(), cacheFetchedItems.M(int64(len(toFetch))))
 	stats.Record(context.Background(), cacheUpdateLatency.M(float64(time.Since(st))/float64(time.Millisecond)))
 
-	logger.Debugf("Ticket Cache update: Previous %d, Deleted %d, Fetched %d, Current %d", previousCount, deletedCount, len(toFetch), len(tc.tickets))
+	logger.Debugf("Ticket Cache update: Previous %d, Deleted %d, Fetched %d, Current %d", previousCount, deletedCount, len(toFetch), len(tc.tickets.tickets))
 	tc.err = nil
+	tc.updatedAt = st
+	return changed
+}
+
+// removeExpired removes the cached tickets whose expire time has passed,
+// adding their ids to changed, and returns how many it removed.
+func (tc *ticketCache) removeExpired(now time.Time, changed map[string]struct{}) int {
+	removed := 0
+	for id, t := range tc.expiring {
+		if tc.tickets.tickets[id] != t {
+			// The ticket was removed or updated since it was cached.
+			delete(tc.expiring, id)
+			continue
+		}
+		expireTime, err := ptypes.Timestamp(t.GetExpireTime())
+		if err == nil && now.Before(expireTime) {
+			continue
+		}
+		tc.tickets.remove(id)
+		delete(tc.expiring, id)
+		changed[id] = struct{}{}
+		removed++
+	}
+	return removed
+}
+
+// applyChanges brings indexed and pending up to date by reading the store's
+// ticket change log, or from a new snapshot when the cache has no cursor or
+// has fallen too far behind.  It returns the ids of tickets which may need to
+// be added to or removed from the cache.
+func (tc *ticketCache) applyChanges(ctx context.Context) (map[string]struct{}, error) {
+	if tc.cursor != "" {
+		changes, cursor, err := tc.store.GetTicketChanges(ctx, tc.cursor)
+		if err == nil {
+			changed := make(map[string]struct{})
+			for _, c := range changes {
+				tc.applyChange(c, changed)
+			}
+			tc.cursor = cursor
+			return changed, nil
+		}
+		if status.Code(err) != codes.OutOfRange {
+			return nil, err
+		}
+		logger.WithError(err).Info("Ticket cache fell behind the ticket change log, resyncing.")
+	}
+
+	snapshot, err := tc.store.GetTicketSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats.Record(ctx, cacheResyncs.M(1))
+
+	// Every ticket which was or is now cached may have changed.
+	changed := make(map[string]struct{}, len(snapshot.Indexed))
+	for id := range tc.tickets.tickets {
+		changed[id] = struct{}{}
+	}
+	for id := range snapshot.Indexed {
+		changed[id] = struct{}{}
+	}
+	tc.indexed = snapshot.Indexed
+	tc.pending = snapshot.Pending
+	tc.cursor = snapshot.Cursor
+	return changed, nil
+}
+
+// applyChange applies a single change from the ticket change log, adding the
+// ids of the tickets it affects to changed.
+func (tc *ticketCache) applyChange(c *statestore.TicketChange, changed map[string]struct{}) {
+	switch c.Kind {
+	case statestore.TicketsIndexed:
+		for _, id := range c.IDs {
+			tc.indexed[id] = struct{}{}
+			// Indexing a ticket again means it has been updated, so drop
+			// the cached copy to have it fetched again.
+			tc.tickets.remove(id)
+		}
+	case statestore.TicketsDeindexed:
+		for _, id := range c.IDs {
+			delete(tc.indexed, id)
+		}
+	case statestore.TicketsAddedToPendingRelease:
+		for _, id := range c.IDs {
+			tc.pending[id] = c.Time
+		}
+	case statestore.TicketsDeletedFromPendingRelease:
+		for _, id := range c.IDs {
+			delete(tc.pending, id)
+		}
+	case statestore.TicketsUpdated:
+		for _, id := range c.IDs {
+			tc.tickets.remove(id)
+		}
+	case statestore.AllTicketsReleased:
+		for id := range tc.pending {
+			changed[id] = struct{}{}
+		}
+		tc.pending = make(map[string]time.Time)
+	}
+
+	for _, id := range c.IDs {
+		changed[id] = struct{}{}
+	}
 }
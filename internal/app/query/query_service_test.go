@@ -15,11 +15,20 @@
 package query
 
 import (
+	"fmt"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/statestore"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
 )
 
 func TestGetPageSize(t *testing.T) {
@@ -66,3 +75,159 @@ func TestGetPageSize(t *testing.T) {
 		})
 	}
 }
+
+func TestTicketCacheUpdate(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+	cfg.Set("statestore.ticketChangeLogLength", 5)
+	cfg.Set("pendingReleaseTimeout", "100ms")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+	tc := &ticketCache{
+		store:   store,
+		cfg:     cfg,
+		tickets: newTicketIndex(),
+	}
+
+	create := func(ids ...string) {
+		for _, id := range ids {
+			ticket := &pb.Ticket{Id: id}
+			require.Nil(t, store.CreateTicket(ctx, ticket))
+			require.Nil(t, store.IndexTicket(ctx, ticket))
+		}
+	}
+	requireCached := func(ids ...string) {
+		tc.update(0)
+		require.Nil(t, tc.err)
+		cached := []string{}
+		for id := range tc.tickets.tickets {
+			cached = append(cached, id)
+		}
+		sort.Strings(cached)
+		sort.Strings(ids)
+		require.Equal(t, ids, cached)
+	}
+
+	create("1", "2", "3")
+	requireCached("1", "2", "3")
+
+	// Changes are applied from the change log.
+	require.Nil(t, store.AddTicketsToPendingRelease(ctx, []string{"1"}))
+	require.Nil(t, store.DeindexTicket(ctx, "2"))
+	create("4")
+	requireCached("3", "4")
+
+	// Pending release times out without a change.
+	time.Sleep(150 * time.Millisecond)
+	requireCached("1", "3", "4")
+
+	require.Nil(t, store.AddTicketsToPendingRelease(ctx, []string{"1", "3"}))
+	requireCached("4")
+	require.Nil(t, store.ReleaseAllTickets(ctx))
+	requireCached("1", "3", "4")
+
+	// Falling behind the change log resyncs from a snapshot.
+	cursor := tc.cursor
+	ids := []string{}
+	for i := 5; i < 20; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	create(ids...)
+	require.Nil(t, store.DeindexTicket(ctx, "1"))
+	_, _, err := store.GetTicketChanges(ctx, cursor)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	requireCached(append(ids, "3", "4")...)
+}
+
+func TestTicketCacheExpiry(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+	tc := &ticketCache{
+		store:   store,
+		cfg:     cfg,
+		tickets: newTicketIndex(),
+	}
+
+	expireTime, err := ptypes.TimestampProto(time.Now().Add(50 * time.Millisecond))
+	require.Nil(t, err)
+	for _, ticket := range []*pb.Ticket{{Id: "1", ExpireTime: expireTime}, {Id: "2"}} {
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+		require.Nil(t, store.IndexTicket(ctx, ticket))
+	}
+
+	tc.update(0)
+	require.Nil(t, tc.err)
+	require.Len(t, tc.tickets.tickets, 2)
+
+	time.Sleep(100 * time.Millisecond)
+	tc.update(0)
+	require.Nil(t, tc.err)
+	require.Len(t, tc.tickets.tickets, 1)
+	require.Contains(t, tc.tickets.tickets, "2")
+
+	// The cache only reads the store, leaving the expired ticket for the
+	// synchronizer to delete.
+	expired, err := store.DeleteExpiredTickets(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"1"}, expired)
+}
+
+func TestTicketCacheMaxStaleness(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+	tc := &ticketCache{
+		store:           store,
+		cfg:             cfg,
+		requests:        make(chan *cacheRequest),
+		startRunRequest: make(chan struct{}, 1),
+		tickets:         newTicketIndex(),
+	}
+	tc.startRunRequest <- struct{}{}
+
+	create := func(id string) {
+		ticket := &pb.Ticket{Id: id}
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+		require.Nil(t, store.IndexTicket(ctx, ticket))
+	}
+	requireCached := func(maxStaleness time.Duration, ids ...string) time.Time {
+		cached := []string{}
+		snapshotTime, err := tc.request(ctx, maxStaleness, func(ti *ticketIndex) {
+			for id := range ti.tickets {
+				cached = append(cached, id)
+			}
+		})
+		require.Nil(t, err)
+		sort.Strings(cached)
+		require.Equal(t, ids, cached)
+		return snapshotTime
+	}
+
+	create("1")
+	// The first request updates the cache, as it has never been updated.
+	first := requireCached(time.Hour, "1")
+	require.False(t, first.IsZero())
+
+	// A fresh enough cache is served without updating it.
+	create("2")
+	require.Equal(t, first, requireCached(time.Hour, "1"))
+
+	// Requests without a max staleness, or with one the cache is too stale
+	// for, update it.
+	time.Sleep(10 * time.Millisecond)
+	second := requireCached(time.Millisecond, "1", "2")
+	require.True(t, second.After(first))
+
+	create("3")
+	third := requireCached(0, "1", "2", "3")
+	require.True(t, third.After(second))
+}
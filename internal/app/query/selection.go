@@ -0,0 +1,118 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"math/rand"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// selectionRequest is the part of a query request which selects which of the
+// matching tickets are returned, and in what order.
+type selectionRequest interface {
+	GetMaxResults() int32
+	GetOrder() *pb.TicketOrder
+	GetSample() bool
+}
+
+// ticketSelection selects the tickets a query returns from the tickets which
+// meet the pool's filtering criteria.
+type ticketSelection struct {
+	maxResults int
+	order      *pb.TicketOrder
+	sample     bool
+}
+
+func newTicketSelection(req selectionRequest) (*ticketSelection, error) {
+	if req.GetMaxResults() < 0 {
+		return nil, status.Error(codes.InvalidArgument, ".max_results must not be negative")
+	}
+	if req.GetSample() && req.GetMaxResults() == 0 {
+		return nil, status.Error(codes.InvalidArgument, ".sample requires .max_results")
+	}
+
+	switch req.GetOrder().GetField() {
+	case pb.TicketOrder_UNORDERED, pb.TicketOrder_CREATE_TIME:
+	case pb.TicketOrder_DOUBLE_ARG:
+		if req.GetOrder().GetDoubleArg() == "" {
+			return nil, status.Error(codes.InvalidArgument, ".order.double_arg is required to order by double_arg")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, ".order.field %v is not supported", req.GetOrder().GetField())
+	}
+
+	return &ticketSelection{
+		maxResults: int(req.GetMaxResults()),
+		order:      req.GetOrder(),
+		sample:     req.GetSample(),
+	}, nil
+}
+
+// apply returns the selected tickets, reordering tickets in place.
+func (s *ticketSelection) apply(tickets []*pb.Ticket) []*pb.Ticket {
+	limited := s.maxResults > 0 && len(tickets) > s.maxResults
+
+	if s.sample && limited {
+		// Partial Fisher-Yates shuffle, moving a uniform sample to the front.
+		for i := 0; i < s.maxResults; i++ {
+			j := i + rand.Intn(len(tickets)-i)
+			tickets[i], tickets[j] = tickets[j], tickets[i]
+		}
+		tickets = tickets[:s.maxResults]
+		limited = false
+	}
+
+	if s.order.GetField() != pb.TicketOrder_UNORDERED {
+		sort.SliceStable(tickets, func(i, j int) bool {
+			return s.less(tickets[i], tickets[j])
+		})
+	}
+
+	if limited {
+		tickets = tickets[:s.maxResults]
+	}
+	return tickets
+}
+
+// less reports whether ticket a comes before ticket b in the order.  Ties are
+// broken by id, so that the order is stable between queries.
+func (s *ticketSelection) less(a, b *pb.Ticket) bool {
+	switch s.order.GetField() {
+	case pb.TicketOrder_CREATE_TIME:
+		at, bt := a.GetCreateTime(), b.GetCreateTime()
+		if at.GetSeconds() != bt.GetSeconds() {
+			return (at.GetSeconds() < bt.GetSeconds()) != s.order.GetDescending()
+		}
+		if at.GetNanos() != bt.GetNanos() {
+			return (at.GetNanos() < bt.GetNanos()) != s.order.GetDescending()
+		}
+	case pb.TicketOrder_DOUBLE_ARG:
+		arg := s.order.GetDoubleArg()
+		av, aok := a.GetSearchFields().GetDoubleArgs()[arg]
+		bv, bok := b.GetSearchFields().GetDoubleArgs()[arg]
+		if aok != bok {
+			// Tickets without the value come last in either direction.
+			return aok
+		}
+		if aok && av != bv {
+			return (av < bv) != s.order.GetDescending()
+		}
+	}
+	return a.GetId() < b.GetId()
+}
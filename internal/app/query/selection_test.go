@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestTicketSelectionInvalid(t *testing.T) {
+	for _, req := range []*pb.QueryTicketsRequest{
+		{MaxResults: -1},
+		{Sample: true},
+		{Order: &pb.TicketOrder{Field: pb.TicketOrder_DOUBLE_ARG}},
+		{Order: &pb.TicketOrder{Field: 100}},
+	} {
+		_, err := newTicketSelection(req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+	}
+}
+
+func TestTicketSelectionOrder(t *testing.T) {
+	tickets := func() []*pb.Ticket {
+		return []*pb.Ticket{
+			{Id: "a", CreateTime: &timestamp.Timestamp{Seconds: 3}},
+			{Id: "b", CreateTime: &timestamp.Timestamp{Seconds: 1, Nanos: 5}, SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 10}}},
+			{Id: "c", CreateTime: &timestamp.Timestamp{Seconds: 1}, SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 30}}},
+			{Id: "d", CreateTime: &timestamp.Timestamp{Seconds: 2}, SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"mmr": 20}}},
+		}
+	}
+
+	tests := []struct {
+		description string
+		req         *pb.QueryTicketsRequest
+		expected    []string
+	}{
+		{
+			description: "unordered",
+			req:         &pb.QueryTicketsRequest{},
+			expected:    []string{"a", "b", "c", "d"},
+		},
+		{
+			description: "max results",
+			req:         &pb.QueryTicketsRequest{MaxResults: 2},
+			expected:    []string{"a", "b"},
+		},
+		{
+			description: "oldest",
+			req: &pb.QueryTicketsRequest{
+				MaxResults: 3,
+				Order:      &pb.TicketOrder{Field: pb.TicketOrder_CREATE_TIME},
+			},
+			expected: []string{"c", "b", "d"},
+		},
+		{
+			description: "newest",
+			req: &pb.QueryTicketsRequest{
+				Order: &pb.TicketOrder{Field: pb.TicketOrder_CREATE_TIME, Descending: true},
+			},
+			expected: []string{"a", "d", "b", "c"},
+		},
+		{
+			description: "double arg",
+			req: &pb.QueryTicketsRequest{
+				Order: &pb.TicketOrder{Field: pb.TicketOrder_DOUBLE_ARG, DoubleArg: "mmr"},
+			},
+			expected: []string{"b", "d", "c", "a"},
+		},
+		{
+			description: "double arg descending",
+			req: &pb.QueryTicketsRequest{
+				Order: &pb.TicketOrder{Field: pb.TicketOrder_DOUBLE_ARG, DoubleArg: "mmr", Descending: true},
+			},
+			expected: []string{"c", "d", "b", "a"},
+		},
+		{
+			description: "sample of every ticket",
+			req: &pb.QueryTicketsRequest{
+				MaxResults: 10,
+				Sample:     true,
+				Order:      &pb.TicketOrder{Field: pb.TicketOrder_CREATE_TIME},
+			},
+			expected: []string{"c", "b", "d", "a"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			s, err := newTicketSelection(test.req)
+			require.Nil(t, err)
+
+			var ids []string
+			for _, ticket := range s.apply(tickets()) {
+				ids = append(ids, ticket.Id)
+			}
+			require.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestTicketSelectionSample(t *testing.T) {
+	s, err := newTicketSelection(&pb.QueryTicketsRequest{
+		MaxResults: 10,
+		Sample:     true,
+		Order:      &pb.TicketOrder{Field: pb.TicketOrder_CREATE_TIME},
+	})
+	require.Nil(t, err)
+
+	seen := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		var tickets []*pb.Ticket
+		for j := 0; j < 100; j++ {
+			tickets = append(tickets, &pb.Ticket{
+				Id:         fmt.Sprintf("%02d", j),
+				CreateTime: &timestamp.Timestamp{Seconds: int64(j)},
+			})
+		}
+
+		sample := s.apply(tickets)
+		require.Len(t, sample, 10)
+		for j, ticket := range sample {
+			if j > 0 {
+				require.True(t, sample[j-1].Id < ticket.Id)
+			}
+			seen[ticket.Id]++
+		}
+	}
+
+	// Sampling every time from the front or the back would leave most tickets
+	// unseen.
+	require.True(t, len(seen) > 80, "%d", len(seen))
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// WatchPool sends a snapshot of the tickets in the pool, and then the tickets
+// which enter and leave the pool as the ticket cache is updated, until the
+// stream is canceled.
+func (s *queryService) WatchPool(req *pb.WatchPoolRequest, responseServer pb.QueryService_WatchPoolServer) error {
+	ctx := responseServer.Context()
+	pool := req.GetPool()
+	if pool == nil {
+		return status.Error(codes.InvalidArgument, ".pool is required")
+	}
+
+	pf, err := filter.NewPoolFilter(pool)
+	if err != nil {
+		return err
+	}
+
+	// Watching before the snapshot is taken means no update is missed.
+	w := newPoolWatcher(pf)
+	s.tc.addWatcher(w)
+	defer s.tc.removeWatcher(w)
+
+	interval := getWatchPoolInterval(s.cfg)
+	pSize := getPageSize(s.cfg)
+	first := true
+	for {
+		var added []*pb.Ticket
+		var removed []string
+		snapshotTime, err := s.tc.request(ctx, interval, func(ti *ticketIndex) {
+			if first {
+				added = w.snapshot(ti)
+			} else {
+				added, removed = w.changes(ti)
+			}
+		})
+		if err != nil {
+			logger.WithError(err).Error("Failed to run request.")
+			return err
+		}
+
+		if first || len(added) > 0 || len(removed) > 0 {
+			err = sendWatchPoolResponses(responseServer, snapshotTime, added, removed, pSize)
+			if err != nil {
+				return err
+			}
+		}
+		first = false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.notify:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// sendWatchPoolResponses pages the changes by the page size, sending the
+// removed ids with the first page.
+func sendWatchPoolResponses(responseServer pb.QueryService_WatchPoolServer, snapshotTime time.Time, added []*pb.Ticket, removed []string, pSize int) error {
+	snapshotTimeProto, err := ptypes.TimestampProto(snapshotTime)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid snapshot time: %v", err)
+	}
+
+	start := 0
+	for {
+		end := start + pSize
+		if end > len(added) {
+			end = len(added)
+		}
+
+		err := responseServer.Send(&pb.WatchPoolResponse{
+			Tickets:      added[start:end],
+			RemovedIds:   removed,
+			SnapshotTime: snapshotTimeProto,
+			Complete:     end == len(added),
+		})
+		if err != nil {
+			return err
+		}
+		if end == len(added) {
+			return nil
+		}
+		start = end
+		removed = nil
+	}
+}
+
+func getWatchPoolInterval(cfg config.View) time.Duration {
+	const (
+		name = "watchPoolInterval"
+		// Default time between refreshes of the ticket cache by pool watchers,
+		// if no other requests refresh it.
+		defaultInterval = time.Second
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultInterval
+	}
+	interval := cfg.GetDuration(name)
+	if interval <= 0 {
+		logger.Infof("%s %v is not positive, using %v", name, interval, defaultInterval)
+		return defaultInterval
+	}
+	return interval
+}
+
+// poolWatcher tracks which tickets in the ticket cache are in a pool, so that
+// the changes to the pool can be found from the ids of the tickets each cache
+// update changed.
+type poolWatcher struct {
+	pf *filter.PoolFilter
+	// sent holds the tickets in the pool, as last sent.  Only accessed within
+	// a ticket cache request.
+	sent map[string]*pb.Ticket
+
+	// Holds a value when the ticket cache has been updated since the watcher
+	// last looked at its changes.
+	notify chan struct{}
+
+	m sync.Mutex
+	// changed holds the ids of tickets the ticket cache has updated since the
+	// watcher last looked at its changes.
+	changed map[string]struct{}
+}
+
+func newPoolWatcher(pf *filter.PoolFilter) *poolWatcher {
+	return &poolWatcher{
+		pf:      pf,
+		sent:    make(map[string]*pb.Ticket),
+		notify:  make(chan struct{}, 1),
+		changed: make(map[string]struct{}),
+	}
+}
+
+// update records the ids of tickets a ticket cache update changed.
+func (w *poolWatcher) update(changed map[string]struct{}) {
+	if len(changed) == 0 {
+		return
+	}
+
+	w.m.Lock()
+	for id := range changed {
+		w.changed[id] = struct{}{}
+	}
+	w.m.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *poolWatcher) takeChanged() map[string]struct{} {
+	w.m.Lock()
+	defer w.m.Unlock()
+	changed := w.changed
+	w.changed = make(map[string]struct{})
+	return changed
+}
+
+// snapshot returns every ticket in the pool.
+func (w *poolWatcher) snapshot(ti *ticketIndex) []*pb.Ticket {
+	// The snapshot already reflects every change so far.
+	w.takeChanged()
+
+	var tickets []*pb.Ticket
+	ti.query(w.pf, func(t *pb.Ticket) {
+		tickets = append(tickets, t)
+		w.sent[t.Id] = t
+	})
+	return tickets
+}
+
+// changes returns the tickets which entered the pool or were updated while in
+// it, and the ids of the tickets which left it, since the last call.
+func (w *poolWatcher) changes(ti *ticketIndex) ([]*pb.Ticket, []string) {
+	var added []*pb.Ticket
+	var removed []string
+	for id := range w.takeChanged() {
+		t, cached := ti.tickets[id]
+		last, wasSent := w.sent[id]
+		switch {
+		case cached && w.pf.In(t):
+			// The cache replaces tickets when they are updated, so the same
+			// ticket is unchanged.
+			if last != t {
+				added = append(added, t)
+				w.sent[id] = t
+			}
+		case wasSent:
+			removed = append(removed, id)
+			delete(w.sent, id)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/filter"
+	"open-match.dev/open-match/internal/statestore"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestPoolWatcher(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+	cfg.Set("pendingReleaseTimeout", "100ms")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+	tc := &ticketCache{
+		store:    store,
+		cfg:      cfg,
+		tickets:  newTicketIndex(),
+		watchers: make(map[*poolWatcher]struct{}),
+	}
+
+	pf, err := filter.NewPoolFilter(&pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "ranked"}},
+	})
+	require.Nil(t, err)
+	w := newPoolWatcher(pf)
+	tc.addWatcher(w)
+
+	index := func(id string, tags ...string) {
+		ticket := &pb.Ticket{Id: id, SearchFields: &pb.SearchFields{Tags: tags}}
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+		require.Nil(t, store.IndexTicket(ctx, ticket))
+	}
+	ids := func(tickets []*pb.Ticket) []string {
+		result := []string{}
+		for _, t := range tickets {
+			result = append(result, t.Id)
+		}
+		sort.Strings(result)
+		return result
+	}
+	requireChanges := func(expectedAdded, expectedRemoved []string) {
+		tc.update(0)
+		require.Nil(t, tc.err)
+		added, removed := w.changes(tc.tickets)
+		sort.Strings(removed)
+		require.Equal(t, expectedAdded, ids(added))
+		require.Equal(t, expectedRemoved, append([]string{}, removed...))
+	}
+
+	index("1", "ranked")
+	index("2")
+	tc.update(0)
+	require.Nil(t, tc.err)
+	require.Equal(t, []string{"1"}, ids(w.snapshot(tc.tickets)))
+	select {
+	case <-w.notify:
+	default:
+		require.Fail(t, "watcher not notified of update")
+	}
+
+	// Unchanged tickets aren't sent again.
+	requireChanges([]string{}, []string{})
+
+	index("3", "ranked")
+	index("4")
+	requireChanges([]string{"3"}, []string{})
+
+	// Updating a ticket sends it again if it's still in the pool, and removes
+	// it if not.
+	require.Nil(t, store.IndexTicket(ctx, &pb.Ticket{Id: "1"}))
+	index("5", "ranked")
+	_, err = store.UpdateTicket(ctx, &pb.Ticket{Id: "3"})
+	require.Nil(t, err)
+	requireChanges([]string{"1", "5"}, []string{"3"})
+
+	// Tickets leave the pool when deindexed, and while pending release.
+	require.Nil(t, store.AddTicketsToPendingRelease(ctx, []string{"5"}))
+	require.Nil(t, store.DeindexTicket(ctx, "1"))
+	requireChanges([]string{}, []string{"1", "5"})
+
+	// The ticket enters the pool again once pending release times out.
+	time.Sleep(150 * time.Millisecond)
+	requireChanges([]string{"5"}, []string{})
+
+	tc.removeWatcher(w)
+	index("6", "ranked")
+	tc.update(0)
+	require.Empty(t, w.takeChanged())
+}
@@ -43,18 +43,21 @@ type evaluator interface {
 	evaluate(context.Context, <-chan []*pb.Match, chan<- string) error
 }
 
-var errNoEvaluatorType = status.Errorf(codes.FailedPrecondition, "unable to determine evaluator type, either api.evaluator.grpcport or api.evaluator.httpport must be specified in the config")
+// defaultEvaluatorName is the evaluator used for profiles which match no
+// evaluator route.
+const defaultEvaluatorName = "evaluator"
 
-func newEvaluator(cfg config.View) evaluator {
+// newEvaluator returns the evaluator configured under api.<name>.
+func newEvaluator(cfg config.View, name string) evaluator {
 	newInstance := func(cfg config.View) (interface{}, func(), error) {
 		// grpc is preferred over http.
-		if cfg.IsSet("api.evaluator.grpcport") {
-			return newGrpcEvaluator(cfg)
+		if cfg.IsSet("api." + name + ".grpcport") {
+			return newGrpcEvaluator(cfg, name)
 		}
-		if cfg.IsSet("api.evaluator.httpport") {
-			return newHTTPEvaluator(cfg)
+		if cfg.IsSet("api." + name + ".httpport") {
+			return newHTTPEvaluator(cfg, name)
 		}
-		return nil, nil, errNoEvaluatorType
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "unable to determine evaluator type, either api.%s.grpcport or api.%s.httpport must be specified in the config", name, name)
 	}
 
 	return &deferredEvaluator{
@@ -83,15 +86,16 @@ type grcpEvaluatorClient struct {
 	evaluator pb.EvaluatorClient
 }
 
-func newGrpcEvaluator(cfg config.View) (evaluator, func(), error) {
-	grpcAddr := fmt.Sprintf("%s:%d", cfg.GetString("api.evaluator.hostname"), cfg.GetInt64("api.evaluator.grpcport"))
+func newGrpcEvaluator(cfg config.View, name string) (evaluator, func(), error) {
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.GetString("api."+name+".hostname"), cfg.GetInt64("api."+name+".grpcport"))
 	conn, err := rpc.GRPCClientFromEndpoint(cfg, grpcAddr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create grpc evaluator client: %w", err)
 	}
 
 	evaluatorClientLogger.WithFields(logrus.Fields{
-		"endpoint": grpcAddr,
+		"evaluator": name,
+		"endpoint":  grpcAddr,
 	}).Info("Created a GRPC client for evaluator endpoint.")
 
 	close := func() {
@@ -173,15 +177,16 @@ type httpEvaluatorClient struct {
 	baseURL    string
 }
 
-func newHTTPEvaluator(cfg config.View) (evaluator, func(), error) {
-	httpAddr := fmt.Sprintf("%s:%d", cfg.GetString("api.evaluator.hostname"), cfg.GetInt64("api.evaluator.httpport"))
+func newHTTPEvaluator(cfg config.View, name string) (evaluator, func(), error) {
+	httpAddr := fmt.Sprintf("%s:%d", cfg.GetString("api."+name+".hostname"), cfg.GetInt64("api."+name+".httpport"))
 	client, baseURL, err := rpc.HTTPClientFromEndpoint(cfg, httpAddr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get a HTTP client from the endpoint %v: %w", httpAddr, err)
 	}
 
 	evaluatorClientLogger.WithFields(logrus.Fields{
-		"endpoint": httpAddr,
+		"evaluator": name,
+		"endpoint":  httpAddr,
 	}).Info("Created a HTTP client for evaluator endpoint.")
 
 	close := func() {
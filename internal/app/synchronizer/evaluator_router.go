@@ -0,0 +1,253 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/stats"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// evaluatorRouter sends each proposal to the evaluator chosen for the
+// MatchProfile it was made for, and merges the evaluators' results.
+//
+// Routes are configured as a list of "<profile name pattern>=<evaluator>"
+// entries under evaluatorRoutes, checked in order.  Patterns use path.Match
+// syntax, and each evaluator is configured under api.<evaluator> the same way
+// as api.evaluator.  Profiles which match no route use api.evaluator.
+type evaluatorRouter struct {
+	cfg          config.View
+	routes       []evaluatorRoute
+	newEvaluator func(cfg config.View, name string) evaluator
+
+	m sync.Mutex
+	// evaluators holds the evaluators which have been used, by name.
+	evaluators map[string]evaluator
+}
+
+func newEvaluatorRouter(cfg config.View) (*evaluatorRouter, error) {
+	routes, err := getEvaluatorRoutes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &evaluatorRouter{
+		cfg:          cfg,
+		routes:       routes,
+		newEvaluator: newEvaluator,
+		evaluators:   make(map[string]evaluator),
+	}, nil
+}
+
+type evaluatorRoute struct {
+	pattern string
+	name    string
+}
+
+func getEvaluatorRoutes(cfg config.View) ([]evaluatorRoute, error) {
+	const name = "evaluatorRoutes"
+
+	if !cfg.IsSet(name) {
+		return nil, nil
+	}
+
+	var routes []evaluatorRoute
+	for _, r := range cfg.GetStringSlice(name) {
+		i := strings.LastIndex(r, "=")
+		if i < 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid evaluator route %q, must be of the form <profile name pattern>=<evaluator>", r)
+		}
+		route := evaluatorRoute{
+			pattern: strings.TrimSpace(r[:i]),
+			name:    strings.TrimSpace(r[i+1:]),
+		}
+		if _, err := path.Match(route.pattern, ""); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid evaluator route %q: %v", r, err)
+		}
+		if route.name == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid evaluator route %q, evaluator is empty", r)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// route returns the name of the evaluator for the profile.
+func route(routes []evaluatorRoute, profileName string) string {
+	for _, r := range routes {
+		if ok, _ := path.Match(r.pattern, profileName); ok {
+			return r.name
+		}
+	}
+	return defaultEvaluatorName
+}
+
+func (er *evaluatorRouter) get(name string) evaluator {
+	er.m.Lock()
+	defer er.m.Unlock()
+
+	e, ok := er.evaluators[name]
+	if !ok {
+		e = er.newEvaluator(er.cfg, name)
+		er.evaluators[name] = e
+	}
+	return e
+}
+
+// evaluate sends the proposals to their evaluators, and passes on the ids of
+// the accepted matches.  Each evaluator only deconflicts its own proposals,
+// so when a match shares tickets with a match already accepted by a different
+// evaluator, or the same backfill, it is dropped.  profileOf returns the name of the profile the
+// proposal with the match id was made for.
+func (er *evaluatorRouter) evaluate(ctx context.Context, profileOf func(matchID string) string, pc <-chan []*pb.Match, acceptedIds chan<- string) error {
+	if len(er.routes) == 0 {
+		return er.get(defaultEvaluatorName).evaluate(ctx, pc, acceptedIds)
+	}
+
+	type result struct {
+		evaluator string
+		matchID   string
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	matches := &sync.Map{}
+	results := make(chan result)
+	var forwarding sync.WaitGroup
+
+	start := func(name string) chan<- *pb.Match {
+		in := make(chan *pb.Match)
+		out := make(chan string)
+		e := er.get(name)
+		eg.Go(func() error {
+			defer close(out)
+			err := e.evaluate(ctx, bufferMatchChannel(in), out)
+			if err != nil {
+				return fmt.Errorf("error calling evaluator %s: %w", name, err)
+			}
+			return nil
+		})
+
+		forwarding.Add(1)
+		go func() {
+			defer forwarding.Done()
+			for id := range out {
+				select {
+				case results <- result{evaluator: name, matchID: id}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+		return in
+	}
+
+	eg.Go(func() error {
+		inputs := make(map[string]chan<- *pb.Match)
+		defer func() {
+			for _, in := range inputs {
+				close(in)
+			}
+			go func() {
+				forwarding.Wait()
+				close(results)
+			}()
+		}()
+
+		for proposals := range pc {
+			for _, proposal := range proposals {
+				if _, ok := matches.LoadOrStore(proposal.GetMatchId(), proposal); ok {
+					return fmt.Errorf("multiple match functions used same match_id: \"%s\"", proposal.GetMatchId())
+				}
+
+				name := route(er.routes, profileOf(proposal.GetMatchId()))
+				in, ok := inputs[name]
+				if !ok {
+					in = start(name)
+					inputs[name] = in
+				}
+				select {
+				case in <- proposal:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		return nil
+	})
+
+	eg.Go(func() error {
+		// claimed and claimedBackfills map the ids of tickets and backfills
+		// in accepted matches to the evaluator which accepted them.
+		claimed := make(map[string]string)
+		claimedBackfills := make(map[string]string)
+
+	results:
+		for r := range results {
+			v, ok := matches.Load(r.matchID)
+			if !ok {
+				return fmt.Errorf("evaluator %s returned match_id \"%s\" which does not correspond to any match in its input", r.evaluator, r.matchID)
+			}
+			match := v.(*pb.Match)
+
+			for _, t := range match.GetTickets() {
+				if owner, ok := claimed[t.GetId()]; ok && owner != r.evaluator {
+					logger.WithFields(logrus.Fields{
+						"match_id":  r.matchID,
+						"ticket_id": t.GetId(),
+						"evaluator": r.evaluator,
+						"owner":     owner,
+					}).Info("match shares a ticket with a match accepted by another evaluator, dropping match")
+					stats.Record(ctx, evaluatorCollisions.M(1))
+					continue results
+				}
+			}
+
+			backfillID := match.GetBackfill().GetId()
+			if backfillID != "" {
+				if owner, ok := claimedBackfills[backfillID]; ok && owner != r.evaluator {
+					logger.WithFields(logrus.Fields{
+						"match_id":    r.matchID,
+						"backfill_id": backfillID,
+						"evaluator":   r.evaluator,
+						"owner":       owner,
+					}).Info("match shares a backfill with a match accepted by another evaluator, dropping match")
+					stats.Record(ctx, evaluatorCollisions.M(1))
+					continue
+				}
+				claimedBackfills[backfillID] = r.evaluator
+			}
+
+			for _, t := range match.GetTickets() {
+				claimed[t.GetId()] = r.evaluator
+			}
+			select {
+			case acceptedIds <- r.matchID:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	return eg.Wait()
+}
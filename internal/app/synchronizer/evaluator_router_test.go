@@ -0,0 +1,238 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestGetEvaluatorRoutes(t *testing.T) {
+	cfg := viper.New()
+	routes, err := getEvaluatorRoutes(cfg)
+	require.Nil(t, err)
+	require.Empty(t, routes)
+
+	cfg.Set("evaluatorRoutes", []string{"ranked-*=ranked", " casual = casual "})
+	routes, err = getEvaluatorRoutes(cfg)
+	require.Nil(t, err)
+	require.Equal(t, []evaluatorRoute{{"ranked-*", "ranked"}, {"casual", "casual"}}, routes)
+
+	require.Equal(t, "ranked", route(routes, "ranked-1v1"))
+	require.Equal(t, "casual", route(routes, "casual"))
+	require.Equal(t, defaultEvaluatorName, route(routes, "casual-2"))
+	require.Equal(t, defaultEvaluatorName, route(routes, ""))
+
+	for _, invalid := range []string{"ranked", "[=ranked", "ranked-*="} {
+		cfg.Set("evaluatorRoutes", []string{invalid})
+		_, err = getEvaluatorRoutes(cfg)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err), invalid)
+		_, err = newEvaluatorRouter(cfg)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err), invalid)
+	}
+}
+
+func TestEvaluatorRouterDefault(t *testing.T) {
+	router, evaluators := newFakeEvaluatorRouter(t, viper.New())
+
+	accepted, err := runEvaluatorRouter(t, router, []*pb.Match{
+		{MatchId: "1", Tickets: []*pb.Ticket{{Id: "a"}}},
+		{MatchId: "2", Tickets: []*pb.Ticket{{Id: "a"}}},
+	}, map[string]string{"1": "ranked", "2": "casual"})
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"1", "2"}, accepted)
+	require.Equal(t, []string{defaultEvaluatorName}, evaluators.names())
+}
+
+func TestEvaluatorRouterRoutes(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("evaluatorRoutes", []string{"ranked-*=ranked", "casual=casual"})
+	router, evaluators := newFakeEvaluatorRouter(t, cfg)
+
+	accepted, err := runEvaluatorRouter(t, router, []*pb.Match{
+		{MatchId: "1", Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}}},
+		{MatchId: "2", Tickets: []*pb.Ticket{{Id: "b"}, {Id: "c"}}},
+		{MatchId: "3", Tickets: []*pb.Ticket{{Id: "d"}}},
+		{MatchId: "4", Tickets: []*pb.Ticket{{Id: "a"}}},
+	}, map[string]string{"1": "ranked-1", "2": "casual", "3": "other", "4": "ranked-2"})
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"casual", defaultEvaluatorName, "ranked"}, evaluators.names())
+	require.Equal(t, []string{"1", "4"}, evaluators.get("ranked").received)
+	require.Equal(t, []string{"2"}, evaluators.get("casual").received)
+	require.Equal(t, []string{"3"}, evaluators.get(defaultEvaluatorName).received)
+
+	// Matches 1 and 2 were accepted by different evaluators but share ticket
+	// b, so only the first of them to be accepted is kept.  Match 4 shares
+	// ticket a with match 1, but is from the same evaluator, which is trusted
+	// to have deconflicted its own matches.
+	if accepted[0] == "1" {
+		require.Equal(t, []string{"1", "3", "4"}, accepted)
+	} else {
+		require.Equal(t, []string{"2", "3", "4"}, accepted)
+	}
+}
+
+func TestEvaluatorRouterBackfills(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("evaluatorRoutes", []string{"ranked=ranked", "casual=casual"})
+	router, _ := newFakeEvaluatorRouter(t, cfg)
+
+	accepted, err := runEvaluatorRouter(t, router, []*pb.Match{
+		{MatchId: "1", Tickets: []*pb.Ticket{{Id: "a"}}, Backfill: &pb.Backfill{Id: "x"}},
+		{MatchId: "2", Tickets: []*pb.Ticket{{Id: "b"}}, Backfill: &pb.Backfill{Id: "x"}},
+		{MatchId: "3", Tickets: []*pb.Ticket{{Id: "c"}}, Backfill: &pb.Backfill{Id: "y"}},
+		{MatchId: "4", Tickets: []*pb.Ticket{{Id: "d"}}, Backfill: &pb.Backfill{Id: "x"}},
+	}, map[string]string{"1": "ranked", "2": "casual", "3": "casual", "4": "ranked"})
+	require.Nil(t, err)
+
+	// Matches 1 and 2 were accepted by different evaluators but share
+	// backfill x, so only the first of them to be accepted is kept, along with
+	// match 4 if it was from the same evaluator.
+	if accepted[0] == "1" {
+		require.Equal(t, []string{"1", "3", "4"}, accepted)
+	} else {
+		require.Equal(t, []string{"2", "3"}, accepted)
+	}
+}
+
+func TestEvaluatorRouterCanceled(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("evaluatorRoutes", []string{"ranked=ranked"})
+	router, _ := newFakeEvaluatorRouter(t, cfg)
+
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	pc := make(chan []*pb.Match, 1)
+	pc <- []*pb.Match{{MatchId: "1"}, {MatchId: "2"}}
+	close(pc)
+
+	// Nothing reads the second accepted id, so evaluate only returns because
+	// the context is canceled.
+	acceptedIds := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.evaluate(ctx, func(string) string {
+			return "ranked"
+		}, pc, acceptedIds)
+	}()
+
+	<-acceptedIds
+	cancel()
+	<-done
+}
+
+func TestEvaluatorRouterDuplicateMatchID(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("evaluatorRoutes", []string{"ranked=ranked"})
+	router, _ := newFakeEvaluatorRouter(t, cfg)
+
+	_, err := runEvaluatorRouter(t, router, []*pb.Match{
+		{MatchId: "1"},
+		{MatchId: "1"},
+	}, map[string]string{"1": "ranked"})
+	require.NotNil(t, err)
+}
+
+func runEvaluatorRouter(t *testing.T, router *evaluatorRouter, proposals []*pb.Match, profiles map[string]string) ([]string, error) {
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	pc := make(chan []*pb.Match, 1)
+	pc <- proposals
+	close(pc)
+
+	acceptedIds := make(chan string, len(proposals))
+	err := router.evaluate(ctx, func(matchID string) string {
+		return profiles[matchID]
+	}, pc, acceptedIds)
+	close(acceptedIds)
+
+	accepted := []string{}
+	for id := range acceptedIds {
+		accepted = append(accepted, id)
+	}
+	sort.Strings(accepted)
+	return accepted, err
+}
+
+func newFakeEvaluatorRouter(t *testing.T, cfg config.View) (*evaluatorRouter, *fakeEvaluators) {
+	evaluators := &fakeEvaluators{
+		evaluators: make(map[string]*fakeEvaluator),
+	}
+	router, err := newEvaluatorRouter(cfg)
+	require.Nil(t, err)
+	router.newEvaluator = func(cfg config.View, name string) evaluator {
+		return evaluators.get(name)
+	}
+	return router, evaluators
+}
+
+type fakeEvaluators struct {
+	m          sync.Mutex
+	evaluators map[string]*fakeEvaluator
+}
+
+func (fe *fakeEvaluators) get(name string) *fakeEvaluator {
+	fe.m.Lock()
+	defer fe.m.Unlock()
+
+	e, ok := fe.evaluators[name]
+	if !ok {
+		e = &fakeEvaluator{}
+		fe.evaluators[name] = e
+	}
+	return e
+}
+
+func (fe *fakeEvaluators) names() []string {
+	fe.m.Lock()
+	defer fe.m.Unlock()
+
+	names := []string{}
+	for name := range fe.evaluators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fakeEvaluator accepts every match it is sent.
+type fakeEvaluator struct {
+	received []string
+}
+
+func (e *fakeEvaluator) evaluate(ctx context.Context, pc <-chan []*pb.Match, acceptedIds chan<- string) error {
+	for proposals := range pc {
+		for _, proposal := range proposals {
+			e.received = append(e.received, proposal.GetMatchId())
+			acceptedIds <- proposal.GetMatchId()
+		}
+	}
+	return nil
+}
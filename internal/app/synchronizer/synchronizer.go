@@ -28,6 +28,9 @@ var (
 	iterationLatency        = stats.Float64("open-match.dev/synchronizer/iteration_latency", "Time elapsed of each synchronizer iteration", stats.UnitMilliseconds)
 	registrationWaitTime    = stats.Float64("open-match.dev/synchronizer/registration_wait_time", "Time elapsed of registration wait time", stats.UnitMilliseconds)
 	registrationMMFDoneTime = stats.Float64("open-match.dev/synchronizer/registration_mmf_done_time", "Time elapsed wasted in registration window with done MMFs", stats.UnitMilliseconds)
+	backfillCollisions      = stats.Int64("open-match.dev/synchronizer/backfill_collisions", "Number of matches dropped because their backfill could not be written", stats.UnitDimensionless)
+	evaluatorCollisions     = stats.Int64("open-match.dev/synchronizer/evaluator_collisions", "Number of matches dropped because another evaluator accepted a match with the same ticket or backfill", stats.UnitDimensionless)
+	ticketsExpired          = stats.Int64("open-match.dev/synchronizer/expired_tickets", "Number of tickets deleted because their expire time passed", stats.UnitDimensionless)
 
 	iterationLatencyView = &view.View{
 		Measure:     iterationLatency,
@@ -47,20 +50,46 @@ var (
 		Description: "Time elapsed wasted in registration window with done MMFs",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
+	backfillCollisionsView = &view.View{
+		Measure:     backfillCollisions,
+		Name:        "open-match.dev/synchronizer/backfill_collisions",
+		Description: "Number of matches dropped because their backfill could not be written",
+		Aggregation: view.Sum(),
+	}
+	evaluatorCollisionsView = &view.View{
+		Measure:     evaluatorCollisions,
+		Name:        "open-match.dev/synchronizer/evaluator_collisions",
+		Description: "Number of matches dropped because another evaluator accepted a match with the same ticket or backfill",
+		Aggregation: view.Sum(),
+	}
+	ticketsExpiredView = &view.View{
+		Measure:     ticketsExpired,
+		Name:        "open-match.dev/synchronizer/expired_tickets",
+		Description: "Total number of tickets deleted because their expire time passed",
+		Aggregation: view.Sum(),
+	}
 )
 
 // BindService creates the synchronizer service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	router, err := newEvaluatorRouter(p.Config())
+	if err != nil {
+		return err
+	}
 	store := statestore.New(p.Config())
-	service := newSynchronizerService(p.Config(), newEvaluator(p.Config()), store)
+	service := newSynchronizerService(p.Config(), router, store)
 	b.AddHealthCheckFunc(store.HealthCheck)
 	b.AddHandleFunc(func(s *grpc.Server) {
 		ipb.RegisterSynchronizerServer(s, service)
 	}, nil)
+	b.AddCloser(startTicketExpiry(p.Config(), store))
 	b.RegisterViews(
 		iterationLatencyView,
 		registrationWaitTimeView,
 		registrationMMFDoneTimeView,
+		backfillCollisionsView,
+		evaluatorCollisionsView,
+		ticketsExpiredView,
 	)
 	return nil
 }
@@ -23,7 +23,12 @@ import (
 
 	"go.opencensus.io/stats"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/rs/xid"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/appmain/contextcause"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
@@ -42,8 +47,9 @@ var (
 // are used to be consistent between function calls to help track everything.
 
 // Streams from multiple GRPC calls of matches are combined on a single channel.
-// These matches are sent to the evaluator, then the tickets are added to the
-// pending release list.  Finally the matches are returned to the calling stream.
+// These matches are sent to the evaluator, then their backfills are written
+// and the tickets are added to the pending release list.  Finally the matches
+// are returned to the calling stream.
 
 // receive from backend                  | Synchronize
 //  -> m1c ->
@@ -51,11 +57,12 @@ var (
 //   -> m2c ->
 // remember return channel m7c for match | fanInFanOut
 //   -> m3c ->
-// setmappings from matchIDs to ticketIDs| cacheMatchIDToTicketIDs
+// setmappings from matchIDs to matches  | cacheMatchIDToMatch
 //   -> m4c -> (buffered)
 // send to evaluator                     | wrapEvaluator
 //   -> m5c -> (buffered)
-// add tickets to pending release            | addMatchesToPendingRelease
+// write backfills, add tickets to       | addMatchesToPendingRelease
+// pending release                       |
 //   -> m6c ->
 // fan out to origin synchronize call    | fanInFanOut
 //   -> (Synchronize call specific ) m7c -> (buffered)
@@ -64,7 +71,7 @@ var (
 type synchronizerService struct {
 	cfg   config.View
 	store statestore.Service
-	eval  evaluator
+	eval  *evaluatorRouter
 
 	synchronizeRegistration chan *registrationRequest
 
@@ -73,7 +80,7 @@ type synchronizerService struct {
 	startCycle chan struct{}
 }
 
-func newSynchronizerService(cfg config.View, eval evaluator, store statestore.Service) *synchronizerService {
+func newSynchronizerService(cfg config.View, eval *evaluatorRouter, store statestore.Service) *synchronizerService {
 	s := &synchronizerService{
 		cfg:   cfg,
 		store: store,
@@ -113,6 +120,7 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 				registration.allM1cSent.Done()
 				return
 			}
+			registration.profiles.Store(req.GetProposal().GetMatchId(), req.GetProfileName())
 			registration.m1c.send(mAndM6c{m: req.Proposal, m7c: registration.m7c})
 		}
 	}()
@@ -133,7 +141,13 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 				return registration.cycleCtx.Err()
 			}
 			for _, mID := range mIDs {
-				err = stream.Send(&ipb.SynchronizeResponse{MatchId: mID})
+				resp := &ipb.SynchronizeResponse{MatchId: mID}
+				if v, ok := registration.accepted.Load(mID); ok {
+					a := v.(*acceptedMatch)
+					resp.Backfill = a.backfill
+					resp.ReservationToken = a.reservationToken
+				}
+				err = stream.Send(resp)
 				if err != nil {
 					logger.WithFields(logrus.Fields{
 						"error": err.Error(),
@@ -161,6 +175,49 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 
 }
 
+// Evaluate sends the proposals straight to the evaluators, outside of any
+// cycle, and returns the ids of the accepted matches.  Nothing is written to
+// the state store.
+func (s *synchronizerService) Evaluate(stream ipb.Synchronizer_EvaluateServer) error {
+	var proposals []*pb.Match
+	profiles := make(map[string]string)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		proposals = append(proposals, req.GetProposal())
+		profiles[req.GetProposal().GetMatchId()] = req.GetProfileName()
+	}
+
+	pc := make(chan []*pb.Match, 1)
+	pc <- proposals
+	close(pc)
+
+	acceptedIds := make(chan string)
+	evalErr := make(chan error, 1)
+	go func() {
+		evalErr <- s.eval.evaluate(stream.Context(), func(matchID string) string {
+			return profiles[matchID]
+		}, pc, acceptedIds)
+		close(acceptedIds)
+	}()
+
+	var sendErr error
+	for mID := range acceptedIds {
+		if sendErr == nil {
+			sendErr = stream.Send(&ipb.SynchronizeResponse{MatchId: mID})
+		}
+	}
+	if err := <-evalErr; err != nil {
+		return fmt.Errorf("error calling evaluator: %w", err)
+	}
+	return sendErr
+}
+
 ///////////////////////////////////////
 ///////////////////////////////////////
 
@@ -181,6 +238,11 @@ type registration struct {
 	m7c        chan string
 	cancelMmfs chan struct{}
 	cycleCtx   context.Context
+	// accepted maps match ids to the *acceptedMatch written for that match.
+	accepted *sync.Map
+	// profiles maps match ids to the name of the profile the match was
+	// proposed for.
+	profiles *sync.Map
 }
 
 func (s synchronizerService) register(ctx context.Context) *registration {
@@ -190,7 +252,9 @@ func (s synchronizerService) register(ctx context.Context) *registration {
 	}
 
 	st := time.Now()
-	defer stats.Record(ctx, registrationWaitTime.M(float64(time.Since(st))/float64(time.Millisecond)))
+	defer func() {
+		stats.Record(ctx, registrationWaitTime.M(float64(time.Since(st))/float64(time.Millisecond)))
+	}()
 	for {
 		select {
 		case s.synchronizeRegistration <- req:
@@ -236,11 +300,13 @@ func (s *synchronizerService) runCycle() {
 		}
 	}()
 
-	matchTickets := &sync.Map{}
-	go s.cacheMatchIDToTicketIDs(matchTickets, m3c, m4c)
-	go s.wrapEvaluator(ctx, cancel, bufferMatchChannel(m4c), m5c)
+	matches := &sync.Map{}
+	acceptedMatches := &sync.Map{}
+	matchProfiles := &sync.Map{}
+	go s.cacheMatchIDToMatch(matches, m3c, m4c)
+	go s.wrapEvaluator(ctx, cancel, matchProfiles, bufferMatchChannel(m4c), m5c)
 	go func() {
-		s.addMatchesToPendingRelease(ctx, matchTickets, cancel, bufferStringChannel(m5c), m6c)
+		s.addMatchesToPendingRelease(ctx, matches, acceptedMatches, cancel, bufferStringChannel(m5c), m6c)
 		// Wait for pending release, but not all matches returned, the next cycle
 		// can start now.
 		close(closedOnCycleEnd)
@@ -261,6 +327,8 @@ Registration:
 				cancelMmfs: make(chan struct{}, 1),
 				cycleCtx:   ctx,
 				allM1cSent: &allM1cSent,
+				accepted:   acceptedMatches,
+				profiles:   matchProfiles,
 			}
 			registrations = append(registrations, r)
 			req.resp <- r
@@ -400,9 +468,15 @@ func (c *cutoffSender) cutoff() {
 ///////////////////////////////////////
 ///////////////////////////////////////
 
-// Calls the evaluator with the matches.
-func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextcause.CancelErrFunc, m4c <-chan []*pb.Match, m5c chan<- string) {
-	err := s.eval.evaluate(ctx, m4c, m5c)
+// Calls the evaluators with the matches.
+func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextcause.CancelErrFunc, profiles *sync.Map, m4c <-chan []*pb.Match, m5c chan<- string) {
+	profileOf := func(matchID string) string {
+		if v, ok := profiles.Load(matchID); ok {
+			return v.(string)
+		}
+		return ""
+	}
+	err := s.eval.evaluate(ctx, profileOf, m4c, m5c)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err,
@@ -415,9 +489,9 @@ func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextc
 ///////////////////////////////////////
 ///////////////////////////////////////
 
-func (s *synchronizerService) cacheMatchIDToTicketIDs(m *sync.Map, m3c <-chan *pb.Match, m4c chan<- *pb.Match) {
+func (s *synchronizerService) cacheMatchIDToMatch(m *sync.Map, m3c <-chan *pb.Match, m4c chan<- *pb.Match) {
 	for match := range m3c {
-		m.Store(match.GetMatchId(), getTicketIds(match.GetTickets()))
+		m.Store(match.GetMatchId(), match)
 		m4c <- match
 	}
 	close(m4c)
@@ -434,26 +508,48 @@ func getTicketIds(tickets []*pb.Ticket) []string {
 ///////////////////////////////////////
 ///////////////////////////////////////
 
-// Calls statestore to add all of the tickets returned by the evaluator to the
-// pendingRelease list.  If it partially fails for whatever reason (not all tickets will
-// nessisarily be in the same call), only the matches which can be safely
-// returned to the Synchronize calls are.
-func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m *sync.Map, cancel contextcause.CancelErrFunc, m5c <-chan []string, m6c chan<- string) {
+// acceptedMatch is what the synchronizer wrote for a match accepted by the
+// evaluator.
+type acceptedMatch struct {
+	// backfill is the match's backfill as written, if it has one.
+	backfill *pb.Backfill
+	// reservationToken identifies the reservation of the match's tickets, for
+	// AssignTickets and ReleaseMatches.  Match ids aren't used, as they're only
+	// unique to the match function which made them.  Empty if the tickets
+	// couldn't be reserved.
+	reservationToken string
+}
+
+// Calls statestore to add the tickets of the matches returned by the evaluator
+// to the pendingRelease list, then to write their backfills.  Backfills are
+// only written once their match's tickets are pending release, and matches
+// whose backfill was modified since the match function read it are dropped,
+// releasing their tickets again.  If it partially fails for whatever reason
+// (not all tickets will nessisarily be in the same call), only the matches
+// which can be safely returned to the Synchronize calls are.
+func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m *sync.Map, accepted *sync.Map, cancel contextcause.CancelErrFunc, m5c <-chan []string, m6c chan<- string) {
 	totalMatches := 0
 	successfulMatches := 0
 	var lastErr error
-	for mIDs := range m5c {
-		ids := []string{}
-		for _, mID := range mIDs {
-			tids, ok := m.Load(mID)
-			if ok {
-				ids = append(ids, tids.([]string)...)
-			} else {
+	for evaluatedIDs := range m5c {
+		tickets := make(map[string][]string, len(evaluatedIDs))
+		matches := make(map[string]*pb.Match, len(evaluatedIDs))
+		tokens := make(map[string]string, len(evaluatedIDs))
+		mIDs := make([]string, 0, len(evaluatedIDs))
+		for _, mID := range evaluatedIDs {
+			mIDs = append(mIDs, mID)
+			v, ok := m.Load(mID)
+			if !ok {
 				logger.Errorf("failed to get MatchId %s with its corresponding tickets from the cache", mID)
+				continue
 			}
+			match := v.(*pb.Match)
+			matches[mID] = match
+			tokens[mID] = xid.New().String()
+			tickets[tokens[mID]] = getTicketIds(match.GetTickets())
 		}
 
-		err := s.store.AddTicketsToPendingRelease(ctx, ids)
+		err := s.store.AddMatchTicketsToPendingRelease(ctx, tickets)
 
 		totalMatches += len(mIDs)
 		if err == nil {
@@ -463,6 +559,23 @@ func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m
 		}
 
 		for _, mID := range mIDs {
+			a := &acceptedMatch{}
+			if err == nil {
+				a.reservationToken = tokens[mID]
+			}
+			if backfill := matches[mID].GetBackfill(); backfill != nil {
+				if err != nil {
+					// The match's tickets may not be pending release, so its
+					// backfill isn't written and the match is dropped.
+					continue
+				}
+				written, ok := s.writeMatchBackfill(ctx, mID, backfill, tickets[tokens[mID]])
+				if !ok {
+					continue
+				}
+				a.backfill = written
+			}
+			accepted.Store(mID, a)
 			m6c <- mID
 		}
 	}
@@ -481,6 +594,76 @@ func (s *synchronizerService) addMatchesToPendingRelease(ctx context.Context, m
 	close(m6c)
 }
 
+// writeMatchBackfill writes the backfill of a match whose tickets were added
+// to pending release, returning the backfill as written.  If the backfill
+// can't be written, the match's tickets are released again, and false is
+// returned to drop the match.
+func (s *synchronizerService) writeMatchBackfill(ctx context.Context, mID string, proposed *pb.Backfill, ticketIDs []string) (*pb.Backfill, bool) {
+	backfill, err := s.writeBackfill(ctx, proposed)
+	if err == nil {
+		return backfill, true
+	}
+
+	logger.WithFields(logrus.Fields{
+		"error":       err.Error(),
+		"match_id":    mID,
+		"backfill_id": proposed.GetId(),
+	}).Info("failed to write the match's backfill, dropping match")
+	stats.Record(ctx, backfillCollisions.M(1))
+
+	if len(ticketIDs) == 0 {
+		return nil, false
+	}
+	err = s.store.DeleteTicketsFromPendingRelease(ctx, ticketIDs)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"match_id": mID,
+		}).Error("failed to release the tickets of a match dropped for its backfill, they will be released after the pending release timeout")
+	}
+	return nil, false
+}
+
+// writeBackfill creates the proposed backfill if it has no id, otherwise
+// updates it if its generation hasn't changed since the match function read
+// it.  Returns the backfill as written.
+func (s *synchronizerService) writeBackfill(ctx context.Context, proposed *pb.Backfill) (*pb.Backfill, error) {
+	backfill, ok := proto.Clone(proposed).(*pb.Backfill)
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to clone backfill proto")
+	}
+
+	if backfill.GetId() == "" {
+		backfill.Id = xid.New().String()
+		backfill.CreateTime = ptypes.TimestampNow()
+		backfill.Generation = 1
+
+		err := s.store.CreateBackfill(ctx, backfill)
+		if err != nil {
+			return nil, err
+		}
+		err = s.store.IndexBackfill(ctx, backfill)
+		if err != nil {
+			return nil, err
+		}
+		return backfill, nil
+	}
+
+	current, err := s.store.GetBackfill(ctx, backfill.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// Open Match owns the create time, keep the stored value.
+	backfill.CreateTime = current.GetCreateTime()
+	backfill.Generation = proposed.GetGeneration() + 1
+
+	err = s.store.UpdateBackfill(ctx, backfill, proposed.GetGeneration())
+	if err != nil {
+		return nil, err
+	}
+	return backfill, nil
+}
+
 ///////////////////////////////////////
 ///////////////////////////////////////
 
@@ -0,0 +1,109 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/statestore"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// failingPendingReleaseStore fails every attempt to add tickets to pending
+// release.
+type failingPendingReleaseStore struct {
+	statestore.Service
+}
+
+func (failingPendingReleaseStore) AddMatchTicketsToPendingRelease(ctx context.Context, tickets map[string][]string) error {
+	return errors.New("pending release unavailable")
+}
+
+func TestAddMatchesToPendingReleaseBackfills(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+
+	stale := &pb.Backfill{Id: "stale", Generation: 1}
+	require.Nil(t, store.CreateBackfill(ctx, &pb.Backfill{Id: "stale", Generation: 2}))
+
+	matches := []*pb.Match{
+		{MatchId: "new", Tickets: []*pb.Ticket{{Id: "1"}}, Backfill: &pb.Backfill{}},
+		{MatchId: "collides", Tickets: []*pb.Ticket{{Id: "2"}}, Backfill: stale},
+		{MatchId: "plain", Tickets: []*pb.Ticket{{Id: "3"}}},
+	}
+
+	run := func(s *synchronizerService) ([]string, *sync.Map) {
+		m := &sync.Map{}
+		ids := []string{}
+		for _, match := range matches {
+			m.Store(match.GetMatchId(), match)
+			ids = append(ids, match.GetMatchId())
+		}
+		m5c := make(chan []string, 1)
+		m5c <- ids
+		close(m5c)
+		m6c := make(chan string, len(ids))
+		accepted := &sync.Map{}
+		s.addMatchesToPendingRelease(ctx, m, accepted, func(error) {}, m5c, m6c)
+
+		returned := []string{}
+		for id := range m6c {
+			returned = append(returned, id)
+		}
+		return returned, accepted
+	}
+	pending := func(id string) bool {
+		_, ok, err := store.GetPendingReleaseTime(ctx, id)
+		require.Nil(t, err)
+		return ok
+	}
+
+	// Backfills aren't written when the tickets can't be added to pending
+	// release.
+	returned, accepted := run(newSynchronizerService(cfg, nil, failingPendingReleaseStore{store}))
+	require.Equal(t, []string{"plain"}, returned)
+	a, ok := accepted.Load("plain")
+	require.True(t, ok)
+	require.Empty(t, a.(*acceptedMatch).reservationToken)
+	ids, err := store.GetIndexedBackfillIDSet(ctx)
+	require.Nil(t, err)
+	require.Empty(t, ids)
+
+	// A match whose backfill collides is dropped, and its tickets released.
+	returned, accepted = run(newSynchronizerService(cfg, nil, store))
+	require.Equal(t, []string{"new", "plain"}, returned)
+	a, ok = accepted.Load("new")
+	require.True(t, ok)
+	require.NotEmpty(t, a.(*acceptedMatch).backfill.GetId())
+	newToken := a.(*acceptedMatch).reservationToken
+	require.NotEmpty(t, newToken)
+	a, ok = accepted.Load("plain")
+	require.True(t, ok)
+	require.NotEmpty(t, a.(*acceptedMatch).reservationToken)
+	require.NotEqual(t, newToken, a.(*acceptedMatch).reservationToken)
+	require.True(t, pending("1"))
+	require.False(t, pending("2"))
+	require.True(t, pending("3"))
+}
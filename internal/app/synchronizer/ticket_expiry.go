@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/statestore"
+)
+
+// startTicketExpiry deindexes and deletes expired tickets every
+// ticketExpiryInterval, until the returned function is called.  The
+// synchronizer runs it as there is only one synchronizer, so the sweep isn't
+// repeated by every replica of a service, and it runs whether or not matches
+// are being fetched.
+func startTicketExpiry(cfg config.View, store statestore.Service) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(getTicketExpiryInterval(cfg)):
+				deleteExpiredTickets(ctx, store)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func deleteExpiredTickets(ctx context.Context, store statestore.Service) {
+	expired, err := store.DeleteExpiredTickets(ctx)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete expired tickets")
+		return
+	}
+	stats.Record(ctx, ticketsExpired.M(int64(len(expired))))
+}
+
+func getTicketExpiryInterval(cfg config.View) time.Duration {
+	const (
+		name = "ticketExpiryInterval"
+		// Default time between sweeps for expired tickets.
+		defaultInterval = time.Second
+	)
+
+	if !cfg.IsSet(name) {
+		return defaultInterval
+	}
+	interval := cfg.GetDuration(name)
+	if interval <= 0 {
+		logger.Infof("%s %v is not positive, using %v", name, interval, defaultInterval)
+		return defaultInterval
+	}
+	return interval
+}
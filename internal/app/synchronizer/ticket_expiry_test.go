@@ -0,0 +1,68 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/statestore"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestTicketExpiry(t *testing.T) {
+	ctx := utilTesting.NewContext(t)
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+	cfg.Set("ticketExpiryInterval", "10ms")
+
+	store := statestore.New(cfg)
+	defer store.Close()
+
+	expireTime, err := ptypes.TimestampProto(time.Now().Add(50 * time.Millisecond))
+	require.Nil(t, err)
+	for _, ticket := range []*pb.Ticket{{Id: "1", ExpireTime: expireTime}, {Id: "2"}} {
+		require.Nil(t, store.CreateTicket(ctx, ticket))
+		require.Nil(t, store.IndexTicket(ctx, ticket))
+	}
+
+	snapshot, err := store.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+
+	stop := startTicketExpiry(cfg, store)
+	defer stop()
+
+	// The sweep deindexes the expired ticket, which the change log records.
+	require.Eventually(t, func() bool {
+		changes, _, err := store.GetTicketChanges(ctx, snapshot.Cursor)
+		require.Nil(t, err)
+		for _, c := range changes {
+			if c.Kind == statestore.TicketsDeindexed {
+				require.Equal(t, []string{"1"}, c.IDs)
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = store.GetTicket(ctx, "1")
+	require.NotNil(t, err)
+	_, err = store.GetTicket(ctx, "2")
+	require.Nil(t, err)
+}
@@ -18,9 +18,13 @@
 package filter
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -39,11 +43,14 @@ var (
 // PoolFilter contains all the filtering criteria from a Pool that the Ticket
 // needs to meet to belong to that Pool.
 type PoolFilter struct {
-	DoubleRangeFilters  []*pb.DoubleRangeFilter
-	StringEqualsFilters []*pb.StringEqualsFilter
-	TagPresentFilters   []*pb.TagPresentFilter
-	CreatedBefore       time.Time
-	CreatedAfter        time.Time
+	DoubleRangeFilters     []*pb.DoubleRangeFilter
+	StringEqualsFilters    []*pb.StringEqualsFilter
+	StringNotEqualsFilters []*pb.StringNotEqualsFilter
+	StringInFilters        []*pb.StringInFilter
+	TagPresentFilters      []*pb.TagPresentFilter
+	TagAbsentFilters       []*pb.TagAbsentFilter
+	CreatedBefore          time.Time
+	CreatedAfter           time.Time
 }
 
 // NewPoolFilter validates a Pool's filtering criteria and returns a PoolFilter.
@@ -63,40 +70,78 @@ func NewPoolFilter(pool *pb.Pool) (*PoolFilter, error) {
 		}
 	}
 
+	for _, f := range pool.GetStringInFilters() {
+		if len(f.GetValues()) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, ".string_in_filters for string_arg %q has no values", f.GetStringArg())
+		}
+	}
+
 	return &PoolFilter{
-		DoubleRangeFilters:  pool.GetDoubleRangeFilters(),
-		StringEqualsFilters: pool.GetStringEqualsFilters(),
-		TagPresentFilters:   pool.GetTagPresentFilters(),
-		CreatedBefore:       cb,
-		CreatedAfter:        ca,
+		DoubleRangeFilters:     pool.GetDoubleRangeFilters(),
+		StringEqualsFilters:    pool.GetStringEqualsFilters(),
+		StringNotEqualsFilters: pool.GetStringNotEqualsFilters(),
+		StringInFilters:        pool.GetStringInFilters(),
+		TagPresentFilters:      pool.GetTagPresentFilters(),
+		TagAbsentFilters:       pool.GetTagAbsentFilters(),
+		CreatedBefore:          cb,
+		CreatedAfter:           ca,
 	}, nil
 }
 
-// In returns true if the Ticket meets all the criteria for this PoolFilter.
-func (pf *PoolFilter) In(ticket *pb.Ticket) bool {
-	s := ticket.GetSearchFields()
+// filteredEntity is a matchmaking entity which can belong to a Pool, such as
+// a Ticket or a Backfill.
+type filteredEntity interface {
+	GetId() string
+	GetSearchFields() *pb.SearchFields
+	GetCreateTime() *timestamp.Timestamp
+}
+
+// In returns true if the Ticket or Backfill meets all the criteria for this
+// PoolFilter.
+func (pf *PoolFilter) In(entity filteredEntity) bool {
+	reason, _ := pf.check(entity)
+	return reason == ""
+}
+
+// Explain returns a description of the first filter which excludes the
+// Ticket or Backfill from this PoolFilter, or an empty string if it is in the
+// PoolFilter.
+func (pf *PoolFilter) Explain(entity filteredEntity) string {
+	reason, f := pf.check(entity)
+	if reason == "" || f == nil {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s{%s}", reason, proto.MessageName(f), strings.TrimSpace(proto.CompactTextString(f)))
+}
+
+// check returns the reason the entity is excluded from this PoolFilter, along
+// with the filter which excluded it, if any.  The reason is empty if the
+// entity is in the PoolFilter.  Reasons are constant, so that In doesn't
+// allocate.
+func (pf *PoolFilter) check(entity filteredEntity) (string, proto.Message) {
+	s := entity.GetSearchFields()
 	if s == nil {
 		s = emptySearchFields
 	}
 
 	if !pf.CreatedAfter.IsZero() || !pf.CreatedBefore.IsZero() {
 		// CreateTime is only populated by Open Match and hence expected to be valid.
-		if ct, err := ptypes.Timestamp(ticket.CreateTime); err == nil {
+		if ct, err := ptypes.Timestamp(entity.GetCreateTime()); err == nil {
 			if !pf.CreatedAfter.IsZero() {
 				if !ct.After(pf.CreatedAfter) {
-					return false
+					return "create time is not after created_after", nil
 				}
 			}
 
 			if !pf.CreatedBefore.IsZero() {
 				if !ct.Before(pf.CreatedBefore) {
-					return false
+					return "create time is not before created_before", nil
 				}
 			}
 		} else {
 			logger.WithFields(logrus.Fields{
 				"error": err.Error(),
-				"id":    ticket.GetId(),
+				"id":    entity.GetId(),
 			}).Error("failed to get time from Timestamp proto")
 		}
 	}
@@ -104,33 +149,64 @@ func (pf *PoolFilter) In(ticket *pb.Ticket) bool {
 	for _, f := range pf.DoubleRangeFilters {
 		v, ok := s.DoubleArgs[f.DoubleArg]
 		if !ok {
-			return false
+			return "double arg is missing", f
 		}
 		// Not simplified so that NaN cases are handled correctly.
 		if !(v >= f.Min && v <= f.Max) {
-			return false
+			return "double arg is out of range", f
 		}
 	}
 
 	for _, f := range pf.StringEqualsFilters {
 		v, ok := s.StringArgs[f.StringArg]
 		if !ok {
-			return false
+			return "string arg is missing", f
 		}
 		if f.Value != v {
-			return false
+			return "string arg does not equal value", f
+		}
+	}
+
+	for _, f := range pf.StringNotEqualsFilters {
+		v, ok := s.StringArgs[f.StringArg]
+		if !ok {
+			return "string arg is missing", f
+		}
+		if f.Value == v {
+			return "string arg equals value", f
+		}
+	}
+
+	for _, f := range pf.StringInFilters {
+		v, ok := s.StringArgs[f.StringArg]
+		if !ok {
+			return "string arg is missing", f
+		}
+		if !contains(f.Values, v) {
+			return "string arg is not in values", f
 		}
 	}
 
-outer:
 	for _, f := range pf.TagPresentFilters {
-		for _, v := range s.Tags {
-			if v == f.Tag {
-				continue outer
-			}
+		if !contains(s.Tags, f.Tag) {
+			return "tag is not present", f
 		}
-		return false
 	}
 
-	return true
+	for _, f := range pf.TagAbsentFilters {
+		if contains(s.Tags, f.Tag) {
+			return "tag is present", f
+		}
+	}
+
+	return "", nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
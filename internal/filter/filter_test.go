@@ -15,8 +15,10 @@
 package filter
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +40,12 @@ func TestMeetsCriteria(t *testing.T) {
 			if !pf.In(tc.Ticket) {
 				t.Error("ticket should be included in the pool")
 			}
+			if !pf.In(backfillFromTicket(tc.Ticket)) {
+				t.Error("backfill should be included in the pool")
+			}
+			if explanation := pf.Explain(tc.Ticket); explanation != "" {
+				t.Errorf("ticket should have no explanation, got %q", explanation)
+			}
 		})
 	}
 
@@ -52,10 +60,90 @@ func TestMeetsCriteria(t *testing.T) {
 			if pf.In(tc.Ticket) {
 				t.Error("ticket should be excluded from the pool")
 			}
+			if pf.In(backfillFromTicket(tc.Ticket)) {
+				t.Error("backfill should be excluded from the pool")
+			}
+			if pf.Explain(tc.Ticket) == "" {
+				t.Error("ticket should have an explanation")
+			}
 		})
 	}
 }
 
+func TestExplain(t *testing.T) {
+	skill := &pb.DoubleRangeFilter{DoubleArg: "skill", Min: 10, Max: 20}
+	beginner := &pb.TagAbsentFilter{Tag: "beginner"}
+	pf, err := NewPoolFilter(&pb.Pool{
+		DoubleRangeFilters: []*pb.DoubleRangeFilter{skill},
+		TagAbsentFilters:   []*pb.TagAbsentFilter{beginner},
+	})
+	assert.Nil(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		ticket *pb.Ticket
+		reason string
+		filter proto.Message
+		// fields are values of the filter which the explanation names.
+		fields []string
+	}{
+		{
+			"missing",
+			&pb.Ticket{},
+			"double arg is missing",
+			skill,
+			[]string{"skill", "10", "20"},
+		},
+		{
+			"outOfRange",
+			&pb.Ticket{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"skill": 21}}},
+			"double arg is out of range",
+			skill,
+			[]string{"skill", "10", "20"},
+		},
+		{
+			"secondFilter",
+			&pb.Ticket{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"skill": 15}, Tags: []string{"beginner"}}},
+			"tag is present",
+			beginner,
+			[]string{"beginner"},
+		},
+		{
+			"included",
+			&pb.Ticket{SearchFields: &pb.SearchFields{DoubleArgs: map[string]float64{"skill": 15}}},
+			"",
+			nil,
+			nil,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reason, filter := pf.check(tc.ticket)
+			assert.Equal(t, tc.reason, reason)
+			assert.True(t, proto.Equal(tc.filter, filter))
+
+			explanation := pf.Explain(tc.ticket)
+			if tc.reason == "" {
+				assert.Empty(t, explanation)
+				return
+			}
+			assert.True(t, strings.HasPrefix(explanation, tc.reason), explanation)
+			for _, field := range tc.fields {
+				assert.Contains(t, explanation, field)
+			}
+		})
+	}
+}
+
+// backfillFromTicket returns a backfill with the same filtered fields as the
+// ticket, which must fall in the same pools.
+func backfillFromTicket(ticket *pb.Ticket) *pb.Backfill {
+	return &pb.Backfill{
+		SearchFields: ticket.SearchFields,
+		CreateTime:   ticket.CreateTime,
+	}
+}
+
 func TestValidPoolFilter(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -79,6 +167,14 @@ func TestValidPoolFilter(t *testing.T) {
 			codes.InvalidArgument,
 			".invalid created_after value",
 		},
+		{
+			"string in empty values",
+			&pb.Pool{
+				StringInFilters: []*pb.StringInFilter{{StringArg: "region"}},
+			},
+			codes.InvalidArgument,
+			".string_in_filters for string_arg \"region\" has no values",
+		},
 	} {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
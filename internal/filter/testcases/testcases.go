@@ -109,6 +109,97 @@ func IncludedTestCases() []TestCase {
 			},
 		},
 
+		{
+			"String not equals simple positive",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					StringArgs: map[string]string{
+						"field": "value",
+					},
+				},
+			},
+			&pb.Pool{
+				StringNotEqualsFilters: []*pb.StringNotEqualsFilter{
+					{
+						StringArg: "field",
+						Value:     "VALUE",
+					},
+				},
+			},
+		},
+
+		{
+			"String in simple positive",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					StringArgs: map[string]string{
+						"region": "us-west",
+					},
+				},
+			},
+			&pb.Pool{
+				StringInFilters: []*pb.StringInFilter{
+					{
+						StringArg: "region",
+						Values:    []string{"us-east", "us-west"},
+					},
+				},
+			},
+		},
+
+		{
+			"TagAbsent no SearchFields",
+			&pb.Ticket{},
+			&pb.Pool{
+				TagAbsentFilters: []*pb.TagAbsentFilter{
+					{
+						Tag: "mytag",
+					},
+				},
+			},
+		},
+
+		{
+			"TagAbsent simple positive", // and case sensitivity
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					Tags: []string{
+						"MYTAG",
+					},
+				},
+			},
+			&pb.Pool{
+				TagAbsentFilters: []*pb.TagAbsentFilter{
+					{
+						Tag: "mytag",
+					},
+				},
+			},
+		},
+
+		{
+			"TagPresent and TagAbsent positive",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					Tags: []string{
+						"A", "B",
+					},
+				},
+			},
+			&pb.Pool{
+				TagPresentFilters: []*pb.TagPresentFilter{
+					{
+						Tag: "A",
+					},
+				},
+				TagAbsentFilters: []*pb.TagAbsentFilter{
+					{
+						Tag: "C",
+					},
+				},
+			},
+		},
+
 		multipleFilters(true, true, true),
 
 		{
@@ -292,6 +383,128 @@ func ExcludedTestCases() []TestCase {
 			},
 		},
 
+		{
+			"StringNotEquals no SearchFields",
+			&pb.Ticket{},
+			&pb.Pool{
+				StringNotEqualsFilters: []*pb.StringNotEqualsFilter{
+					{
+						StringArg: "field",
+						Value:     "value",
+					},
+				},
+			},
+		},
+
+		{
+			"String not equals simple negative",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					StringArgs: map[string]string{
+						"field": "value",
+					},
+				},
+			},
+			&pb.Pool{
+				StringNotEqualsFilters: []*pb.StringNotEqualsFilter{
+					{
+						StringArg: "field",
+						Value:     "value",
+					},
+				},
+			},
+		},
+
+		{
+			"String not equals missing field",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					StringArgs: map[string]string{
+						"otherfield": "othervalue",
+					},
+				},
+			},
+			&pb.Pool{
+				StringNotEqualsFilters: []*pb.StringNotEqualsFilter{
+					{
+						StringArg: "field",
+						Value:     "value",
+					},
+				},
+			},
+		},
+
+		{
+			"StringIn no SearchFields",
+			&pb.Ticket{},
+			&pb.Pool{
+				StringInFilters: []*pb.StringInFilter{
+					{
+						StringArg: "field",
+						Values:    []string{"value"},
+					},
+				},
+			},
+		},
+
+		{
+			"String in simple negative", // and case sensitivity
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					StringArgs: map[string]string{
+						"region": "us-west",
+					},
+				},
+			},
+			&pb.Pool{
+				StringInFilters: []*pb.StringInFilter{
+					{
+						StringArg: "region",
+						Values:    []string{"us-east", "US-WEST"},
+					},
+				},
+			},
+		},
+
+		{
+			"TagAbsent simple negative",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					Tags: []string{
+						"mytag",
+					},
+				},
+			},
+			&pb.Pool{
+				TagAbsentFilters: []*pb.TagAbsentFilter{
+					{
+						Tag: "mytag",
+					},
+				},
+			},
+		},
+
+		{
+			"TagAbsent multiple with one present",
+			&pb.Ticket{
+				SearchFields: &pb.SearchFields{
+					Tags: []string{
+						"A", "B", "C",
+					},
+				},
+			},
+			&pb.Pool{
+				TagAbsentFilters: []*pb.TagAbsentFilter{
+					{
+						Tag: "D",
+					},
+					{
+						Tag: "B",
+					},
+				},
+			},
+		},
+
 		{
 			"CreatedBefore simple negative",
 			&pb.Ticket{},
@@ -0,0 +1,95 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: internal/api/idempotency.proto
+
+package ipb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+	pb "open-match.dev/open-match/pkg/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The record state storage keeps of the ticket created for an idempotency key.
+type IdempotencyRecord struct {
+	// A hash of the create ticket request which recorded the key, so that
+	// requests repeating the key with a different ticket are rejected.
+	RequestHash []byte `protobuf:"bytes,1,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"`
+	// The ticket created for the request.
+	Ticket               *pb.Ticket `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *IdempotencyRecord) Reset()         { *m = IdempotencyRecord{} }
+func (m *IdempotencyRecord) String() string { return proto.CompactTextString(m) }
+func (*IdempotencyRecord) ProtoMessage()    {}
+func (*IdempotencyRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_52af32ebc857a43d, []int{0}
+}
+
+func (m *IdempotencyRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdempotencyRecord.Unmarshal(m, b)
+}
+func (m *IdempotencyRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdempotencyRecord.Marshal(b, m, deterministic)
+}
+func (m *IdempotencyRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdempotencyRecord.Merge(m, src)
+}
+func (m *IdempotencyRecord) XXX_Size() int {
+	return xxx_messageInfo_IdempotencyRecord.Size(m)
+}
+func (m *IdempotencyRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdempotencyRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdempotencyRecord proto.InternalMessageInfo
+
+func (m *IdempotencyRecord) GetRequestHash() []byte {
+	if m != nil {
+		return m.RequestHash
+	}
+	return nil
+}
+
+func (m *IdempotencyRecord) GetTicket() *pb.Ticket {
+	if m != nil {
+		return m.Ticket
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*IdempotencyRecord)(nil), "openmatch.internal.IdempotencyRecord")
+}
+
+func init() { proto.RegisterFile("internal/api/idempotency.proto", fileDescriptor_52af32ebc857a43d) }
+
+var fileDescriptor_52af32ebc857a43d = []byte{
+	// 180 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcb, 0xcc, 0x2b, 0x49,
+	0x2d, 0xca, 0x4b, 0xcc, 0xd1, 0x4f, 0x2c, 0xc8, 0xd4, 0xcf, 0x4c, 0x49, 0xcd, 0x2d, 0xc8, 0x2f,
+	0x49, 0xcd, 0x4b, 0xae, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0xca, 0x2f, 0x48, 0xcd,
+	0xcb, 0x4d, 0x2c, 0x49, 0xce, 0xd0, 0x83, 0xa9, 0x94, 0x12, 0x02, 0x29, 0xcd, 0x4d, 0x2d, 0x2e,
+	0x4e, 0x4c, 0x4f, 0x2d, 0x86, 0xa8, 0x53, 0x4a, 0xe4, 0x12, 0xf4, 0x44, 0x68, 0x0e, 0x4a, 0x4d,
+	0xce, 0x2f, 0x4a, 0x11, 0x52, 0xe4, 0xe2, 0x29, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x89, 0xcf,
+	0x48, 0x2c, 0xce, 0x90, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0xe2, 0x86, 0x8a, 0x79, 0x24, 0x16,
+	0x67, 0x08, 0x69, 0x72, 0xb1, 0x95, 0x64, 0x26, 0x67, 0xa7, 0x96, 0x48, 0x30, 0x29, 0x30, 0x6a,
+	0x70, 0x1b, 0x09, 0xea, 0x21, 0x2c, 0x0c, 0x01, 0x4b, 0x04, 0x41, 0x15, 0x38, 0x69, 0x44, 0xa9,
+	0x81, 0xe4, 0x74, 0x21, 0x92, 0x29, 0xa9, 0x65, 0xfa, 0x08, 0xae, 0x3e, 0xdc, 0x1b, 0x99, 0x05,
+	0x49, 0x49, 0x6c, 0x60, 0x37, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd5, 0xab, 0x63, 0x85,
+	0xdd, 0x00, 0x00, 0x00,
+}
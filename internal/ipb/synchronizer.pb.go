@@ -27,10 +27,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type SynchronizeRequest struct {
 	// A match returned by an mmf.
-	Proposal             *pb.Match `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Proposal *pb.Match `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// The name of the MatchProfile the mmf was called with to make the
+	// proposal.  Used to choose which evaluator the proposal is sent to.
+	ProfileName          string   `protobuf:"bytes,2,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SynchronizeRequest) Reset()         { *m = SynchronizeRequest{} }
@@ -65,6 +68,13 @@ func (m *SynchronizeRequest) GetProposal() *pb.Match {
 	return nil
 }
 
+func (m *SynchronizeRequest) GetProfileName() string {
+	if m != nil {
+		return m.ProfileName
+	}
+	return ""
+}
+
 type SynchronizeResponse struct {
 	// Instructs the backend call that it can start running the mmfs.
 	StartMmfs bool `protobuf:"varint,1,opt,name=start_mmfs,json=startMmfs,proto3" json:"start_mmfs,omitempty"`
@@ -73,7 +83,13 @@ type SynchronizeResponse struct {
 	CancelMmfs bool `protobuf:"varint,2,opt,name=cancel_mmfs,json=cancelMmfs,proto3" json:"cancel_mmfs,omitempty"`
 	// A match ID returned by the evaluator and should be returned to the FetchMatches
 	// caller.
-	MatchId              string   `protobuf:"bytes,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	MatchId string `protobuf:"bytes,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The backfill as written to state storage for the match with match_id, if
+	// the match updates a backfill.  Replaces the match's proposed backfill.
+	Backfill *pb.Backfill `protobuf:"bytes,5,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	// The token the tickets of the match with match_id are reserved under, if
+	// they were added to pending release.
+	ReservationToken     string   `protobuf:"bytes,6,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -125,6 +141,20 @@ func (m *SynchronizeResponse) GetMatchId() string {
 	return ""
 }
 
+func (m *SynchronizeResponse) GetBackfill() *pb.Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+func (m *SynchronizeResponse) GetReservationToken() string {
+	if m != nil {
+		return m.ReservationToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SynchronizeRequest)(nil), "openmatch.internal.SynchronizeRequest")
 	proto.RegisterType((*SynchronizeResponse)(nil), "openmatch.internal.SynchronizeResponse")
@@ -133,24 +163,29 @@ func init() {
 func init() { proto.RegisterFile("internal/api/synchronizer.proto", fileDescriptor_35ff6b85fea1c4b7) }
 
 var fileDescriptor_35ff6b85fea1c4b7 = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0x89, 0x16, 0x4d, 0x27, 0x1e, 0xca, 0x7a, 0xa9, 0x05, 0x69, 0xe9, 0xa1, 0xe6, 0xa0,
-	0x1b, 0xa9, 0xdf, 0xa0, 0x37, 0x85, 0x5e, 0xe2, 0xcd, 0x4b, 0xd9, 0x24, 0x53, 0xbb, 0x90, 0xfd,
-	0xe3, 0xce, 0x5a, 0xd0, 0x4f, 0x2f, 0xd9, 0xc5, 0xa6, 0xd2, 0x83, 0x97, 0x85, 0x37, 0xf3, 0xdb,
-	0x37, 0xcc, 0x1b, 0x98, 0x4a, 0xed, 0xd1, 0x69, 0xd1, 0x16, 0xc2, 0xca, 0x82, 0xbe, 0x74, 0xbd,
-	0x73, 0x46, 0xcb, 0x6f, 0x74, 0xdc, 0x3a, 0xe3, 0x0d, 0x63, 0xc6, 0xa2, 0x56, 0xc2, 0xd7, 0x3b,
-	0xfe, 0x8b, 0x4e, 0x58, 0xc7, 0x2a, 0x24, 0x12, 0xef, 0x48, 0x91, 0x9b, 0xaf, 0x80, 0xbd, 0xf6,
-	0xbf, 0x4b, 0xfc, 0xf8, 0x44, 0xf2, 0xec, 0x1e, 0x52, 0xeb, 0x8c, 0x35, 0x24, 0xda, 0x71, 0x32,
-	0x4b, 0xf2, 0x6c, 0x39, 0xe2, 0xbd, 0xe1, 0xba, 0x7b, 0xcb, 0x03, 0x31, 0xdf, 0xc3, 0xf5, 0x1f,
-	0x0f, 0xb2, 0x46, 0x13, 0xb2, 0x5b, 0x00, 0xf2, 0xc2, 0xf9, 0x8d, 0x52, 0x5b, 0x0a, 0x36, 0x69,
-	0x39, 0x0c, 0x95, 0xb5, 0xda, 0x12, 0x9b, 0x42, 0x56, 0x0b, 0x5d, 0x63, 0x1b, 0xfb, 0x67, 0xa1,
-	0x0f, 0xb1, 0x14, 0x80, 0x1b, 0x48, 0xc3, 0xbc, 0x8d, 0x6c, 0xc6, 0x83, 0x59, 0x92, 0x0f, 0xcb,
-	0xcb, 0xa0, 0x9f, 0x9b, 0x97, 0x41, 0x7a, 0x3e, 0x1a, 0x2c, 0x1d, 0x5c, 0x1d, 0xcd, 0x75, 0xac,
-	0x82, 0xec, 0x48, 0xb3, 0x05, 0x3f, 0xcd, 0x80, 0x9f, 0x2e, 0x3b, 0xb9, 0xfb, 0x97, 0x8b, 0x0b,
-	0xe5, 0xc9, 0x63, 0xb2, 0xca, 0xdf, 0x16, 0x1d, 0xfd, 0x10, 0xf1, 0x06, 0xf7, 0x45, 0x2f, 0x8b,
-	0xc3, 0x51, 0xa4, 0xad, 0xaa, 0x8b, 0x10, 0xf0, 0xd3, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x84,
-	0xba, 0xf6, 0x41, 0xab, 0x01, 0x00, 0x00,
+	// 349 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0x41, 0x4f, 0xea, 0x40,
+	0x10, 0xc7, 0x53, 0x1e, 0x8f, 0x57, 0xa6, 0x1c, 0x78, 0xcb, 0xa5, 0x8f, 0xe4, 0x05, 0xe4, 0x80,
+	0x4d, 0xd4, 0xd6, 0xe0, 0x37, 0x20, 0xf1, 0xa0, 0x09, 0x1e, 0xaa, 0x27, 0x2f, 0xcd, 0xb6, 0x4c,
+	0x65, 0x43, 0xbb, 0xbb, 0xee, 0x2e, 0x24, 0xfa, 0x35, 0xf5, 0x03, 0x99, 0x6e, 0x11, 0x6a, 0x38,
+	0x78, 0xf2, 0xd2, 0x64, 0xfe, 0xff, 0x5f, 0xe7, 0xdf, 0x99, 0x0e, 0x8c, 0x18, 0x37, 0xa8, 0x38,
+	0x2d, 0x22, 0x2a, 0x59, 0xa4, 0x5f, 0x78, 0xb6, 0x52, 0x82, 0xb3, 0x57, 0x54, 0xa1, 0x54, 0xc2,
+	0x08, 0x42, 0x84, 0x44, 0x5e, 0x52, 0x93, 0xad, 0xc2, 0x4f, 0x74, 0x48, 0x2a, 0xb6, 0x44, 0xad,
+	0xe9, 0x13, 0xea, 0x9a, 0x9b, 0x20, 0x90, 0xfb, 0xc3, 0xdb, 0x31, 0x3e, 0x6f, 0x50, 0x1b, 0x72,
+	0x0e, 0xae, 0x54, 0x42, 0x0a, 0x4d, 0x0b, 0xdf, 0x19, 0x3b, 0x81, 0x37, 0xeb, 0x87, 0x87, 0x86,
+	0x8b, 0xea, 0x19, 0xef, 0x09, 0x72, 0x02, 0x3d, 0xa9, 0x44, 0xce, 0x0a, 0x4c, 0x38, 0x2d, 0xd1,
+	0x6f, 0x8d, 0x9d, 0xa0, 0x1b, 0x7b, 0x3b, 0xed, 0x8e, 0x96, 0x38, 0x79, 0x77, 0x60, 0xf0, 0x25,
+	0x47, 0x4b, 0xc1, 0x35, 0x92, 0xff, 0x00, 0xda, 0x50, 0x65, 0x92, 0xb2, 0xcc, 0xb5, 0x8d, 0x72,
+	0xe3, 0xae, 0x55, 0x16, 0x65, 0xae, 0xc9, 0x08, 0xbc, 0x8c, 0xf2, 0x0c, 0x8b, 0xda, 0x6f, 0x59,
+	0x1f, 0x6a, 0xc9, 0x02, 0xff, 0xc0, 0xb5, 0xdf, 0x94, 0xb0, 0xa5, 0xdf, 0xb6, 0xb1, 0x7f, 0x6c,
+	0x7d, 0xb3, 0x24, 0x11, 0xb8, 0x29, 0xcd, 0xd6, 0x39, 0x2b, 0x0a, 0xff, 0xb7, 0x9d, 0x61, 0xd0,
+	0x98, 0x61, 0xbe, 0xb3, 0xe2, 0x3d, 0x44, 0xce, 0xe0, 0xaf, 0x42, 0x8d, 0x6a, 0x4b, 0x0d, 0x13,
+	0x3c, 0x31, 0x62, 0x8d, 0xdc, 0xef, 0xd8, 0xa6, 0xfd, 0x86, 0xf1, 0x50, 0xe9, 0xb7, 0x6d, 0xf7,
+	0x57, 0xbf, 0x3d, 0x7b, 0x73, 0xa0, 0xd7, 0x18, 0x4b, 0x91, 0x14, 0xbc, 0x46, 0x4d, 0xa6, 0xe1,
+	0xf1, 0x6f, 0x08, 0x8f, 0xf7, 0x3d, 0x3c, 0xfd, 0x96, 0xab, 0xf7, 0x15, 0x38, 0x97, 0x0e, 0x49,
+	0xc0, 0xbd, 0xde, 0xd2, 0x62, 0x43, 0xcd, 0xcf, 0x04, 0xcc, 0x83, 0xc7, 0x69, 0x45, 0x5f, 0xd4,
+	0xf8, 0x12, 0xb7, 0xd1, 0xa1, 0x8c, 0xf6, 0x87, 0xc7, 0x64, 0x9a, 0x76, 0xec, 0x11, 0x5d, 0x7d,
+	0x04, 0x00, 0x00, 0xff, 0xff, 0xaa, 0x9e, 0xf3, 0x5c, 0x8f, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -168,6 +203,10 @@ type SynchronizerClient interface {
 	// Synchronize signals the caller when it is safe to run mmfs, collects the
 	// mmfs' proposals, and returns the evaluated matches.
 	Synchronize(ctx context.Context, opts ...grpc.CallOption) (Synchronizer_SynchronizeClient, error)
+	// Evaluate sends proposals to the evaluators and returns the ids of the
+	// accepted matches, without waiting for a cycle, writing backfills, or
+	// moving tickets to pending.  Used for dry runs.
+	Evaluate(ctx context.Context, opts ...grpc.CallOption) (Synchronizer_EvaluateClient, error)
 }
 
 type synchronizerClient struct {
@@ -209,11 +248,46 @@ func (x *synchronizerSynchronizeClient) Recv() (*SynchronizeResponse, error) {
 	return m, nil
 }
 
+func (c *synchronizerClient) Evaluate(ctx context.Context, opts ...grpc.CallOption) (Synchronizer_EvaluateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Synchronizer_serviceDesc.Streams[1], "/openmatch.internal.Synchronizer/Evaluate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &synchronizerEvaluateClient{stream}
+	return x, nil
+}
+
+type Synchronizer_EvaluateClient interface {
+	Send(*SynchronizeRequest) error
+	Recv() (*SynchronizeResponse, error)
+	grpc.ClientStream
+}
+
+type synchronizerEvaluateClient struct {
+	grpc.ClientStream
+}
+
+func (x *synchronizerEvaluateClient) Send(m *SynchronizeRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *synchronizerEvaluateClient) Recv() (*SynchronizeResponse, error) {
+	m := new(SynchronizeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SynchronizerServer is the server API for Synchronizer service.
 type SynchronizerServer interface {
 	// Synchronize signals the caller when it is safe to run mmfs, collects the
 	// mmfs' proposals, and returns the evaluated matches.
 	Synchronize(Synchronizer_SynchronizeServer) error
+	// Evaluate sends proposals to the evaluators and returns the ids of the
+	// accepted matches, without waiting for a cycle, writing backfills, or
+	// moving tickets to pending.  Used for dry runs.
+	Evaluate(Synchronizer_EvaluateServer) error
 }
 
 // UnimplementedSynchronizerServer can be embedded to have forward compatible implementations.
@@ -223,6 +297,9 @@ type UnimplementedSynchronizerServer struct {
 func (*UnimplementedSynchronizerServer) Synchronize(srv Synchronizer_SynchronizeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Synchronize not implemented")
 }
+func (*UnimplementedSynchronizerServer) Evaluate(srv Synchronizer_EvaluateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
 
 func RegisterSynchronizerServer(s *grpc.Server, srv SynchronizerServer) {
 	s.RegisterService(&_Synchronizer_serviceDesc, srv)
@@ -254,6 +331,32 @@ func (x *synchronizerSynchronizeServer) Recv() (*SynchronizeRequest, error) {
 	return m, nil
 }
 
+func _Synchronizer_Evaluate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SynchronizerServer).Evaluate(&synchronizerEvaluateServer{stream})
+}
+
+type Synchronizer_EvaluateServer interface {
+	Send(*SynchronizeResponse) error
+	Recv() (*SynchronizeRequest, error)
+	grpc.ServerStream
+}
+
+type synchronizerEvaluateServer struct {
+	grpc.ServerStream
+}
+
+func (x *synchronizerEvaluateServer) Send(m *SynchronizeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *synchronizerEvaluateServer) Recv() (*SynchronizeRequest, error) {
+	m := new(SynchronizeRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Synchronizer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.internal.Synchronizer",
 	HandlerType: (*SynchronizerServer)(nil),
@@ -265,6 +368,12 @@ var _Synchronizer_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Evaluate",
+			Handler:       _Synchronizer_Evaluate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "internal/api/synchronizer.proto",
 }
@@ -0,0 +1,338 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: internal/api/ticketcache.proto
+
+package ipb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+	pb "open-match.dev/open-match/pkg/pb"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetTicketChangesRequest struct {
+	// The id of the ticket cache the caller's version is from, or empty if the
+	// caller has no tickets yet.
+	CacheId string `protobuf:"bytes,1,opt,name=cache_id,json=cacheId,proto3" json:"cache_id,omitempty"`
+	// The version of the ticket cache the caller has.
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// How long ago the ticket cache may have been refreshed for the changes to
+	// be sent without refreshing it again.  Followers pass on the staleness
+	// allowed by the queries they are updating for.  Unset or zero always
+	// refreshes the ticket cache.
+	MaxStaleness         *duration.Duration `protobuf:"bytes,3,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetTicketChangesRequest) Reset()         { *m = GetTicketChangesRequest{} }
+func (m *GetTicketChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTicketChangesRequest) ProtoMessage()    {}
+func (*GetTicketChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4e81da7e7388678, []int{0}
+}
+
+func (m *GetTicketChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTicketChangesRequest.Unmarshal(m, b)
+}
+func (m *GetTicketChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTicketChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTicketChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTicketChangesRequest.Merge(m, src)
+}
+func (m *GetTicketChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTicketChangesRequest.Size(m)
+}
+func (m *GetTicketChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTicketChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTicketChangesRequest proto.InternalMessageInfo
+
+func (m *GetTicketChangesRequest) GetCacheId() string {
+	if m != nil {
+		return m.CacheId
+	}
+	return ""
+}
+
+func (m *GetTicketChangesRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetTicketChangesRequest) GetMaxStaleness() *duration.Duration {
+	if m != nil {
+		return m.MaxStaleness
+	}
+	return nil
+}
+
+type GetTicketChangesResponse struct {
+	// The id of the ticket cache.  A new id is chosen each time the query
+	// service starts, as versions are only meaningful within one cache.
+	CacheId string `protobuf:"bytes,1,opt,name=cache_id,json=cacheId,proto3" json:"cache_id,omitempty"`
+	// The version of the ticket cache the changes bring the caller to.
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// If true, the caller must discard the tickets it has, and replace them with
+	// the tickets sent, as the changes since its version are no longer known.
+	Snapshot bool `protobuf:"varint,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Tickets which were added or updated since the caller's version.
+	Tickets []*pb.Ticket `protobuf:"bytes,4,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// The ids of the tickets which were removed since the caller's version.
+	RemovedIds []string `protobuf:"bytes,5,rep,name=removed_ids,json=removedIds,proto3" json:"removed_ids,omitempty"`
+	// The time the ticket cache was refreshed from state storage.
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Whether this is the last response.  The changes are only complete once it
+	// has been received.
+	Complete             bool     `protobuf:"varint,7,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTicketChangesResponse) Reset()         { *m = GetTicketChangesResponse{} }
+func (m *GetTicketChangesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTicketChangesResponse) ProtoMessage()    {}
+func (*GetTicketChangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c4e81da7e7388678, []int{1}
+}
+
+func (m *GetTicketChangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTicketChangesResponse.Unmarshal(m, b)
+}
+func (m *GetTicketChangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTicketChangesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTicketChangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTicketChangesResponse.Merge(m, src)
+}
+func (m *GetTicketChangesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTicketChangesResponse.Size(m)
+}
+func (m *GetTicketChangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTicketChangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTicketChangesResponse proto.InternalMessageInfo
+
+func (m *GetTicketChangesResponse) GetCacheId() string {
+	if m != nil {
+		return m.CacheId
+	}
+	return ""
+}
+
+func (m *GetTicketChangesResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetTicketChangesResponse) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
+	}
+	return false
+}
+
+func (m *GetTicketChangesResponse) GetTickets() []*pb.Ticket {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+func (m *GetTicketChangesResponse) GetRemovedIds() []string {
+	if m != nil {
+		return m.RemovedIds
+	}
+	return nil
+}
+
+func (m *GetTicketChangesResponse) GetUpdateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return nil
+}
+
+func (m *GetTicketChangesResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*GetTicketChangesRequest)(nil), "openmatch.internal.GetTicketChangesRequest")
+	proto.RegisterType((*GetTicketChangesResponse)(nil), "openmatch.internal.GetTicketChangesResponse")
+}
+
+func init() { proto.RegisterFile("internal/api/ticketcache.proto", fileDescriptor_c4e81da7e7388678) }
+
+var fileDescriptor_c4e81da7e7388678 = []byte{
+	// 389 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xcf, 0x8b, 0xd4, 0x30,
+	0x14, 0xc7, 0xe9, 0x8e, 0xee, 0xcc, 0xa6, 0x0a, 0x9a, 0x8b, 0xd9, 0x1e, 0x76, 0xcb, 0x1e, 0xa4,
+	0xb0, 0x9a, 0xca, 0x78, 0x14, 0x3c, 0xa8, 0x20, 0x7b, 0x8d, 0x7b, 0xf2, 0x52, 0x32, 0xcd, 0xb3,
+	0x0d, 0x36, 0x3f, 0xec, 0x4b, 0x87, 0x3d, 0xf9, 0x27, 0x78, 0xf0, 0x2f, 0x96, 0x36, 0xed, 0x2e,
+	0xcc, 0x20, 0x88, 0xc7, 0x6f, 0xf2, 0x09, 0x7c, 0xde, 0x37, 0x8f, 0x5c, 0x68, 0x1b, 0xa0, 0xb7,
+	0xb2, 0x2b, 0xa5, 0xd7, 0x65, 0xd0, 0xf5, 0x77, 0x08, 0xb5, 0xac, 0x5b, 0xe0, 0xbe, 0x77, 0xc1,
+	0x51, 0xea, 0x3c, 0x58, 0x23, 0x43, 0xdd, 0xf2, 0x85, 0xcc, 0xe8, 0x88, 0x1a, 0x40, 0x94, 0x0d,
+	0x60, 0xe4, 0xb2, 0x8b, 0xc6, 0xb9, 0xa6, 0x83, 0x72, 0x4a, 0xbb, 0xe1, 0x5b, 0xa9, 0x86, 0x5e,
+	0x06, 0xed, 0xec, 0x7c, 0x7f, 0x79, 0x78, 0x1f, 0xb4, 0x01, 0x0c, 0xd2, 0xf8, 0x08, 0x5c, 0xfd,
+	0x4a, 0xc8, 0x8b, 0xcf, 0x10, 0x6e, 0x27, 0x83, 0x8f, 0xad, 0xb4, 0x0d, 0xa0, 0x80, 0x1f, 0x03,
+	0x60, 0xa0, 0xe7, 0x64, 0x33, 0x39, 0x55, 0x5a, 0xb1, 0x24, 0x4f, 0x8a, 0x33, 0xb1, 0x9e, 0xf2,
+	0x8d, 0xa2, 0x8c, 0xac, 0xf7, 0xd0, 0xa3, 0x76, 0x96, 0x9d, 0xe4, 0x49, 0xb1, 0x12, 0x4b, 0xa4,
+	0xef, 0xc9, 0x53, 0x23, 0xef, 0x2a, 0x0c, 0xb2, 0x03, 0x0b, 0x88, 0x6c, 0x95, 0x27, 0x45, 0xba,
+	0x3d, 0xe7, 0xd1, 0x84, 0x2f, 0x26, 0xfc, 0xd3, 0x6c, 0x2a, 0x9e, 0x18, 0x79, 0xf7, 0x65, 0xc1,
+	0xaf, 0x7e, 0x9f, 0x10, 0x76, 0x2c, 0x84, 0xde, 0x59, 0x84, 0xff, 0x33, 0xca, 0xc8, 0x06, 0xad,
+	0xf4, 0xd8, 0xba, 0x30, 0xc9, 0x6c, 0xc4, 0x7d, 0xa6, 0xd7, 0x64, 0x1d, 0xcb, 0x47, 0xf6, 0x28,
+	0x5f, 0x15, 0xe9, 0xf6, 0x39, 0x7f, 0x68, 0x3e, 0x3a, 0x88, 0x85, 0xa0, 0x97, 0x24, 0xed, 0xc1,
+	0xb8, 0x3d, 0xa8, 0x4a, 0x2b, 0x64, 0x8f, 0xf3, 0x55, 0x71, 0x26, 0xc8, 0x7c, 0x74, 0xa3, 0x90,
+	0xbe, 0x23, 0xe9, 0xe0, 0x95, 0x0c, 0x50, 0x8d, 0x35, 0xb3, 0xd3, 0x69, 0xf2, 0xec, 0x68, 0xf2,
+	0xdb, 0xe5, 0x0f, 0x04, 0x89, 0xf8, 0x78, 0x30, 0x6a, 0xd6, 0xce, 0xf8, 0x0e, 0x02, 0xb0, 0x75,
+	0xd4, 0x5c, 0xf2, 0xf6, 0x27, 0x49, 0xe7, 0x42, 0xc6, 0x69, 0xa9, 0x23, 0xcf, 0x0e, 0x2b, 0xa2,
+	0xd7, 0xfc, 0x78, 0x65, 0xf8, 0x5f, 0x7e, 0x36, 0x7b, 0xf5, 0x6f, 0x70, 0x6c, 0xfd, 0x4d, 0xf2,
+	0xa1, 0xf8, 0xfa, 0x72, 0x7c, 0xf0, 0x3a, 0xbe, 0x50, 0xb0, 0x2f, 0x1f, 0x62, 0x79, 0xbf, 0xca,
+	0xda, 0xef, 0x76, 0xa7, 0xd3, 0x94, 0x6f, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x40, 0xd2, 0xca,
+	0x4a, 0xe1, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TicketCacheClient is the client API for TicketCache service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TicketCacheClient interface {
+	// GetTicketChanges refreshes the ticket cache unless it is fresh enough for
+	// max_staleness, joining any refresh requested by other callers, and returns
+	// the changes since the caller's version.
+	// Responses are paged by queryPageSize.
+	GetTicketChanges(ctx context.Context, in *GetTicketChangesRequest, opts ...grpc.CallOption) (TicketCache_GetTicketChangesClient, error)
+}
+
+type ticketCacheClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTicketCacheClient(cc *grpc.ClientConn) TicketCacheClient {
+	return &ticketCacheClient{cc}
+}
+
+func (c *ticketCacheClient) GetTicketChanges(ctx context.Context, in *GetTicketChangesRequest, opts ...grpc.CallOption) (TicketCache_GetTicketChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TicketCache_serviceDesc.Streams[0], "/openmatch.internal.TicketCache/GetTicketChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketCacheGetTicketChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TicketCache_GetTicketChangesClient interface {
+	Recv() (*GetTicketChangesResponse, error)
+	grpc.ClientStream
+}
+
+type ticketCacheGetTicketChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketCacheGetTicketChangesClient) Recv() (*GetTicketChangesResponse, error) {
+	m := new(GetTicketChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TicketCacheServer is the server API for TicketCache service.
+type TicketCacheServer interface {
+	// GetTicketChanges refreshes the ticket cache unless it is fresh enough for
+	// max_staleness, joining any refresh requested by other callers, and returns
+	// the changes since the caller's version.
+	// Responses are paged by queryPageSize.
+	GetTicketChanges(*GetTicketChangesRequest, TicketCache_GetTicketChangesServer) error
+}
+
+// UnimplementedTicketCacheServer can be embedded to have forward compatible implementations.
+type UnimplementedTicketCacheServer struct {
+}
+
+func (*UnimplementedTicketCacheServer) GetTicketChanges(req *GetTicketChangesRequest, srv TicketCache_GetTicketChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetTicketChanges not implemented")
+}
+
+func RegisterTicketCacheServer(s *grpc.Server, srv TicketCacheServer) {
+	s.RegisterService(&_TicketCache_serviceDesc, srv)
+}
+
+func _TicketCache_GetTicketChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTicketChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketCacheServer).GetTicketChanges(m, &ticketCacheGetTicketChangesServer{stream})
+}
+
+type TicketCache_GetTicketChangesServer interface {
+	Send(*GetTicketChangesResponse) error
+	grpc.ServerStream
+}
+
+type ticketCacheGetTicketChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketCacheGetTicketChangesServer) Send(m *GetTicketChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _TicketCache_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "openmatch.internal.TicketCache",
+	HandlerType: (*TicketCacheServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetTicketChanges",
+			Handler:       _TicketCache_GetTicketChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/ticketcache.proto",
+}
@@ -16,6 +16,7 @@ package statestore
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/trace"
 	"open-match.dev/open-match/pkg/pb"
@@ -41,30 +42,72 @@ func (is *instrumentedService) CreateTicket(ctx context.Context, ticket *pb.Tick
 	return is.s.CreateTicket(ctx, ticket)
 }
 
+func (is *instrumentedService) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CreateTickets")
+	defer span.End()
+	return is.s.CreateTickets(ctx, tickets)
+}
+
 func (is *instrumentedService) GetTicket(ctx context.Context, id string) (*pb.Ticket, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicket")
 	defer span.End()
 	return is.s.GetTicket(ctx, id)
 }
 
+func (is *instrumentedService) ReserveIdempotencyKey(ctx context.Context, key string, requestHash []byte, ticket *pb.Ticket, window time.Duration) (*pb.Ticket, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReserveIdempotencyKey")
+	defer span.End()
+	return is.s.ReserveIdempotencyKey(ctx, key, requestHash, ticket, window)
+}
+
+func (is *instrumentedService) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteIdempotencyKey")
+	defer span.End()
+	return is.s.DeleteIdempotencyKey(ctx, key)
+}
+
 func (is *instrumentedService) DeleteTicket(ctx context.Context, id string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteTicket")
 	defer span.End()
 	return is.s.DeleteTicket(ctx, id)
 }
 
+func (is *instrumentedService) DeleteTickets(ctx context.Context, ids []string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteTickets")
+	defer span.End()
+	return is.s.DeleteTickets(ctx, ids)
+}
+
+func (is *instrumentedService) UpdateTicket(ctx context.Context, ticket *pb.Ticket) (*pb.Ticket, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateTicket")
+	defer span.End()
+	return is.s.UpdateTicket(ctx, ticket)
+}
+
 func (is *instrumentedService) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexTicket")
 	defer span.End()
 	return is.s.IndexTicket(ctx, ticket)
 }
 
+func (is *instrumentedService) IndexTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexTickets")
+	defer span.End()
+	return is.s.IndexTickets(ctx, tickets)
+}
+
 func (is *instrumentedService) DeindexTicket(ctx context.Context, id string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeindexTicket")
 	defer span.End()
 	return is.s.DeindexTicket(ctx, id)
 }
 
+func (is *instrumentedService) DeindexTickets(ctx context.Context, ids []string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeindexTickets")
+	defer span.End()
+	return is.s.DeindexTickets(ctx, ids)
+}
+
 func (is *instrumentedService) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTickets")
 	defer span.End()
@@ -77,6 +120,18 @@ func (is *instrumentedService) GetIndexedIDSet(ctx context.Context) (map[string]
 	return is.s.GetIndexedIDSet(ctx)
 }
 
+func (is *instrumentedService) GetTicketSnapshot(ctx context.Context) (*TicketSnapshot, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketSnapshot")
+	defer span.End()
+	return is.s.GetTicketSnapshot(ctx)
+}
+
+func (is *instrumentedService) GetTicketChanges(ctx context.Context, cursor string) ([]*TicketChange, string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketChanges")
+	defer span.End()
+	return is.s.GetTicketChanges(ctx, cursor)
+}
+
 func (is *instrumentedService) UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateAssignments")
 	defer span.End()
@@ -89,12 +144,30 @@ func (is *instrumentedService) GetAssignments(ctx context.Context, id string, ca
 	return is.s.GetAssignments(ctx, id, callback)
 }
 
+func (is *instrumentedService) SubscribeAssignments(ctx context.Context, ids []string, all bool, callback func(*pb.Ticket) error) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.SubscribeAssignments")
+	defer span.End()
+	return is.s.SubscribeAssignments(ctx, ids, all, callback)
+}
+
 func (is *instrumentedService) AddTicketsToPendingRelease(ctx context.Context, ids []string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AddTicketsToPendingRelease")
 	defer span.End()
 	return is.s.AddTicketsToPendingRelease(ctx, ids)
 }
 
+func (is *instrumentedService) AddMatchTicketsToPendingRelease(ctx context.Context, tickets map[string][]string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AddMatchTicketsToPendingRelease")
+	defer span.End()
+	return is.s.AddMatchTicketsToPendingRelease(ctx, tickets)
+}
+
+func (is *instrumentedService) GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetPendingReleaseTime")
+	defer span.End()
+	return is.s.GetPendingReleaseTime(ctx, id)
+}
+
 func (is *instrumentedService) DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteTicketsFromPendingRelease")
 	defer span.End()
@@ -106,3 +179,63 @@ func (is *instrumentedService) ReleaseAllTickets(ctx context.Context) error {
 	defer span.End()
 	return is.s.ReleaseAllTickets(ctx)
 }
+
+func (is *instrumentedService) ReleaseMatches(ctx context.Context, tokens []string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReleaseMatches")
+	defer span.End()
+	return is.s.ReleaseMatches(ctx, tokens)
+}
+
+func (is *instrumentedService) DeleteExpiredTickets(ctx context.Context) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteExpiredTickets")
+	defer span.End()
+	return is.s.DeleteExpiredTickets(ctx)
+}
+
+func (is *instrumentedService) CreateBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CreateBackfill")
+	defer span.End()
+	return is.s.CreateBackfill(ctx, backfill)
+}
+
+func (is *instrumentedService) GetBackfill(ctx context.Context, id string) (*pb.Backfill, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetBackfill")
+	defer span.End()
+	return is.s.GetBackfill(ctx, id)
+}
+
+func (is *instrumentedService) GetBackfills(ctx context.Context, ids []string) ([]*pb.Backfill, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetBackfills")
+	defer span.End()
+	return is.s.GetBackfills(ctx, ids)
+}
+
+func (is *instrumentedService) UpdateBackfill(ctx context.Context, backfill *pb.Backfill, expectedGeneration int64) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateBackfill")
+	defer span.End()
+	return is.s.UpdateBackfill(ctx, backfill, expectedGeneration)
+}
+
+func (is *instrumentedService) DeleteBackfill(ctx context.Context, id string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeleteBackfill")
+	defer span.End()
+	return is.s.DeleteBackfill(ctx, id)
+}
+
+func (is *instrumentedService) IndexBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexBackfill")
+	defer span.End()
+	return is.s.IndexBackfill(ctx, backfill)
+}
+
+func (is *instrumentedService) DeindexBackfill(ctx context.Context, id string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeindexBackfill")
+	defer span.End()
+	return is.s.DeindexBackfill(ctx, id)
+}
+
+func (is *instrumentedService) GetIndexedBackfillIDSet(ctx context.Context) (map[string]struct{}, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetIndexedBackfillIDSet")
+	defer span.End()
+	return is.s.GetIndexedBackfillIDSet(ctx)
+}
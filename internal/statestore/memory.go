@@ -0,0 +1,963 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+var (
+	// memoryBackends holds the in memory stores which are currently open,
+	// keyed by the configuration they were created with.  Services which are
+	// bound in the same process with the same configuration (eg, minimatch)
+	// share a single store.
+	memoryBackendsMutex sync.Mutex
+	memoryBackends      = map[config.View]*memoryBackend{}
+)
+
+type memoryTicket struct {
+	ticket *pb.Ticket
+	// expireAt is the time the ticket is automatically deleted, or the zero
+	// value if it does not expire.
+	expireAt time.Time
+}
+
+// memoryReservation is the tickets reserved under a reservation token.  The
+// tickets are only still reserved while reservations maps them to the token.
+type memoryReservation struct {
+	ids []string
+	// expireAt is the time after which the tickets are no longer pending
+	// release, so the reservation can be purged.
+	expireAt time.Time
+}
+
+// memoryIdempotencyRecord is the Ticket recorded for an idempotency key, with
+// the hash of the request which recorded it.
+type memoryIdempotencyRecord struct {
+	requestHash []byte
+	ticket      *pb.Ticket
+	expireAt    time.Time
+}
+
+type memoryBackend struct {
+	cfg config.View
+	// refs is the number of Services which have been handed out for this
+	// store and not yet closed.  Guarded by memoryBackendsMutex.
+	refs int
+
+	mu      sync.Mutex
+	tickets map[string]*memoryTicket
+	indexed map[string]struct{}
+	// pending maps ticket ids to the time they were added to pending release.
+	pending map[string]time.Time
+	// reservations maps the ids of pending tickets to the token of the
+	// reservation of the match they were proposed in.
+	reservations map[string]string
+	// reserved maps reservation tokens to their tickets, so that matches are
+	// released without scanning every reservation.  Expired reservations are
+	// purged once the map grows to reservedPurgeAt.
+	reserved        map[string]*memoryReservation
+	reservedPurgeAt int
+	// expiring maps ticket ids to their expire time, for tickets which have
+	// not yet been assigned or deleted.
+	expiring map[string]time.Time
+
+	backfills        map[string]*pb.Backfill
+	indexedBackfills map[string]struct{}
+
+	// idempotencyKeys holds the records of idempotency keys.  Expired records
+	// are purged once the map grows to idempotencyKeysPurgeAt.
+	idempotencyKeys        map[string]*memoryIdempotencyRecord
+	idempotencyKeysPurgeAt int
+
+	// changed is closed and replaced every time a ticket is modified, to wake
+	// up any assignment watchers.
+	changed chan struct{}
+	// subscribers are the open assignment subscriptions.
+	subscribers map[*memorySubscriber]struct{}
+
+	// changes holds the most recent ticket changes.  changesBase is the
+	// position in the change log of the first retained change.
+	changes     []*TicketChange
+	changesBase int
+}
+
+// memorySubscriber is an open assignment subscription.
+type memorySubscriber struct {
+	// ids are the tickets subscribed to, unless all is set.
+	ids map[string]struct{}
+	all bool
+	// queue holds assigned tickets which are not yet passed to the
+	// subscriber's callback.  Guarded by memoryBackend.mu.
+	queue []*pb.Ticket
+	// wake is signaled when tickets are added to the queue.
+	wake chan struct{}
+}
+
+// newMemory returns a statestore.Service which keeps all state in the memory
+// of the current process.  It is intended for single binary deployments, such
+// as minimatch, and local development.
+func newMemory(cfg config.View) Service {
+	memoryBackendsMutex.Lock()
+	defer memoryBackendsMutex.Unlock()
+
+	mb, ok := memoryBackends[cfg]
+	if !ok {
+		mb = &memoryBackend{
+			cfg:      cfg,
+			tickets:  make(map[string]*memoryTicket),
+			indexed:  make(map[string]struct{}),
+			pending:  make(map[string]time.Time),
+			expiring: make(map[string]time.Time),
+
+			reservations: make(map[string]string),
+			reserved:     make(map[string]*memoryReservation),
+
+			backfills:        make(map[string]*pb.Backfill),
+			indexedBackfills: make(map[string]struct{}),
+
+			idempotencyKeys: make(map[string]*memoryIdempotencyRecord),
+
+			changed:     make(chan struct{}),
+			subscribers: make(map[*memorySubscriber]struct{}),
+		}
+		memoryBackends[cfg] = mb
+	}
+	mb.refs++
+	return &memoryService{mb: mb}
+}
+
+// memoryService is a handle to a shared memoryBackend.  It ensures each
+// handle releases its reference exactly once.
+type memoryService struct {
+	mb        *memoryBackend
+	closeOnce sync.Once
+}
+
+// Close releases the handle to the store.  The store's contents are dropped
+// once every handle has been closed.
+func (ms *memoryService) Close() error {
+	ms.closeOnce.Do(func() {
+		memoryBackendsMutex.Lock()
+		defer memoryBackendsMutex.Unlock()
+
+		ms.mb.refs--
+		if ms.mb.refs == 0 {
+			delete(memoryBackends, ms.mb.cfg)
+		}
+	})
+	return nil
+}
+
+// HealthCheck indicates if the database is reachable.  The in memory store is
+// always reachable.
+func (ms *memoryService) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// notifyLocked wakes up anyone waiting for ticket changes.  Must be called
+// while holding mb.mu.
+func (mb *memoryBackend) notifyLocked() {
+	close(mb.changed)
+	mb.changed = make(chan struct{})
+}
+
+// getLocked returns the ticket with the given id, removing it first if it has
+// expired.  Must be called while holding mb.mu.
+func (mb *memoryBackend) getLocked(id string) (*pb.Ticket, bool) {
+	mt, ok := mb.tickets[id]
+	if !ok {
+		return nil, false
+	}
+	if !mt.expireAt.IsZero() && !time.Now().Before(mt.expireAt) {
+		delete(mb.tickets, id)
+		return nil, false
+	}
+	return mt.ticket, true
+}
+
+// CreateTicket creates a new Ticket in the state storage. If the id already exists, it will be overwritten.
+func (ms *memoryService) CreateTicket(ctx context.Context, ticket *pb.Ticket) error {
+	return ms.CreateTickets(ctx, []*pb.Ticket{ticket})
+}
+
+// CreateTickets creates multiple Tickets in the state storage.  Tickets whose
+// ids already exist are overwritten.
+func (ms *memoryService) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	mts := make([]*memoryTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		mt := &memoryTicket{
+			ticket: proto.Clone(ticket).(*pb.Ticket),
+		}
+		if ticket.GetExpireTime() != nil {
+			expireTime, err := ptypes.Timestamp(ticket.GetExpireTime())
+			if err != nil {
+				err = errors.Wrapf(err, "invalid expire time for ticket, id: %s", ticket.GetId())
+				return status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			mt.expireAt = expireTime
+		}
+		mts = append(mts, mt)
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for _, mt := range mts {
+		id := mt.ticket.GetId()
+		if !mt.expireAt.IsZero() {
+			mb.expiring[id] = mt.expireAt
+		}
+		mb.tickets[id] = mt
+	}
+	mb.notifyLocked()
+	return nil
+}
+
+// GetTicket gets the Ticket with the specified id from state storage. This method fails if the Ticket does not exist.
+func (ms *memoryService) GetTicket(ctx context.Context, id string) (*pb.Ticket, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	t, ok := mb.getLocked(id)
+	if !ok {
+		msg := fmt.Sprintf("Ticket id:%s not found", id)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	return proto.Clone(t).(*pb.Ticket), nil
+}
+
+// ReserveIdempotencyKey records the Ticket for the idempotency key, unless a
+// Ticket is already recorded for it, in which case that Ticket is returned.
+func (ms *memoryService) ReserveIdempotencyKey(ctx context.Context, key string, requestHash []byte, ticket *pb.Ticket, window time.Duration) (*pb.Ticket, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	now := time.Now()
+	if r, ok := mb.idempotencyKeys[key]; ok && now.Before(r.expireAt) {
+		if !bytes.Equal(r.requestHash, requestHash) {
+			return nil, status.Errorf(codes.FailedPrecondition, "idempotency key %s was used by a different request", key)
+		}
+		return proto.Clone(r.ticket).(*pb.Ticket), nil
+	}
+	if len(mb.idempotencyKeys) >= mb.idempotencyKeysPurgeAt {
+		for k, r := range mb.idempotencyKeys {
+			if !now.Before(r.expireAt) {
+				delete(mb.idempotencyKeys, k)
+			}
+		}
+		mb.idempotencyKeysPurgeAt = 2*len(mb.idempotencyKeys) + 1
+	}
+
+	mb.idempotencyKeys[key] = &memoryIdempotencyRecord{
+		requestHash: requestHash,
+		ticket:      proto.Clone(ticket).(*pb.Ticket),
+		expireAt:    now.Add(window),
+	}
+	return nil, nil
+}
+
+// DeleteIdempotencyKey removes the record of the idempotency key.
+func (ms *memoryService) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	delete(mb.idempotencyKeys, key)
+	return nil
+}
+
+// DeleteTicket removes the Ticket with the specified id from state storage.
+func (ms *memoryService) DeleteTicket(ctx context.Context, id string) error {
+	return ms.DeleteTickets(ctx, []string{id})
+}
+
+// DeleteTickets removes multiple Tickets from state storage.
+func (ms *memoryService) DeleteTickets(ctx context.Context, ids []string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	deleted := false
+	for _, id := range ids {
+		delete(mb.expiring, id)
+		delete(mb.reservations, id)
+		if _, ok := mb.tickets[id]; ok {
+			delete(mb.tickets, id)
+			deleted = true
+		}
+	}
+	if deleted {
+		mb.notifyLocked()
+	}
+	return nil
+}
+
+// UpdateTicket replaces the SearchFields and Extensions of an existing Ticket,
+// unless it is assigned or pending release.
+func (ms *memoryService) UpdateTicket(ctx context.Context, ticket *pb.Ticket) (*pb.Ticket, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	current, ok := mb.getLocked(ticket.GetId())
+	if !ok {
+		msg := fmt.Sprintf("Ticket id:%s not found", ticket.GetId())
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	if current.GetAssignment() != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket %s is assigned", ticket.GetId())
+	}
+	ttl := mb.cfg.GetDuration("pendingReleaseTimeout")
+	if proposed, ok := mb.pending[ticket.GetId()]; ok && time.Since(proposed) <= ttl {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket %s is pending release", ticket.GetId())
+	}
+
+	updated := proto.Clone(current).(*pb.Ticket)
+	updated.SearchFields = proto.Clone(ticket.GetSearchFields()).(*pb.SearchFields)
+	updated.Extensions = ticket.GetExtensions()
+	mb.tickets[ticket.GetId()].ticket = proto.Clone(updated).(*pb.Ticket)
+	mb.recordChangeLocked(TicketsUpdated, time.Now(), []string{ticket.GetId()})
+	mb.notifyLocked()
+	return updated, nil
+}
+
+// IndexTicket indexes the Ticket id for the configured index fields.
+func (ms *memoryService) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
+	return ms.IndexTickets(ctx, []*pb.Ticket{ticket})
+}
+
+// IndexTickets indexes multiple Tickets.
+func (ms *memoryService) IndexTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	ids := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		mb.indexed[ticket.GetId()] = struct{}{}
+		ids = append(ids, ticket.GetId())
+	}
+	mb.recordChangeLocked(TicketsIndexed, time.Now(), ids)
+	return nil
+}
+
+// DeindexTicket removes the indexing for the specified Ticket. Only the indexes are removed but the Ticket continues to exist.
+func (ms *memoryService) DeindexTicket(ctx context.Context, id string) error {
+	return ms.DeindexTickets(ctx, []string{id})
+}
+
+// DeindexTickets removes the indexing for multiple Tickets.  The Tickets
+// continue to exist.
+func (ms *memoryService) DeindexTickets(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for _, id := range ids {
+		delete(mb.indexed, id)
+	}
+	mb.recordChangeLocked(TicketsDeindexed, time.Now(), ids)
+	return nil
+}
+
+// recordChangeLocked appends a change to the ticket change log, trimming the
+// oldest changes once the log is twice the configured length.  Must be called
+// while holding mb.mu.
+func (mb *memoryBackend) recordChangeLocked(kind TicketChangeKind, t time.Time, ids []string) {
+	mb.changes = append(mb.changes, &TicketChange{
+		Kind: kind,
+		IDs:  append([]string(nil), ids...),
+		Time: t,
+	})
+
+	length := getTicketChangeLogLength(mb.cfg)
+	if len(mb.changes) >= 2*length {
+		trim := len(mb.changes) - length
+		mb.changes = append([]*TicketChange(nil), mb.changes[trim:]...)
+		mb.changesBase += trim
+	}
+}
+
+// changesCursorLocked returns the cursor for the end of the change log.  Must
+// be called while holding mb.mu.
+func (mb *memoryBackend) changesCursorLocked() string {
+	return strconv.Itoa(mb.changesBase + len(mb.changes))
+}
+
+// GetTicketSnapshot returns the current index and pending release state,
+// along with a cursor for reading the changes made after it.
+func (ms *memoryService) GetTicketSnapshot(ctx context.Context) (*TicketSnapshot, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	snapshot := &TicketSnapshot{
+		Cursor:  mb.changesCursorLocked(),
+		Indexed: make(map[string]struct{}, len(mb.indexed)),
+		Pending: make(map[string]time.Time, len(mb.pending)),
+	}
+	for id := range mb.indexed {
+		snapshot.Indexed[id] = struct{}{}
+	}
+	for id, t := range mb.pending {
+		snapshot.Pending[id] = t
+	}
+	return snapshot, nil
+}
+
+// GetTicketChanges returns the changes made to the index and pending release
+// state after the cursor, in order, along with the cursor to use for the next
+// call.  This method fails with OutOfRange if changes after the cursor have
+// been trimmed.
+func (ms *memoryService) GetTicketChanges(ctx context.Context, cursor string) ([]*TicketChange, string, error) {
+	pos, err := strconv.Atoi(cursor)
+	if err != nil {
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid ticket changes cursor %q", cursor)
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if pos < mb.changesBase || pos > mb.changesBase+len(mb.changes) {
+		return nil, "", status.Errorf(codes.OutOfRange, "ticket changes after cursor %s are no longer retained", cursor)
+	}
+	changes := append([]*TicketChange(nil), mb.changes[pos-mb.changesBase:]...)
+	return changes, mb.changesCursorLocked(), nil
+}
+
+// GetIndexedIDSet returns the ids of all tickets currently indexed.
+func (ms *memoryService) GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	ttl := mb.cfg.GetDuration("pendingReleaseTimeout")
+	curTime := time.Now()
+	endTime := curTime.Add(time.Hour)
+	startTime := curTime.Add(-ttl)
+
+	r := make(map[string]struct{}, len(mb.indexed))
+	for id := range mb.indexed {
+		// Filter out tickets that are fetched but not assigned within ttl time.
+		if proposed, ok := mb.pending[id]; ok && !proposed.Before(startTime) && !proposed.After(endTime) {
+			continue
+		}
+		// Filter out tickets which have expired, but haven't been deleted yet.
+		if expireTime, ok := mb.expiring[id]; ok && !curTime.Before(expireTime) {
+			continue
+		}
+		r[id] = struct{}{}
+	}
+	return r, nil
+}
+
+// GetTickets returns multiple tickets from storage.  Missing tickets are
+// silently ignored.
+func (ms *memoryService) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	r := make([]*pb.Ticket, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := mb.getLocked(id); ok {
+			r = append(r, proto.Clone(t).(*pb.Ticket))
+		}
+	}
+	return r, nil
+}
+
+// UpdateAssignments update using the request's specified tickets with assignments.
+func (ms *memoryService) UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
+	resp := &pb.AssignTicketsResponse{}
+	if len(req.Assignments) == 0 {
+		return resp, nil
+	}
+
+	idToA := make(map[string]*pb.Assignment)
+	ids := make([]string, 0)
+	for _, a := range req.Assignments {
+		if a.Assignment == nil {
+			return nil, status.Error(codes.InvalidArgument, "AssignmentGroup.Assignment is required")
+		}
+
+		for _, id := range a.TicketIds {
+			if _, ok := idToA[id]; ok {
+				return nil, status.Errorf(codes.InvalidArgument, "Ticket id %s is assigned multiple times in one assign tickets call.", id)
+			}
+
+			idToA[id] = a.Assignment
+			ids = append(ids, id)
+		}
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	lost := mb.getLostReservationsLocked(req)
+	assignmentTimeout := mb.cfg.GetDuration("assignedDeleteTimeout")
+	expireAt := time.Now().Add(assignmentTimeout)
+	for _, id := range ids {
+		t, ok := mb.getLocked(id)
+		if !ok {
+			resp.Failures = append(resp.Failures, &pb.AssignmentFailure{
+				TicketId: id,
+				Cause:    pb.AssignmentFailure_TICKET_NOT_FOUND,
+			})
+			continue
+		}
+		if _, ok := lost[id]; ok {
+			resp.Failures = append(resp.Failures, &pb.AssignmentFailure{
+				TicketId: id,
+				Cause:    pb.AssignmentFailure_RESERVATION_LOST,
+			})
+			continue
+		}
+
+		t = proto.Clone(t).(*pb.Ticket)
+		t.Assignment = proto.Clone(idToA[id]).(*pb.Assignment)
+		mb.tickets[id] = &memoryTicket{
+			ticket:   t,
+			expireAt: expireAt,
+		}
+		// Assigned tickets are deleted after assignedDeleteTimeout instead of
+		// their expire time, and are no longer reserved.
+		delete(mb.expiring, id)
+		delete(mb.reservations, id)
+
+		for sub := range mb.subscribers {
+			if _, ok := sub.ids[id]; !ok && !sub.all {
+				continue
+			}
+			sub.queue = append(sub.queue, t)
+			select {
+			case sub.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+	mb.notifyLocked()
+
+	// Wake up watchers once the assigned tickets expire, so that they observe
+	// the deletion.
+	time.AfterFunc(assignmentTimeout, func() {
+		mb.mu.Lock()
+		defer mb.mu.Unlock()
+		mb.notifyLocked()
+	})
+
+	return resp, nil
+}
+
+// getLostReservationsLocked returns the ids of the tickets in groups which
+// specify a reservation token, where any of the group's tickets is not pending
+// release under that token.  Must be called while holding mb.mu.
+func (mb *memoryBackend) getLostReservationsLocked(req *pb.AssignTicketsRequest) map[string]struct{} {
+	lost := make(map[string]struct{})
+	startTime := time.Now().Add(-mb.cfg.GetDuration("pendingReleaseTimeout"))
+
+	for _, a := range req.Assignments {
+		if a.ReservationToken == "" {
+			continue
+		}
+
+		reserved := true
+		for _, id := range a.TicketIds {
+			proposed, ok := mb.pending[id]
+			if !ok || proposed.Before(startTime) || mb.reservations[id] != a.ReservationToken {
+				reserved = false
+			}
+		}
+		if !reserved {
+			for _, id := range a.TicketIds {
+				lost[id] = struct{}{}
+			}
+		}
+	}
+	return lost
+}
+
+// GetAssignments returns the assignment associated with the input ticket id.
+// Rather than polling, the callback is invoked each time the store changes.
+func (ms *memoryService) GetAssignments(ctx context.Context, id string, callback func(*pb.Assignment) error) error {
+	mb := ms.mb
+	for {
+		mb.mu.Lock()
+		t, ok := mb.getLocked(id)
+		changed := mb.changed
+		mb.mu.Unlock()
+
+		if !ok {
+			msg := fmt.Sprintf("Ticket id:%s not found", id)
+			return status.Error(codes.NotFound, msg)
+		}
+
+		var a *pb.Assignment
+		if t.GetAssignment() != nil {
+			a = proto.Clone(t.GetAssignment()).(*pb.Assignment)
+		}
+		err := callback(a)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		case <-changed:
+		}
+	}
+}
+
+// SubscribeAssignments calls callback with the tickets in ids, or every
+// ticket if all is set, which are assigned while the subscription is open.
+// Tickets in ids which already have an assignment are passed to callback
+// first.
+func (ms *memoryService) SubscribeAssignments(ctx context.Context, ids []string, all bool, callback func(*pb.Ticket) error) error {
+	if len(ids) == 0 && !all {
+		return status.Error(codes.InvalidArgument, "SubscribeAssignments, no tickets to subscribe to")
+	}
+
+	mb := ms.mb
+	sub := &memorySubscriber{
+		ids:  make(map[string]struct{}, len(ids)),
+		all:  all,
+		wake: make(chan struct{}, 1),
+	}
+
+	mb.mu.Lock()
+	for _, id := range ids {
+		sub.ids[id] = struct{}{}
+		if t, ok := mb.getLocked(id); ok && t.GetAssignment() != nil {
+			sub.queue = append(sub.queue, t)
+		}
+	}
+	mb.subscribers[sub] = struct{}{}
+	mb.mu.Unlock()
+
+	defer func() {
+		mb.mu.Lock()
+		defer mb.mu.Unlock()
+		delete(mb.subscribers, sub)
+	}()
+
+	for {
+		mb.mu.Lock()
+		queue := sub.queue
+		sub.queue = nil
+		mb.mu.Unlock()
+
+		for _, t := range queue {
+			err := callback(proto.Clone(t).(*pb.Ticket))
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		case <-sub.wake:
+		}
+	}
+}
+
+// AddTicketsToPendingRelease appends new proposed tickets to the proposed set with current timestamp
+func (ms *memoryService) AddTicketsToPendingRelease(ctx context.Context, ids []string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	currentTime := time.Now()
+	for _, id := range ids {
+		mb.pending[id] = currentTime
+		delete(mb.reservations, id)
+	}
+	mb.recordChangeLocked(TicketsAddedToPendingRelease, currentTime, ids)
+	return nil
+}
+
+// AddMatchTicketsToPendingRelease appends the tickets of matches to the
+// proposed set with current timestamp, and records their reservations.
+func (ms *memoryService) AddMatchTicketsToPendingRelease(ctx context.Context, tickets map[string][]string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	currentTime := time.Now()
+	if len(mb.reserved) >= mb.reservedPurgeAt {
+		for token, r := range mb.reserved {
+			if !currentTime.Before(r.expireAt) {
+				delete(mb.reserved, token)
+			}
+		}
+		mb.reservedPurgeAt = 2*len(mb.reserved) + 1
+	}
+
+	expireAt := currentTime.Add(mb.cfg.GetDuration("pendingReleaseTimeout"))
+	var ids []string
+	for token, matchTickets := range tickets {
+		for _, id := range matchTickets {
+			mb.pending[id] = currentTime
+			mb.reservations[id] = token
+			ids = append(ids, id)
+		}
+		mb.reserved[token] = &memoryReservation{
+			ids:      append([]string(nil), matchTickets...),
+			expireAt: expireAt,
+		}
+	}
+	if len(ids) > 0 {
+		mb.recordChangeLocked(TicketsAddedToPendingRelease, currentTime, ids)
+	}
+	return nil
+}
+
+// GetPendingReleaseTime returns the time the ticket was added to pending
+// release, and false if the ticket is not pending release.
+func (ms *memoryService) GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	t, ok := mb.pending[id]
+	return t, ok, nil
+}
+
+// DeleteTicketsFromPendingRelease deletes tickets from the proposed set
+func (ms *memoryService) DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	for _, id := range ids {
+		delete(mb.pending, id)
+		delete(mb.reservations, id)
+	}
+	mb.recordChangeLocked(TicketsDeletedFromPendingRelease, time.Now(), ids)
+	return nil
+}
+
+// ReleaseAllTickets releases all pending tickets back to active
+func (ms *memoryService) ReleaseAllTickets(ctx context.Context) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.pending = make(map[string]time.Time)
+	mb.reservations = make(map[string]string)
+	mb.reserved = make(map[string]*memoryReservation)
+	mb.recordChangeLocked(AllTicketsReleased, time.Now(), nil)
+	return nil
+}
+
+// ReleaseMatches releases the tickets still pending under the reservation
+// tokens back to active, returning the ids of the released tickets.
+func (ms *memoryService) ReleaseMatches(ctx context.Context, tokens []string) ([]string, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	var ids []string
+	for _, token := range tokens {
+		r, ok := mb.reserved[token]
+		if !ok {
+			continue
+		}
+		delete(mb.reserved, token)
+		for _, id := range r.ids {
+			if mb.reservations[id] != token {
+				continue
+			}
+			ids = append(ids, id)
+			delete(mb.pending, id)
+			delete(mb.reservations, id)
+		}
+	}
+	if len(ids) > 0 {
+		mb.recordChangeLocked(TicketsDeletedFromPendingRelease, time.Now(), ids)
+	}
+	return ids, nil
+}
+
+// DeleteExpiredTickets deindexes and deletes all tickets whose expire time has
+// passed, returning the ids of the tickets it removed.
+func (ms *memoryService) DeleteExpiredTickets(ctx context.Context) ([]string, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	now := time.Now()
+	var ids []string
+	for id, expireTime := range mb.expiring {
+		if now.Before(expireTime) {
+			continue
+		}
+		ids = append(ids, id)
+		delete(mb.expiring, id)
+		delete(mb.indexed, id)
+		delete(mb.pending, id)
+		delete(mb.reservations, id)
+		delete(mb.tickets, id)
+	}
+	if len(ids) > 0 {
+		mb.recordChangeLocked(TicketsDeindexed, now, ids)
+		mb.recordChangeLocked(TicketsDeletedFromPendingRelease, now, ids)
+		mb.notifyLocked()
+	}
+	return ids, nil
+}
+
+// CreateBackfill creates a new Backfill in the state storage. If the id already exists, it will be overwritten.
+func (ms *memoryService) CreateBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.backfills[backfill.GetId()] = proto.Clone(backfill).(*pb.Backfill)
+	return nil
+}
+
+// GetBackfill gets the Backfill with the specified id from state storage. This method fails if the Backfill does not exist.
+func (ms *memoryService) GetBackfill(ctx context.Context, id string) (*pb.Backfill, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	b, ok := mb.backfills[id]
+	if !ok {
+		msg := fmt.Sprintf("Backfill id:%s not found", id)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	return proto.Clone(b).(*pb.Backfill), nil
+}
+
+// GetBackfills returns multiple backfills from storage.  Missing backfills are
+// silently ignored.
+func (ms *memoryService) GetBackfills(ctx context.Context, ids []string) ([]*pb.Backfill, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	r := make([]*pb.Backfill, 0, len(ids))
+	for _, id := range ids {
+		if b, ok := mb.backfills[id]; ok {
+			r = append(r, proto.Clone(b).(*pb.Backfill))
+		}
+	}
+	return r, nil
+}
+
+// UpdateBackfill overwrites an existing Backfill, only if its stored
+// generation is expectedGeneration.
+func (ms *memoryService) UpdateBackfill(ctx context.Context, backfill *pb.Backfill, expectedGeneration int64) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	current, ok := mb.backfills[backfill.GetId()]
+	if !ok {
+		msg := fmt.Sprintf("Backfill id:%s not found", backfill.GetId())
+		return status.Error(codes.NotFound, msg)
+	}
+	if current.GetGeneration() != expectedGeneration {
+		return status.Errorf(codes.Aborted, "backfill %s has generation %d, expected %d", backfill.GetId(), current.GetGeneration(), expectedGeneration)
+	}
+
+	mb.backfills[backfill.GetId()] = proto.Clone(backfill).(*pb.Backfill)
+	return nil
+}
+
+// DeleteBackfill removes the Backfill with the specified id from state storage.
+func (ms *memoryService) DeleteBackfill(ctx context.Context, id string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	delete(mb.backfills, id)
+	return nil
+}
+
+// IndexBackfill adds the Backfill id to the index.
+func (ms *memoryService) IndexBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.indexedBackfills[backfill.GetId()] = struct{}{}
+	return nil
+}
+
+// DeindexBackfill removes the Backfill id from the index. The Backfill continues to exist.
+func (ms *memoryService) DeindexBackfill(ctx context.Context, id string) error {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	delete(mb.indexedBackfills, id)
+	return nil
+}
+
+// GetIndexedBackfillIDSet returns the ids of all backfills currently indexed.
+func (ms *memoryService) GetIndexedBackfillIDSet(ctx context.Context) (map[string]struct{}, error) {
+	mb := ms.mb
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	r := make(map[string]struct{}, len(mb.indexedBackfills))
+	for id := range mb.indexedBackfills {
+		r[id] = struct{}{}
+	}
+	return r, nil
+}
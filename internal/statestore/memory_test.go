@@ -0,0 +1,295 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	internalTesting "open-match.dev/open-match/internal/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestMemoryTicketLifecycle(t *testing.T) {
+	service := New(createMemory())
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	ticket := &pb.Ticket{
+		Id: "1",
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{
+				"testindex1": 42,
+			},
+		},
+	}
+
+	_, err := service.GetTicket(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, service.DeleteTicket(ctx, "1"))
+	require.Nil(t, service.DeindexTicket(ctx, "1"))
+
+	require.Nil(t, service.CreateTicket(ctx, ticket))
+	// Modifying the original must not modify the stored ticket.
+	ticket.SearchFields.DoubleArgs["testindex1"] = 0
+
+	result, err := service.GetTicket(ctx, "1")
+	require.Nil(t, err)
+	require.Equal(t, float64(42), result.SearchFields.DoubleArgs["testindex1"])
+
+	tickets, err := service.GetTickets(ctx, []string{"1", "missing"})
+	require.Nil(t, err)
+	require.Len(t, tickets, 1)
+
+	require.Nil(t, service.DeleteTicket(ctx, "1"))
+	_, err = service.GetTicket(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMemoryPendingReleases(t *testing.T) {
+	cfg := createMemory()
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	tickets := internalTesting.GenerateFloatRangeTickets(
+		internalTesting.Property{Name: "testindex1", Min: 0, Max: 10, Interval: 2},
+		internalTesting.Property{Name: "testindex2", Min: 0, Max: 10, Interval: 2},
+	)
+
+	ticketIds := []string{}
+	for _, ticket := range tickets {
+		require.Nil(t, service.CreateTicket(ctx, ticket))
+		require.Nil(t, service.IndexTicket(ctx, ticket))
+		ticketIds = append(ticketIds, ticket.GetId())
+	}
+
+	verifyTickets := func(expectLen int) {
+		ids, err := service.GetIndexedIDSet(ctx)
+		require.Nil(t, err)
+		require.Equal(t, expectLen, len(ids))
+	}
+
+	verifyTickets(len(tickets))
+
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, ticketIds[:3]))
+	verifyTickets(len(tickets) - 3)
+
+	require.Nil(t, service.DeleteTicketsFromPendingRelease(ctx, ticketIds[:1]))
+	verifyTickets(len(tickets) - 2)
+
+	_, ok, err := service.GetPendingReleaseTime(ctx, ticketIds[0])
+	require.Nil(t, err)
+	require.False(t, ok)
+	_, ok, err = service.GetPendingReleaseTime(ctx, ticketIds[1])
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	require.Nil(t, service.ReleaseAllTickets(ctx))
+	verifyTickets(len(tickets))
+
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, ticketIds[:3]))
+	verifyTickets(len(tickets) - 3)
+
+	// Sleep until the pending release expired and verify we still have all the tickets
+	time.Sleep(cfg.GetDuration("pendingReleaseTimeout"))
+	verifyTickets(len(tickets))
+}
+
+func TestMemoryUpdateAssignments(t *testing.T) {
+	cfg := createMemory()
+	service := New(cfg)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	require.Nil(t, service.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	_, err := service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{"1"}}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"1", "2"},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, resp.Failures, 1)
+	require.Equal(t, "2", resp.Failures[0].TicketId)
+	require.Equal(t, pb.AssignmentFailure_TICKET_NOT_FOUND, resp.Failures[0].Cause)
+
+	ticket, err := service.GetTicket(ctx, "1")
+	require.Nil(t, err)
+	require.Equal(t, "a", ticket.Assignment.Connection)
+
+	// Assigned tickets are deleted after assignedDeleteTimeout.
+	time.Sleep(cfg.GetDuration("assignedDeleteTimeout"))
+	_, err = service.GetTicket(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMemoryGetAssignments(t *testing.T) {
+	service := New(createMemory())
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	err := service.GetAssignments(ctx, "1", func(*pb.Assignment) error {
+		require.Fail(t, "callback called for missing ticket")
+		return nil
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.Nil(t, service.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	assignments := make(chan *pb.Assignment)
+	errs := make(chan error)
+	go func() {
+		errs <- service.GetAssignments(ctx, "1", func(a *pb.Assignment) error {
+			assignments <- a
+			return nil
+		})
+	}()
+
+	require.Nil(t, <-assignments)
+
+	_, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"1"},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "a", (<-assignments).Connection)
+
+	// Once the assigned ticket is deleted, the watch stops.
+	require.Equal(t, codes.NotFound, status.Code(<-errs))
+}
+
+func TestMemorySharedByConfig(t *testing.T) {
+	cfg := createMemory()
+	ctx := utilTesting.NewContext(t)
+
+	s1 := New(cfg)
+	s2 := New(cfg)
+	require.Nil(t, s1.CreateTicket(ctx, &pb.Ticket{Id: "1"}))
+
+	_, err := s2.GetTicket(ctx, "1")
+	require.Nil(t, err)
+
+	// Another config gets its own store.
+	s3 := New(createMemory())
+	defer s3.Close()
+	_, err = s3.GetTicket(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The store's contents are dropped once all services are closed.
+	require.Nil(t, s1.Close())
+	require.Nil(t, s1.Close())
+	_, err = s2.GetTicket(ctx, "1")
+	require.Nil(t, err)
+	require.Nil(t, s2.Close())
+
+	s4 := New(cfg)
+	defer s4.Close()
+	_, err = s4.GetTicket(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func createMemory() config.Mutable {
+	cfg := viper.New()
+	cfg.Set("statestore.backend", "memory")
+	cfg.Set("pendingReleaseTimeout", "200ms")
+	cfg.Set("assignedDeleteTimeout", "200ms")
+	return cfg
+}
+
+func TestMemoryDeleteExpiredTickets(t *testing.T) {
+	testDeleteExpiredTickets(t, createMemory())
+}
+
+func TestMemoryBackfillLifecycle(t *testing.T) {
+	testBackfillLifecycle(t, createMemory())
+}
+
+func TestMemorySubscribeAssignments(t *testing.T) {
+	testSubscribeAssignments(t, createMemory())
+}
+
+func TestMemoryTicketChanges(t *testing.T) {
+	testTicketChanges(t, createMemory())
+}
+
+func TestMemoryUpdateTicket(t *testing.T) {
+	testUpdateTicket(t, createMemory())
+}
+
+func TestMemoryTicketBatches(t *testing.T) {
+	testTicketBatches(t, createMemory())
+}
+
+func TestMemoryIdempotencyKeys(t *testing.T) {
+	testIdempotencyKeys(t, createMemory())
+}
+
+func TestMemoryIdempotencyKeyExpires(t *testing.T) {
+	service := New(createMemory())
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	existing, err := service.ReserveIdempotencyKey(ctx, "key", []byte("hash"), &pb.Ticket{Id: "1"}, time.Millisecond)
+	require.Nil(t, err)
+	require.Nil(t, existing)
+
+	time.Sleep(2 * time.Millisecond)
+	existing, err = service.ReserveIdempotencyKey(ctx, "key", []byte("other"), &pb.Ticket{Id: "2"}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, existing)
+}
+
+func TestMemoryConditionalAssignments(t *testing.T) {
+	testConditionalAssignments(t, createMemory())
+}
+
+func TestMemoryReleaseMatches(t *testing.T) {
+	testReleaseMatches(t, createMemory())
+}
+
+func TestMemoryAssignedAndDeletedTicketsUnreserved(t *testing.T) {
+	cfg := createMemory()
+	ms := New(cfg).(*memoryService)
+	defer ms.Close()
+
+	testAssignedAndDeletedTicketsUnreserved(t, cfg, func(id string) bool {
+		ms.mb.mu.Lock()
+		defer ms.mb.mu.Unlock()
+		_, ok := ms.mb.reservations[id]
+		return ok
+	})
+}
@@ -16,12 +16,22 @@ package statestore
 
 import (
 	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/telemetry"
 	"open-match.dev/open-match/pkg/pb"
 )
 
+var (
+	logger = logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "statestore",
+	})
+)
+
 // Service is a generic interface for talking to a storage backend.
 type Service interface {
 	// HealthCheck indicates if the database is reachable.
@@ -33,43 +43,217 @@ type Service interface {
 	// GetTicket gets the Ticket with the specified id from state storage. This method fails if the Ticket does not exist.
 	GetTicket(ctx context.Context, id string) (*pb.Ticket, error)
 
+	// CreateTickets creates multiple Tickets in the state storage, in a single
+	// round trip where the storage supports it.  Tickets whose ids already
+	// exist are overwritten.
+	CreateTickets(ctx context.Context, tickets []*pb.Ticket) error
+
+	// ReserveIdempotencyKey records the Ticket as the one created for the
+	// idempotency key by the request with the hash, for the length of the
+	// window, unless a Ticket is already recorded for the key.  It returns the
+	// Ticket recorded by an earlier call, or nil if this call recorded the
+	// Ticket.  It fails with FailedPrecondition if the earlier call's request
+	// hash differs.
+	ReserveIdempotencyKey(ctx context.Context, key string, requestHash []byte, ticket *pb.Ticket, window time.Duration) (*pb.Ticket, error)
+
+	// DeleteIdempotencyKey removes the record of the idempotency key, so a
+	// later request with the key creates a new Ticket.
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+
 	// DeleteTicket removes the Ticket with the specified id from state storage. This method succeeds if the Ticket does not exist.
 	DeleteTicket(ctx context.Context, id string) error
 
+	// DeleteTickets removes multiple Tickets from state storage. This method
+	// succeeds for Tickets which do not exist.
+	DeleteTickets(ctx context.Context, ids []string) error
+
+	// UpdateTicket replaces the SearchFields and Extensions of an existing
+	// Ticket with those of ticket, keeping the rest of the stored Ticket, and
+	// returns the updated Ticket.  This method fails with NotFound
+	// if the Ticket does not exist, and FailedPrecondition if it is assigned
+	// or pending release.
+	UpdateTicket(ctx context.Context, ticket *pb.Ticket) (*pb.Ticket, error)
+
 	// IndexTicket adds the ticket to the index.
 	IndexTicket(ctx context.Context, ticket *pb.Ticket) error
 
+	// IndexTickets adds multiple tickets to the index.
+	IndexTickets(ctx context.Context, tickets []*pb.Ticket) error
+
 	// DeindexTicket removes specified ticket from the index. The Ticket continues to exist.
 	DeindexTicket(ctx context.Context, id string) error
 
+	// DeindexTickets removes multiple tickets from the index. The Tickets
+	// continue to exist.
+	DeindexTickets(ctx context.Context, ids []string) error
+
 	// GetIndexedIDSet returns the ids of all tickets currently indexed.
 	GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error)
 
+	// GetTicketSnapshot returns the current index and pending release state,
+	// along with a cursor for reading the changes made after it.
+	GetTicketSnapshot(ctx context.Context) (*TicketSnapshot, error)
+
+	// GetTicketChanges returns the changes made to the index and pending
+	// release state after the cursor, in order, along with the cursor to use
+	// for the next call.  This method fails with OutOfRange if changes after
+	// the cursor are no longer retained, in which case the caller must start
+	// again from a new snapshot.
+	GetTicketChanges(ctx context.Context, cursor string) ([]*TicketChange, string, error)
+
 	// GetTickets returns multiple tickets from storage.  Missing tickets are
 	// silently ignored.
 	GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error)
 
 	// UpdateAssignments update using the request's specified tickets with assignments.
+	// Groups which specify a reservation token are only assigned if all of
+	// their tickets are pending release under that token, and otherwise fail
+	// with RESERVATION_LOST.
 	UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error)
 
 	// GetAssignments returns the assignment associated with the input ticket id
 	GetAssignments(ctx context.Context, id string, callback func(*pb.Assignment) error) error
 
+	// SubscribeAssignments calls callback with the tickets in ids, or every
+	// ticket if all is set, which are assigned while the subscription is open.
+	// Tickets in ids which already have an assignment are passed to callback
+	// first.  A ticket may be passed more than once.  Blocks until ctx is done
+	// or callback returns an error.
+	SubscribeAssignments(ctx context.Context, ids []string, all bool, callback func(*pb.Ticket) error) error
+
 	AddTicketsToPendingRelease(ctx context.Context, ids []string) error
 
+	// AddMatchTicketsToPendingRelease adds tickets to pending release, as
+	// AddTicketsToPendingRelease does, and records the reservation each ticket
+	// is pending release under.  tickets maps reservation tokens, which must be
+	// unique, to the ids of the reserved match's tickets.
+	AddMatchTicketsToPendingRelease(ctx context.Context, tickets map[string][]string) error
+
+	// GetPendingReleaseTime returns the time the ticket was added to pending
+	// release, and false if the ticket is not pending release.
+	GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error)
+
 	// DeleteTicketsFromPendingRelease deletes tickets from the proposed sorted set
 	DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error
 
 	// ReleaseAllTickets releases all pending tickets back to active
 	ReleaseAllTickets(ctx context.Context) error
 
+	// ReleaseMatches releases the tickets still pending under the reservation
+	// tokens back to active, returning the ids of the released tickets.
+	ReleaseMatches(ctx context.Context, tokens []string) ([]string, error)
+
+	// CreateBackfill creates a new Backfill in the state storage. If the id already exists, it will be overwritten.
+	CreateBackfill(ctx context.Context, backfill *pb.Backfill) error
+
+	// GetBackfill gets the Backfill with the specified id from state storage. This method fails if the Backfill does not exist.
+	GetBackfill(ctx context.Context, id string) (*pb.Backfill, error)
+
+	// GetBackfills returns multiple backfills from storage.  Missing backfills
+	// are silently ignored.
+	GetBackfills(ctx context.Context, ids []string) ([]*pb.Backfill, error)
+
+	// UpdateBackfill overwrites an existing Backfill, only if its stored
+	// generation is expectedGeneration.  This method fails with NotFound if the
+	// Backfill does not exist, and Aborted if the generation doesn't match.
+	UpdateBackfill(ctx context.Context, backfill *pb.Backfill, expectedGeneration int64) error
+
+	// DeleteBackfill removes the Backfill with the specified id from state storage. This method succeeds if the Backfill does not exist.
+	DeleteBackfill(ctx context.Context, id string) error
+
+	// IndexBackfill adds the backfill to the index.
+	IndexBackfill(ctx context.Context, backfill *pb.Backfill) error
+
+	// DeindexBackfill removes specified backfill from the index. The Backfill continues to exist.
+	DeindexBackfill(ctx context.Context, id string) error
+
+	// GetIndexedBackfillIDSet returns the ids of all backfills currently indexed.
+	GetIndexedBackfillIDSet(ctx context.Context) (map[string]struct{}, error)
+
+	// DeleteExpiredTickets deindexes and deletes all tickets whose expire time
+	// has passed, returning the ids of the tickets it removed.
+	DeleteExpiredTickets(ctx context.Context) ([]string, error)
+
 	// Closes the connection to the underlying storage.
 	Close() error
 }
 
+// TicketChangeKind is the type of a TicketChange.
+type TicketChangeKind int
+
+const (
+	// TicketsIndexed means the tickets were added to the index.
+	TicketsIndexed TicketChangeKind = iota + 1
+	// TicketsDeindexed means the tickets were removed from the index.
+	TicketsDeindexed
+	// TicketsAddedToPendingRelease means the tickets were added to pending
+	// release at the change's time.
+	TicketsAddedToPendingRelease
+	// TicketsDeletedFromPendingRelease means the tickets were removed from
+	// pending release.
+	TicketsDeletedFromPendingRelease
+	// AllTicketsReleased means every ticket was removed from pending release.
+	// The change has no ids.
+	AllTicketsReleased
+	// TicketsUpdated means the contents of the tickets changed, without
+	// changing whether they are indexed.
+	TicketsUpdated
+)
+
+// TicketChange is a single entry in the ticket change log.
+type TicketChange struct {
+	Kind TicketChangeKind
+	IDs  []string
+	Time time.Time
+}
+
+// TicketSnapshot is the full index and pending release state at a point in
+// the ticket change log.
+type TicketSnapshot struct {
+	// Cursor is passed to GetTicketChanges to read the changes made after the
+	// snapshot.
+	Cursor string
+	// Indexed holds the ids of all indexed tickets.
+	Indexed map[string]struct{}
+	// Pending maps the ids of tickets pending release to the time they were
+	// added to pending release.
+	Pending map[string]time.Time
+}
+
+const (
+	// configNameBackend selects the storage backend.  Supported values are
+	// "redis" (the default) and "memory".
+	configNameBackend = "statestore.backend"
+
+	backendRedis  = "redis"
+	backendMemory = "memory"
+
+	// configNameTicketChangeLogLength is the approximate number of ticket
+	// changes retained for GetTicketChanges.  Readers which fall further
+	// behind than this must start again from a snapshot.
+	configNameTicketChangeLogLength = "statestore.ticketChangeLogLength"
+	defaultTicketChangeLogLength    = 100000
+)
+
+func getTicketChangeLogLength(cfg config.View) int {
+	if !cfg.IsSet(configNameTicketChangeLogLength) {
+		return defaultTicketChangeLogLength
+	}
+	return cfg.GetInt(configNameTicketChangeLogLength)
+}
+
 // New creates a Service based on the configuration.
 func New(cfg config.View) Service {
-	s := newRedis(cfg)
+	var s Service
+	switch cfg.GetString(configNameBackend) {
+	case backendMemory:
+		s = newMemory(cfg)
+	case backendRedis, "":
+		s = newRedis(cfg)
+	default:
+		logger.Fatalf("unknown statestore backend %q, must be one of %q or %q", cfg.GetString(configNameBackend), backendRedis, backendMemory)
+	}
+
 	if cfg.GetBool(telemetry.ConfigNameEnableMetrics) {
 		return &instrumentedService{
 			s: s,
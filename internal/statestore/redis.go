@@ -15,23 +15,49 @@
 package statestore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/pkg/pb"
 )
 
-const allTickets = "allTickets"
+const (
+	allTickets   = "allTickets"
+	allBackfills = "allBackfills"
+	// expiringTickets is a sorted set of ticket ids, scored by the unix nano
+	// time at which the ticket expires.
+	expiringTickets = "expiringTickets"
+	// assignmentsChannel is the pub/sub channel which every assigned ticket is
+	// published to, for subscribers which watch all assignments.  Each
+	// assigned ticket is also published to its own channel, named by
+	// assignmentChannel, so that subscribers which watch particular tickets
+	// only receive their assignments.
+	assignmentsChannel = "assignments"
+	// ticketChanges is a stream of the changes made to allTickets and
+	// proposed_ticket_ids, read by GetTicketChanges.
+	ticketChanges = "ticketChanges"
+	// ticketReservations is a hash of the ids of tickets in
+	// proposed_ticket_ids to the reservation token of the match they were
+	// proposed in.  The tickets reserved under each token are also kept in a
+	// set named by reservationKey, so matches are released without reading
+	// every reservation.
+	ticketReservations = "ticketReservations"
+)
 
 var (
 	redisLogger = logrus.WithFields(logrus.Fields{
@@ -222,24 +248,79 @@ func redisURLFromAddr(addr string, cfg config.View, usePassword bool) string {
 
 // CreateTicket creates a new Ticket in the state storage. If the id already exists, it will be overwritten.
 func (rb *redisBackend) CreateTicket(ctx context.Context, ticket *pb.Ticket) error {
+	return rb.CreateTickets(ctx, []*pb.Ticket{ticket})
+}
+
+// CreateTickets creates multiple Tickets in the state storage in a single
+// round trip.  Tickets whose ids already exist are overwritten.
+func (rb *redisBackend) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "CreateTicket, id: %s, failed to connect to redis: %v", ticket.GetId(), err)
+		return status.Errorf(codes.Unavailable, "CreateTickets, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	for _, ticket := range tickets {
+		err = sendCreateTicket(redisConn, ticket)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrap(err, "failed to create tickets")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// sendCreateTicket queues the commands which store the ticket, and track its
+// expiry if it has an expire time.
+func sendCreateTicket(redisConn redis.Conn, ticket *pb.Ticket) error {
 	value, err := proto.Marshal(ticket)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", ticket.GetId())
 		return status.Errorf(codes.Internal, "%v", err)
 	}
 
-	_, err = redisConn.Do("SET", ticket.GetId(), value)
+	if ticket.GetExpireTime() == nil {
+		err = redisConn.Send("SET", ticket.GetId(), value)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to set the value for ticket, id: %s", ticket.GetId())
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+		return nil
+	}
+
+	expireTime, err := ptypes.Timestamp(ticket.GetExpireTime())
+	if err != nil {
+		err = errors.Wrapf(err, "invalid expire time for ticket, id: %s", ticket.GetId())
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	ttl := time.Until(expireTime) / time.Millisecond
+	if ttl < 1 {
+		ttl = 1
+	}
+
+	err = redisConn.Send("SET", ticket.GetId(), value, "PX", int64(ttl))
 	if err != nil {
 		err = errors.Wrapf(err, "failed to set the value for ticket, id: %s", ticket.GetId())
 		return status.Errorf(codes.Internal, "%v", err)
 	}
-
+	err = redisConn.Send("ZADD", expiringTickets, expireTime.UnixNano(), ticket.GetId())
+	if err != nil {
+		err = errors.Wrapf(err, "failed to add ticket to expiring tickets, id: %s", ticket.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
 	return nil
 }
 
@@ -278,34 +359,283 @@ func (rb *redisBackend) GetTicket(ctx context.Context, id string) (*pb.Ticket, e
 	return ticket, nil
 }
 
+func idempotencyRecordKey(key string) string {
+	return "idempotency/" + key
+}
+
+// ReserveIdempotencyKey records the Ticket for the idempotency key, unless a
+// Ticket is already recorded for it, in which case that Ticket is returned.
+func (rb *redisBackend) ReserveIdempotencyKey(ctx context.Context, key string, requestHash []byte, ticket *pb.Ticket, window time.Duration) (*pb.Ticket, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "ReserveIdempotencyKey, key: %s, failed to connect to redis: %v", key, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	value, err := proto.Marshal(&ipb.IdempotencyRecord{
+		RequestHash: requestHash,
+		Ticket:      ticket,
+	})
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the idempotency record proto, id: %s", ticket.GetId())
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	ttl := int64(window / time.Millisecond)
+	if ttl < 1 {
+		ttl = 1
+	}
+
+	// The record can expire between failing to set it and getting it, so try
+	// again until one of the two succeeds.
+	for {
+		reply, err := redisConn.Do("SET", idempotencyRecordKey(key), value, "NX", "PX", ttl)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to set the idempotency key, key: %s", key)
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if reply != nil {
+			return nil, nil
+		}
+
+		existing, err := redis.Bytes(redisConn.Do("GET", idempotencyRecordKey(key)))
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			err = errors.Wrapf(err, "failed to get the idempotency key, key: %s", key)
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		recorded := &ipb.IdempotencyRecord{}
+		err = proto.Unmarshal(existing, recorded)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to unmarshal the idempotency record proto, key: %s", key)
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if !bytes.Equal(recorded.RequestHash, requestHash) {
+			return nil, status.Errorf(codes.FailedPrecondition, "idempotency key %s was used by a different request", key)
+		}
+		return recorded.Ticket, nil
+	}
+}
+
+// DeleteIdempotencyKey removes the record of the idempotency key.
+func (rb *redisBackend) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "DeleteIdempotencyKey, key: %s, failed to connect to redis: %v", key, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("DEL", idempotencyRecordKey(key))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to delete the idempotency key, key: %s", key)
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	return nil
+}
+
 // DeleteTicket removes the Ticket with the specified id from state storage.
 func (rb *redisBackend) DeleteTicket(ctx context.Context, id string) error {
+	return rb.DeleteTickets(ctx, []string{id})
+}
+
+// DeleteTickets removes multiple Tickets from state storage in a single round
+// trip.
+func (rb *redisBackend) DeleteTickets(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "DeleteTicket, id: %s, failed to connect to redis: %v", id, err)
+		return status.Errorf(codes.Unavailable, "DeleteTickets, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", id)
+	keys := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, id)
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("DEL", keys...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to delete the tickets from state storage")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	err = redisConn.Send("ZREM", append([]interface{}{expiringTickets}, keys...)...)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to delete the ticket from state storage, id: %s", id)
+		err = errors.Wrap(err, "failed to remove the tickets from expiring tickets")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	err = redisConn.Send("HDEL", append([]interface{}{ticketReservations}, keys...)...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to remove the tickets reservations")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrap(err, "failed to delete the tickets")
 		return status.Errorf(codes.Internal, "%v", err)
 	}
 
 	return nil
 }
 
+// UpdateTicket replaces the SearchFields and Extensions of an existing Ticket,
+// unless it is assigned or pending release.  The ticket and pending release
+// set are watched, and the update is retried if either is modified
+// concurrently, failing with Aborted once the attempts run out.
+func (rb *redisBackend) UpdateTicket(ctx context.Context, ticket *pb.Ticket) (*pb.Ticket, error) {
+	id := ticket.GetId()
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "UpdateTicket, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	const maxAttempts = 5
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		_, err = redisConn.Do("WATCH", id, "proposed_ticket_ids")
+		if err != nil {
+			err = errors.Wrapf(err, "failed to watch ticket, id: %s", id)
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		updated, ok, err := rb.tryUpdateTicket(redisConn, ticket)
+		if err != nil || ok {
+			return updated, err
+		}
+	}
+	return nil, status.Errorf(codes.Aborted, "ticket %s or pending release was modified concurrently", id)
+}
+
+// tryUpdateTicket makes a single attempt at updating the watched ticket,
+// returning false if a watched key was modified concurrently.
+func (rb *redisBackend) tryUpdateTicket(redisConn redis.Conn, ticket *pb.Ticket) (*pb.Ticket, bool, error) {
+	id := ticket.GetId()
+	value, err := redis.Bytes(redisConn.Do("GET", id))
+	if err == redis.ErrNil {
+		msg := fmt.Sprintf("Ticket id:%s not found", id)
+		return nil, false, status.Error(codes.NotFound, msg)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to get the ticket from state storage, id: %s", id)
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+	current := &pb.Ticket{}
+	err = proto.Unmarshal(value, current)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to unmarshal the ticket proto, id: %s", id)
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+	if current.GetAssignment() != nil {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "ticket %s is assigned", id)
+	}
+
+	nanos, err := redis.Float64(redisConn.Do("ZSCORE", "proposed_ticket_ids", id))
+	if err != nil && err != redis.ErrNil {
+		return nil, false, status.Errorf(codes.Internal, "error getting pending release time, id: %s: %v", id, err)
+	}
+	ttl := rb.cfg.GetDuration("pendingReleaseTimeout")
+	if err == nil && time.Since(time.Unix(0, int64(nanos))) <= ttl {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "ticket %s is pending release", id)
+	}
+
+	updated := proto.Clone(current).(*pb.Ticket)
+	updated.SearchFields = ticket.GetSearchFields()
+	updated.Extensions = ticket.GetExtensions()
+	value, err = proto.Marshal(updated)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the ticket proto, id: %s", id)
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	args := []interface{}{id, value, "XX"}
+	if updated.GetExpireTime() != nil {
+		expireTime, err := ptypes.Timestamp(updated.GetExpireTime())
+		if err != nil {
+			err = errors.Wrapf(err, "invalid expire time for ticket, id: %s", id)
+			return nil, false, status.Errorf(codes.Internal, "%v", err)
+		}
+		ttl := time.Until(expireTime) / time.Millisecond
+		if ttl < 1 {
+			ttl = 1
+		}
+		args = append(args, "PX", int64(ttl))
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SET", args...)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to set the value for ticket, id: %s", id)
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+	err = rb.sendTicketChange(redisConn, TicketsUpdated, time.Now(), []string{id})
+	if err != nil {
+		return nil, false, err
+	}
+	reply, err := redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to update the ticket, id: %s", id)
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+	// A nil reply means a watched key was modified concurrently.
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	return updated, true, nil
+}
+
 // IndexTicket indexes the Ticket id for the configured index fields.
 func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
+	return rb.IndexTickets(ctx, []*pb.Ticket{ticket})
+}
+
+// IndexTickets indexes multiple Tickets in a single round trip.
+func (rb *redisBackend) IndexTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "IndexTicket, id: %s, failed to connect to redis: %v", ticket.GetId(), err)
+		return status.Errorf(codes.Unavailable, "IndexTickets, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	err = redisConn.Send("SADD", allTickets, ticket.Id)
+	ids := make([]string, 0, len(tickets))
+	cmds := make([]interface{}, 0, len(tickets)+1)
+	cmds = append(cmds, allTickets)
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.GetId())
+		cmds = append(cmds, ticket.GetId())
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SADD", cmds...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to add tickets to all tickets")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	err = rb.sendTicketChange(redisConn, TicketsIndexed, time.Now(), ids)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to add ticket to all tickets, id: %s", ticket.Id)
+		return err
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrap(err, "failed to index tickets")
 		return status.Errorf(codes.Internal, "%v", err)
 	}
 
@@ -314,15 +644,44 @@ func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) erro
 
 // DeindexTicket removes the indexing for the specified Ticket. Only the indexes are removed but the Ticket continues to exist.
 func (rb *redisBackend) DeindexTicket(ctx context.Context, id string) error {
+	return rb.DeindexTickets(ctx, []string{id})
+}
+
+// DeindexTickets removes the indexing for multiple Tickets in a single round
+// trip.  The Tickets continue to exist.
+func (rb *redisBackend) DeindexTickets(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "DeindexTicket, id: %s, failed to connect to redis: %v", id, err)
+		return status.Errorf(codes.Unavailable, "DeindexTickets, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	err = redisConn.Send("SREM", allTickets, id)
+	cmds := make([]interface{}, 0, len(ids)+1)
+	cmds = append(cmds, allTickets)
+	for _, id := range ids {
+		cmds = append(cmds, id)
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SREM", cmds...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to remove tickets from all tickets")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	err = rb.sendTicketChange(redisConn, TicketsDeindexed, time.Now(), ids)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to remove ticket from all tickets, id: %s", id)
+		return err
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrap(err, "failed to deindex tickets")
 		return status.Errorf(codes.Internal, "%v", err)
 	}
 
@@ -348,6 +707,12 @@ func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{
 		return nil, status.Errorf(codes.Internal, "error getting pending release %v", err)
 	}
 
+	// Filter out tickets which have expired, but haven't been deleted yet.
+	idsExpired, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", expiringTickets, "-inf", curTime.UnixNano()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting expired tickets %v", err)
+	}
+
 	idsIndexed, err := redis.Strings(redisConn.Do("SMEMBERS", allTickets))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error getting all indexed ticket ids %v", err)
@@ -360,66 +725,239 @@ func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{
 	for _, id := range idsInPendingReleases {
 		delete(r, id)
 	}
+	for _, id := range idsExpired {
+		delete(r, id)
+	}
 
 	return r, nil
 }
 
-// GetTickets returns multiple tickets from storage.  Missing tickets are
-// silently ignored.
-func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
-	if len(ids) == 0 {
-		return nil, nil
+// ticketSnapshotMarker is the kind of the ticketChanges entries added by
+// GetTicketSnapshot, which mark the position of a snapshot in the stream.
+const ticketSnapshotMarker TicketChangeKind = 0
+
+// sendTicketChange queues the addition of a change to the ticketChanges
+// stream.  It should be sent in the same transaction as the change itself.
+func (rb *redisBackend) sendTicketChange(redisConn redis.Conn, kind TicketChangeKind, t time.Time, ids []string) error {
+	args := make([]interface{}, 0, 9+2*len(ids))
+	args = append(args, ticketChanges, "MAXLEN", "~", getTicketChangeLogLength(rb.cfg), "*", "kind", int(kind), "time", t.UnixNano())
+	for _, id := range ids {
+		args = append(args, "id", id)
+	}
+
+	err := redisConn.Send("XADD", args...)
+	if err != nil {
+		return errors.Wrap(err, "error sending ticket change")
 	}
+	return nil
+}
 
+// GetTicketSnapshot returns the current index and pending release state,
+// along with a cursor for reading the changes made after it.
+func (rb *redisBackend) GetTicketSnapshot(ctx context.Context) (*TicketSnapshot, error) {
 	redisConn, err := rb.redisPool.GetContext(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "GetTickets, failed to connect to redis: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "GetTicketSnapshot, failed to connect to redis: %v", err)
 	}
 	defer handleConnectionClose(&redisConn)
 
-	queryParams := make([]interface{}, len(ids))
-	for i, id := range ids {
-		queryParams[i] = id
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting redis multi")
 	}
-
-	ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
+	err = redisConn.Send("SMEMBERS", allTickets)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to lookup tickets %v", ids)
-		return nil, status.Errorf(codes.Internal, "%v", err)
+		return nil, errors.Wrap(err, "error sending all indexed ticket ids read")
+	}
+	err = redisConn.Send("ZRANGE", "proposed_ticket_ids", 0, -1, "WITHSCORES")
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending pending release read")
+	}
+	err = rb.sendTicketChange(redisConn, ticketSnapshotMarker, time.Now(), nil)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := redis.Values(redisConn.Do("EXEC"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting ticket snapshot %v", err)
+	}
+	if len(replies) != 3 {
+		return nil, status.Errorf(codes.Internal, "expected 3 replies from redis, but received %d", len(replies))
 	}
 
-	r := make([]*pb.Ticket, 0, len(ids))
+	idsIndexed, err := redis.Strings(replies[0], nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting all indexed ticket ids %v", err)
+	}
+	pendingScores, err := redis.StringMap(replies[1], nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting pending release %v", err)
+	}
+	cursor, err := redis.String(replies[2], nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error adding ticket snapshot marker %v", err)
+	}
 
-	for i, b := range ticketBytes {
-		// Tickets may be deleted by the time we read it from redis.
-		if b != nil {
-			t := &pb.Ticket{}
-			err = proto.Unmarshal(b, t)
-			if err != nil {
-				err = errors.Wrapf(err, "failed to unmarshal ticket from redis, key %s", ids[i])
-				return nil, status.Errorf(codes.Internal, "%v", err)
-			}
-			r = append(r, t)
+	snapshot := &TicketSnapshot{
+		Cursor:  cursor,
+		Indexed: make(map[string]struct{}, len(idsIndexed)),
+		Pending: make(map[string]time.Time, len(pendingScores)),
+	}
+	for _, id := range idsIndexed {
+		snapshot.Indexed[id] = struct{}{}
+	}
+	for id, score := range pendingScores {
+		nanos, err := strconv.ParseFloat(score, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid pending release time for ticket, id: %s: %v", id, err)
 		}
+		snapshot.Pending[id] = time.Unix(0, int64(nanos))
 	}
-
-	return r, nil
+	return snapshot, nil
 }
 
-// UpdateAssignments update using the request's specified tickets with assignments.
-func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
-	resp := &pb.AssignTicketsResponse{}
-	if len(req.Assignments) == 0 {
-		return resp, nil
+// GetTicketChanges returns the changes made to the index and pending release
+// state after the cursor, in order, along with the cursor to use for the next
+// call.  This method fails with OutOfRange if the cursor's entry has been
+// trimmed from the stream.
+func (rb *redisBackend) GetTicketChanges(ctx context.Context, cursor string) ([]*TicketChange, string, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Unavailable, "GetTicketChanges, failed to connect to redis: %v", err)
 	}
+	defer handleConnectionClose(&redisConn)
 
-	idToA := make(map[string]*pb.Assignment)
-	ids := make([]string, 0)
-	idsI := make([]interface{}, 0)
-	for _, a := range req.Assignments {
-		if a.Assignment == nil {
-			return nil, status.Error(codes.InvalidArgument, "AssignmentGroup.Assignment is required")
-		}
+	// The range includes the cursor's own entry, which is used to check that
+	// no entries after it have been trimmed.
+	entries, err := redis.Values(redisConn.Do("XRANGE", ticketChanges, cursor, "+"))
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "error getting ticket changes %v", err)
+	}
+	if len(entries) == 0 {
+		return nil, "", status.Errorf(codes.OutOfRange, "ticket changes after cursor %s are no longer retained", cursor)
+	}
+
+	var changes []*TicketChange
+	for i, e := range entries {
+		entry, err := redis.Values(e, nil)
+		if err != nil || len(entry) != 2 {
+			return nil, "", status.Errorf(codes.Internal, "invalid ticket change entry %v", e)
+		}
+		id, err := redis.String(entry[0], nil)
+		if err != nil {
+			return nil, "", status.Errorf(codes.Internal, "invalid ticket change entry id %v", err)
+		}
+		if i == 0 {
+			if id != cursor {
+				return nil, "", status.Errorf(codes.OutOfRange, "ticket changes after cursor %s are no longer retained", cursor)
+			}
+			continue
+		}
+		cursor = id
+
+		fields, err := redis.Strings(entry[1], nil)
+		if err != nil {
+			return nil, "", status.Errorf(codes.Internal, "invalid ticket change entry fields %v", err)
+		}
+		change, err := parseTicketChange(fields)
+		if err != nil {
+			return nil, "", status.Errorf(codes.Internal, "invalid ticket change entry, id: %s: %v", id, err)
+		}
+		if change.Kind != ticketSnapshotMarker {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, cursor, nil
+}
+
+// parseTicketChange parses the field value pairs of a ticketChanges entry.
+func parseTicketChange(fields []string) (*TicketChange, error) {
+	if len(fields)%2 != 0 {
+		return nil, errors.New("odd number of fields")
+	}
+
+	change := &TicketChange{}
+	for i := 0; i < len(fields); i += 2 {
+		value := fields[i+1]
+		switch fields[i] {
+		case "kind":
+			kind, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid kind")
+			}
+			change.Kind = TicketChangeKind(kind)
+		case "time":
+			nanos, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid time")
+			}
+			change.Time = time.Unix(0, nanos)
+		case "id":
+			change.IDs = append(change.IDs, value)
+		}
+	}
+	return change, nil
+}
+
+// GetTickets returns multiple tickets from storage.  Missing tickets are
+// silently ignored.
+func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetTickets, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	queryParams := make([]interface{}, len(ids))
+	for i, id := range ids {
+		queryParams[i] = id
+	}
+
+	ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to lookup tickets %v", ids)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	r := make([]*pb.Ticket, 0, len(ids))
+
+	for i, b := range ticketBytes {
+		// Tickets may be deleted by the time we read it from redis.
+		if b != nil {
+			t := &pb.Ticket{}
+			err = proto.Unmarshal(b, t)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to unmarshal ticket from redis, key %s", ids[i])
+				return nil, status.Errorf(codes.Internal, "%v", err)
+			}
+			r = append(r, t)
+		}
+	}
+
+	return r, nil
+}
+
+// UpdateAssignments update using the request's specified tickets with assignments.
+func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
+	if len(req.Assignments) == 0 {
+		return &pb.AssignTicketsResponse{}, nil
+	}
+
+	idToA := make(map[string]*pb.Assignment)
+	ids := make([]string, 0)
+	conditional := false
+	for _, a := range req.Assignments {
+		if a.Assignment == nil {
+			return nil, status.Error(codes.InvalidArgument, "AssignmentGroup.Assignment is required")
+		}
+		if a.ReservationToken != "" {
+			conditional = true
+		}
 
 		for _, id := range a.TicketIds {
 			if _, ok := idToA[id]; ok {
@@ -428,7 +966,6 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 
 			idToA[id] = a.Assignment
 			ids = append(ids, id)
-			idsI = append(idsI, id)
 		}
 	}
 
@@ -438,9 +975,44 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 	}
 	defer handleConnectionClose(&redisConn)
 
+	// Conditional assignments watch the reservations they check, and are
+	// retried if those change before the assignments are written.
+	const maxAttempts = 5
+	for attempt := 1; ; attempt++ {
+		if conditional {
+			_, err = redisConn.Do("WATCH", "proposed_ticket_ids", ticketReservations)
+			if err != nil {
+				return nil, errors.Wrap(err, "error watching ticket reservations")
+			}
+		}
+
+		resp, ok, err := rb.tryUpdateAssignments(redisConn, req, ids, idToA)
+		if err != nil || ok {
+			return resp, err
+		}
+		if attempt == maxAttempts {
+			return nil, status.Error(codes.Aborted, "ticket reservations were modified concurrently")
+		}
+	}
+}
+
+// tryUpdateAssignments makes a single attempt at assigning the tickets,
+// returning false if a watched key was modified concurrently.
+func (rb *redisBackend) tryUpdateAssignments(redisConn redis.Conn, req *pb.AssignTicketsRequest, ids []string, idToA map[string]*pb.Assignment) (*pb.AssignTicketsResponse, bool, error) {
+	resp := &pb.AssignTicketsResponse{}
+
+	idsI := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		idsI = append(idsI, id)
+	}
 	ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", idsI...))
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	lost, err := rb.getLostReservations(redisConn, req)
+	if err != nil {
+		return nil, false, err
 	}
 
 	tickets := make([]*pb.Ticket, 0, len(ticketBytes))
@@ -451,12 +1023,17 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 				TicketId: ids[i],
 				Cause:    pb.AssignmentFailure_TICKET_NOT_FOUND,
 			})
+		} else if _, ok := lost[ids[i]]; ok {
+			resp.Failures = append(resp.Failures, &pb.AssignmentFailure{
+				TicketId: ids[i],
+				Cause:    pb.AssignmentFailure_RESERVATION_LOST,
+			})
 		} else {
 			t := &pb.Ticket{}
 			err = proto.Unmarshal(ticketByte, t)
 			if err != nil {
 				err = errors.Wrapf(err, "failed to unmarshal ticket from redis %s", ids[i])
-				return nil, status.Errorf(codes.Internal, "%v", err)
+				return nil, false, status.Errorf(codes.Internal, "%v", err)
 			}
 			tickets = append(tickets, t)
 		}
@@ -464,33 +1041,63 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 	assignmentTimeout := rb.cfg.GetDuration("assignedDeleteTimeout") / time.Millisecond
 	err = redisConn.Send("MULTI")
 	if err != nil {
-		return nil, errors.Wrap(err, "error starting redis multi")
+		return nil, false, errors.Wrap(err, "error starting redis multi")
 	}
 
-	for _, ticket := range tickets {
+	assignedBytes := make([][]byte, len(tickets))
+	for i, ticket := range tickets {
 		ticket.Assignment = idToA[ticket.Id]
 
 		var ticketByte []byte
 		ticketByte, err = proto.Marshal(ticket)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to marshal ticket %s", ticket.GetId())
+			return nil, false, status.Errorf(codes.Internal, "failed to marshal ticket %s", ticket.GetId())
 		}
+		assignedBytes[i] = ticketByte
 
 		err = redisConn.Send("SET", ticket.Id, ticketByte, "PX", int64(assignmentTimeout), "XX")
 		if err != nil {
-			return nil, errors.Wrap(err, "error sending ticket assignment set")
+			return nil, false, errors.Wrap(err, "error sending ticket assignment set")
 		}
 	}
 
-	wasSet, err := redis.Values(redisConn.Do("EXEC"))
+	// Assigned tickets are deleted after assignedDeleteTimeout instead of
+	// their expire time, and are no longer reserved.
+	if len(tickets) > 0 {
+		ids := make([]interface{}, 0, len(tickets))
+		for _, ticket := range tickets {
+			ids = append(ids, ticket.Id)
+		}
+		err = redisConn.Send("ZREM", append([]interface{}{expiringTickets}, ids...)...)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "error sending assigned tickets removal from expiring tickets")
+		}
+		err = redisConn.Send("HDEL", append([]interface{}{ticketReservations}, ids...)...)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "error sending assigned tickets reservations removal")
+		}
+	}
+
+	reply, err := redisConn.Do("EXEC")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "error executing assignment set")
+	}
+	// A nil reply means a watched key was modified concurrently.
+	if reply == nil {
+		return nil, false, nil
+	}
+	wasSet, err := redis.Values(reply, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error executing assignment set")
+		return nil, false, errors.Wrap(err, "error executing assignment set")
 	}
 
-	if len(wasSet) != len(tickets) {
-		return nil, status.Errorf(codes.Internal, "sent %d tickets to redis, but received %d back", len(tickets), len(wasSet))
+	// The replies of the expiring tickets and reservations removals follow
+	// the ticket sets.
+	if len(tickets) > 0 && len(wasSet) != len(tickets)+2 {
+		return nil, false, status.Errorf(codes.Internal, "sent %d tickets to redis, but received %d back", len(tickets), len(wasSet)-2)
 	}
 
+	published := 0
 	for i, ticket := range tickets {
 		v, err := redis.String(wasSet[i], nil)
 		if err == redis.ErrNil {
@@ -501,14 +1108,94 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 			continue
 		}
 		if err != nil {
-			return nil, errors.Wrap(err, "unexpected error from redis multi set")
+			return nil, false, errors.Wrap(err, "unexpected error from redis multi set")
 		}
 		if v != "OK" {
-			return nil, status.Errorf(codes.Internal, "unexpected response from redis: %s", v)
+			return nil, false, status.Errorf(codes.Internal, "unexpected response from redis: %s", v)
 		}
+
+		err = redisConn.Send("PUBLISH", assignmentChannel(ticket.Id), assignedBytes[i])
+		if err != nil {
+			return nil, false, errors.Wrap(err, "error sending assigned ticket publish")
+		}
+		err = redisConn.Send("PUBLISH", assignmentsChannel, assignedBytes[i])
+		if err != nil {
+			return nil, false, errors.Wrap(err, "error sending assigned ticket publish")
+		}
+		published++
 	}
 
-	return resp, nil
+	// Flush the pipelined publishes to assignment subscribers.
+	if published > 0 {
+		_, err = redisConn.Do("")
+		if err != nil {
+			return nil, false, errors.Wrap(err, "error publishing assigned tickets")
+		}
+	}
+
+	return resp, true, nil
+}
+
+// getLostReservations returns the ids of the tickets in groups which specify
+// a reservation token, where any of the group's tickets is not pending release
+// under that token.
+func (rb *redisBackend) getLostReservations(redisConn redis.Conn, req *pb.AssignTicketsRequest) (map[string]struct{}, error) {
+	lost := make(map[string]struct{})
+	startTime := time.Now().Add(-rb.cfg.GetDuration("pendingReleaseTimeout")).UnixNano()
+
+	for _, a := range req.Assignments {
+		if a.ReservationToken == "" || len(a.TicketIds) == 0 {
+			continue
+		}
+
+		args := []interface{}{ticketReservations}
+		for _, id := range a.TicketIds {
+			args = append(args, id)
+			err := redisConn.Send("ZSCORE", "proposed_ticket_ids", id)
+			if err != nil {
+				return nil, errors.Wrap(err, "error sending pending release time read")
+			}
+		}
+		err := redisConn.Send("HMGET", args...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending ticket reservations read")
+		}
+		err = redisConn.Flush()
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading ticket reservations")
+		}
+
+		reserved := true
+		for range a.TicketIds {
+			nanos, err := redis.Float64(redisConn.Receive())
+			if err == redis.ErrNil {
+				reserved = false
+				continue
+			}
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error getting pending release time %v", err)
+			}
+			if int64(nanos) < startTime {
+				reserved = false
+			}
+		}
+		tokens, err := redis.Strings(redisConn.Receive())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error getting ticket reservations %v", err)
+		}
+		for _, token := range tokens {
+			if token != a.ReservationToken {
+				reserved = false
+			}
+		}
+
+		if !reserved {
+			for _, id := range a.TicketIds {
+				lost[id] = struct{}{}
+			}
+		}
+	}
+	return lost, nil
 }
 
 // GetAssignments returns the assignment associated with the input ticket id
@@ -541,8 +1228,158 @@ func (rb *redisBackend) GetAssignments(ctx context.Context, id string, callback
 	return nil
 }
 
+// SubscribeAssignments calls callback with the tickets in ids, or every
+// ticket if all is set, which are assigned while the subscription is open, as
+// published by UpdateAssignments.  Tickets in ids which already have an
+// assignment are passed to callback once the subscription is established, so
+// no assignment is missed.
+func (rb *redisBackend) SubscribeAssignments(ctx context.Context, ids []string, all bool, callback func(*pb.Ticket) error) error {
+	// Redis counts each channel once, however many times it is subscribed to.
+	channels := make([]interface{}, 0, len(ids)+1)
+	subscribed := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := subscribed[id]; ok {
+			continue
+		}
+		subscribed[id] = struct{}{}
+		channels = append(channels, assignmentChannel(id))
+	}
+	if all {
+		channels = append(channels, assignmentsChannel)
+	}
+	if len(channels) == 0 {
+		return status.Error(codes.InvalidArgument, "SubscribeAssignments, no tickets to subscribe to")
+	}
+
+	// Subscribed connections can't be used for other commands, so use a
+	// dedicated connection rather than one from the pool.
+	redisConn, err := rb.redisPool.DialContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "SubscribeAssignments, failed to connect to redis: %v", err)
+	}
+	psc := redis.PubSubConn{Conn: redisConn}
+	defer handleConnectionClose(&psc.Conn)
+
+	err = psc.Subscribe(channels...)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "SubscribeAssignments, failed to subscribe: %v", err)
+	}
+
+	// The connection's read timeout is the pool's idle timeout, if set, so
+	// ping periodically to keep an idle subscription open.  Unsubscribing
+	// ends the receive loop below.
+	var ping <-chan time.Time
+	if idleTimeout := rb.cfg.GetDuration("redis.pool.idleTimeout"); idleTimeout > 0 {
+		ticker := time.NewTicker(idleTimeout / 2)
+		defer ticker.Stop()
+		ping = ticker.C
+	}
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	defer wg.Wait()
+	defer close(done)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				if err := psc.Unsubscribe(); err != nil {
+					redisLogger.WithError(err).Warning("failed to unsubscribe from assignments")
+				}
+				return
+			case <-ping:
+				if err := psc.Ping(""); err != nil {
+					redisLogger.WithError(err).Warning("failed to ping assignments subscription")
+				}
+			}
+		}
+	}()
+
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Subscription:
+			if v.Count == 0 {
+				return status.Error(codes.Canceled, "SubscribeAssignments, subscription closed")
+			}
+			if v.Kind != "subscribe" || v.Count != len(channels) {
+				continue
+			}
+			// The subscription to every channel is established, so any
+			// assignment from now on is published to it.
+			tickets, err := rb.GetTickets(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, ticket := range tickets {
+				if ticket.GetAssignment() == nil {
+					continue
+				}
+				err = callback(ticket)
+				if err != nil {
+					return err
+				}
+			}
+		case redis.Message:
+			ticket := &pb.Ticket{}
+			err = proto.Unmarshal(v.Data, ticket)
+			if err != nil {
+				err = errors.Wrap(err, "failed to unmarshal published ticket")
+				return status.Errorf(codes.Internal, "%v", err)
+			}
+			err = callback(ticket)
+			if err != nil {
+				return err
+			}
+		case redis.Pong:
+		case error:
+			if ctx.Err() != nil {
+				return status.Error(codes.Canceled, ctx.Err().Error())
+			}
+			return status.Errorf(codes.Unavailable, "SubscribeAssignments, failed to receive: %v", v)
+		}
+	}
+}
+
+// assignmentChannel returns the pub/sub channel the ticket is published to
+// when it is assigned.
+func assignmentChannel(id string) string {
+	return assignmentsChannel + ":" + id
+}
+
 // AddTicketsToPendingRelease appends new proposed tickets to the proposed sorted set with current timestamp
 func (rb *redisBackend) AddTicketsToPendingRelease(ctx context.Context, ids []string) error {
+	return rb.addTicketsToPendingRelease(ctx, ids, nil)
+}
+
+// AddMatchTicketsToPendingRelease appends the tickets of matches to the
+// proposed set with current timestamp, and records their reservations.
+func (rb *redisBackend) AddMatchTicketsToPendingRelease(ctx context.Context, tickets map[string][]string) error {
+	var ids []string
+	tokenOf := make(map[string]string)
+	for token, matchTickets := range tickets {
+		for _, id := range matchTickets {
+			ids = append(ids, id)
+			tokenOf[id] = token
+		}
+	}
+	return rb.addTicketsToPendingRelease(ctx, ids, tokenOf)
+}
+
+// reservationKey returns the key of the set of ticket ids reserved under the
+// token.  The set expires once its tickets are no longer pending release, and
+// its tickets are only still reserved while ticketReservations maps them to
+// the token.
+func reservationKey(token string) string {
+	return "reservation/" + token
+}
+
+// addTicketsToPendingRelease appends the tickets to the proposed set, and
+// records the reservation token of each in tokenOf.  Tickets without a token
+// have any earlier reservation removed.
+func (rb *redisBackend) addTicketsToPendingRelease(ctx context.Context, ids []string, tokenOf map[string]string) error {
 	if len(ids) == 0 {
 		return nil
 	}
@@ -553,14 +1390,61 @@ func (rb *redisBackend) AddTicketsToPendingRelease(ctx context.Context, ids []st
 	}
 	defer handleConnectionClose(&redisConn)
 
-	currentTime := time.Now().UnixNano()
+	currentTime := time.Now()
 	cmds := make([]interface{}, 0, 2*len(ids)+1)
 	cmds = append(cmds, "proposed_ticket_ids")
+	reserve := []interface{}{ticketReservations}
+	unreserve := []interface{}{ticketReservations}
+	reserved := make(map[string][]interface{})
 	for _, id := range ids {
-		cmds = append(cmds, currentTime, id)
+		cmds = append(cmds, currentTime.UnixNano(), id)
+		if token, ok := tokenOf[id]; ok {
+			reserve = append(reserve, id, token)
+			if _, ok := reserved[token]; !ok {
+				reserved[token] = []interface{}{reservationKey(token)}
+			}
+			reserved[token] = append(reserved[token], id)
+		} else {
+			unreserve = append(unreserve, id)
+		}
 	}
 
-	_, err = redisConn.Do("ZADD", cmds...)
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("ZADD", cmds...)
+	if err != nil {
+		return errors.Wrap(err, "error sending proposed tickets to pending release")
+	}
+	if len(reserve) > 1 {
+		err = redisConn.Send("HMSET", reserve...)
+		if err != nil {
+			return errors.Wrap(err, "error sending proposed tickets reservations")
+		}
+	}
+	pendingReleaseTimeout := rb.cfg.GetDuration("pendingReleaseTimeout") / time.Millisecond
+	for token, members := range reserved {
+		err = redisConn.Send("SADD", members...)
+		if err != nil {
+			return errors.Wrap(err, "error sending reservation tickets")
+		}
+		err = redisConn.Send("PEXPIRE", reservationKey(token), int64(pendingReleaseTimeout))
+		if err != nil {
+			return errors.Wrap(err, "error sending reservation expiry")
+		}
+	}
+	if len(unreserve) > 1 {
+		err = redisConn.Send("HDEL", unreserve...)
+		if err != nil {
+			return errors.Wrap(err, "error sending proposed tickets reservations removal")
+		}
+	}
+	err = rb.sendTicketChange(redisConn, TicketsAddedToPendingRelease, currentTime, ids)
+	if err != nil {
+		return err
+	}
+	_, err = redisConn.Do("EXEC")
 	if err != nil {
 		err = errors.Wrap(err, "failed to append proposed tickets to pending release")
 		return status.Error(codes.Internal, err.Error())
@@ -569,6 +1453,25 @@ func (rb *redisBackend) AddTicketsToPendingRelease(ctx context.Context, ids []st
 	return nil
 }
 
+// GetPendingReleaseTime returns the time the ticket was added to pending
+// release, and false if the ticket is not pending release.
+func (rb *redisBackend) GetPendingReleaseTime(ctx context.Context, id string) (time.Time, bool, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return time.Time{}, false, status.Errorf(codes.Unavailable, "GetPendingReleaseTime, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	nanos, err := redis.Float64(redisConn.Do("ZSCORE", "proposed_ticket_ids", id))
+	if err == redis.ErrNil {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, status.Errorf(codes.Internal, "error getting pending release time, id: %s: %v", id, err)
+	}
+	return time.Unix(0, int64(nanos)), true, nil
+}
+
 // DeleteTicketsFromPendingRelease deletes tickets from the proposed sorted set
 func (rb *redisBackend) DeleteTicketsFromPendingRelease(ctx context.Context, ids []string) error {
 	if len(ids) == 0 {
@@ -587,7 +1490,23 @@ func (rb *redisBackend) DeleteTicketsFromPendingRelease(ctx context.Context, ids
 		cmds = append(cmds, id)
 	}
 
-	_, err = redisConn.Do("ZREM", cmds...)
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("ZREM", cmds...)
+	if err != nil {
+		return errors.Wrap(err, "error sending proposed tickets removal from pending release")
+	}
+	err = redisConn.Send("HDEL", append([]interface{}{ticketReservations}, cmds[1:]...)...)
+	if err != nil {
+		return errors.Wrap(err, "error sending proposed tickets reservations removal")
+	}
+	err = rb.sendTicketChange(redisConn, TicketsDeletedFromPendingRelease, time.Now(), ids)
+	if err != nil {
+		return err
+	}
+	_, err = redisConn.Do("EXEC")
 	if err != nil {
 		err = errors.Wrap(err, "failed to delete proposed tickets from pending release")
 		return status.Error(codes.Internal, err.Error())
@@ -603,10 +1522,464 @@ func (rb *redisBackend) ReleaseAllTickets(ctx context.Context) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", "proposed_ticket_ids")
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("DEL", "proposed_ticket_ids", ticketReservations)
+	if err != nil {
+		return errors.Wrap(err, "error sending pending release removal")
+	}
+	err = rb.sendTicketChange(redisConn, AllTicketsReleased, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+	_, err = redisConn.Do("EXEC")
 	return err
 }
 
+// ReleaseMatches releases the tickets still pending under the reservation
+// tokens back to active, returning the ids of the released tickets.
+func (rb *redisBackend) ReleaseMatches(ctx context.Context, tokens []string) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "ReleaseMatches, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	keys := make([]interface{}, 0, len(tokens))
+	for _, token := range tokens {
+		keys = append(keys, reservationKey(token))
+	}
+
+	// The reservations are watched so that tickets reserved again in the
+	// meantime aren't released.
+	const maxAttempts = 5
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		_, err = redisConn.Do("WATCH", append([]interface{}{ticketReservations}, keys...)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error watching ticket reservations")
+		}
+
+		ids, err := rb.getReservedTickets(redisConn, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+
+		err = redisConn.Send("MULTI")
+		if err != nil {
+			return nil, errors.Wrap(err, "error starting redis multi")
+		}
+		err = redisConn.Send("ZREM", append([]interface{}{"proposed_ticket_ids"}, ids...)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending proposed tickets removal from pending release")
+		}
+		err = redisConn.Send("HDEL", append([]interface{}{ticketReservations}, ids...)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending proposed tickets reservations removal")
+		}
+		err = redisConn.Send("DEL", keys...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending reservations removal")
+		}
+		released := make([]string, len(ids))
+		for i, id := range ids {
+			released[i] = id.(string)
+		}
+		err = rb.sendTicketChange(redisConn, TicketsDeletedFromPendingRelease, time.Now(), released)
+		if err != nil {
+			return nil, err
+		}
+
+		reply, err := redisConn.Do("EXEC")
+		if err != nil {
+			err = errors.Wrap(err, "failed to release matches")
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// A nil reply means the reservations were modified concurrently.
+		if reply != nil {
+			return released, nil
+		}
+	}
+	return nil, status.Error(codes.Aborted, "ticket reservations were modified concurrently")
+}
+
+// getReservedTickets returns the ids of the tickets still reserved under the
+// tokens, reading only the sets of those reservations.
+func (rb *redisBackend) getReservedTickets(redisConn redis.Conn, tokens []string) ([]interface{}, error) {
+	for _, token := range tokens {
+		err := redisConn.Send("SMEMBERS", reservationKey(token))
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending reservation tickets read")
+		}
+	}
+	err := redisConn.Flush()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading reservation tickets")
+	}
+
+	var members []string
+	seen := make(map[string]struct{})
+	release := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		release[token] = struct{}{}
+		ids, err := redis.Strings(redisConn.Receive())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error getting reservation tickets %v", err)
+		}
+		for _, id := range ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				members = append(members, id)
+			}
+		}
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(members)+1)
+	args = append(args, ticketReservations)
+	for _, id := range members {
+		args = append(args, id)
+	}
+	current, err := redis.Strings(redisConn.Do("HMGET", args...))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting ticket reservations %v", err)
+	}
+
+	// Tickets released, or reserved again under another token, since the
+	// reservation was made are left out.
+	ids := []interface{}{}
+	for i, id := range members {
+		if _, ok := release[current[i]]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteExpiredTickets deindexes and deletes all tickets whose expire time has
+// passed, returning the ids of the tickets it removed.  When called
+// concurrently, each expired ticket is returned by exactly one caller.
+func (rb *redisBackend) DeleteExpiredTickets(ctx context.Context) ([]string, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "DeleteExpiredTickets, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	candidates, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", expiringTickets, "-inf", time.Now().UnixNano()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting expired tickets %v", err)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Claim the expired tickets, so that concurrent callers don't both report
+	// the same expiration.
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting redis multi")
+	}
+	for _, id := range candidates {
+		err = redisConn.Send("ZREM", expiringTickets, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending expired ticket claim")
+		}
+	}
+	claimed, err := redis.Ints(redisConn.Do("EXEC"))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error claiming expired tickets %v", err)
+	}
+	if len(claimed) != len(candidates) {
+		return nil, status.Errorf(codes.Internal, "sent %d tickets to redis, but received %d back", len(candidates), len(claimed))
+	}
+
+	ids := make([]string, 0, len(candidates))
+	idsI := make([]interface{}, 0, len(candidates))
+	for i, id := range candidates {
+		if claimed[i] == 1 {
+			ids = append(ids, id)
+			idsI = append(idsI, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SREM", append([]interface{}{allTickets}, idsI...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending expired tickets deindex")
+	}
+	err = redisConn.Send("ZREM", append([]interface{}{"proposed_ticket_ids"}, idsI...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending expired tickets pending release removal")
+	}
+	err = redisConn.Send("HDEL", append([]interface{}{ticketReservations}, idsI...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending expired tickets reservations removal")
+	}
+	err = redisConn.Send("DEL", idsI...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending expired tickets delete")
+	}
+	now := time.Now()
+	err = rb.sendTicketChange(redisConn, TicketsDeindexed, now, ids)
+	if err != nil {
+		return nil, err
+	}
+	err = rb.sendTicketChange(redisConn, TicketsDeletedFromPendingRelease, now, ids)
+	if err != nil {
+		return nil, err
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error deleting expired tickets %v", err)
+	}
+
+	return ids, nil
+}
+
+// backfillKey returns the key a backfill is stored under, keeping backfills
+// separate from tickets.
+func backfillKey(id string) string {
+	return "backfill/" + id
+}
+
+// CreateBackfill creates a new Backfill in the state storage. If the id already exists, it will be overwritten.
+func (rb *redisBackend) CreateBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "CreateBackfill, id: %s, failed to connect to redis: %v", backfill.GetId(), err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	value, err := proto.Marshal(backfill)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the backfill proto, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	_, err = redisConn.Do("SET", backfillKey(backfill.GetId()), value)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to set the value for backfill, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// GetBackfill gets the Backfill with the specified id from state storage. This method fails if the Backfill does not exist.
+func (rb *redisBackend) GetBackfill(ctx context.Context, id string) (*pb.Backfill, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetBackfill, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	return getBackfill(redisConn, id)
+}
+
+func getBackfill(redisConn redis.Conn, id string) (*pb.Backfill, error) {
+	value, err := redis.Bytes(redisConn.Do("GET", backfillKey(id)))
+	if err != nil {
+		// Return NotFound if redigo did not find the backfill in storage.
+		if err == redis.ErrNil {
+			msg := fmt.Sprintf("Backfill id:%s not found", id)
+			return nil, status.Error(codes.NotFound, msg)
+		}
+
+		err = errors.Wrapf(err, "failed to get the backfill from state storage, id: %s", id)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	backfill := &pb.Backfill{}
+	err = proto.Unmarshal(value, backfill)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to unmarshal the backfill proto, id: %s", id)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return backfill, nil
+}
+
+// GetBackfills returns multiple backfills from storage.  Missing backfills are
+// silently ignored.
+func (rb *redisBackend) GetBackfills(ctx context.Context, ids []string) ([]*pb.Backfill, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetBackfills, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	queryParams := make([]interface{}, len(ids))
+	for i, id := range ids {
+		queryParams[i] = backfillKey(id)
+	}
+
+	backfillBytes, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to lookup backfills %v", ids)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	r := make([]*pb.Backfill, 0, len(ids))
+	for i, b := range backfillBytes {
+		// Backfills may be deleted by the time we read it from redis.
+		if b != nil {
+			backfill := &pb.Backfill{}
+			err = proto.Unmarshal(b, backfill)
+			if err != nil {
+				err = errors.Wrapf(err, "failed to unmarshal backfill from redis, key %s", ids[i])
+				return nil, status.Errorf(codes.Internal, "%v", err)
+			}
+			r = append(r, backfill)
+		}
+	}
+
+	return r, nil
+}
+
+// UpdateBackfill overwrites an existing Backfill, only if its stored
+// generation is expectedGeneration.
+func (rb *redisBackend) UpdateBackfill(ctx context.Context, backfill *pb.Backfill, expectedGeneration int64) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "UpdateBackfill, id: %s, failed to connect to redis: %v", backfill.GetId(), err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	key := backfillKey(backfill.GetId())
+	_, err = redisConn.Do("WATCH", key)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to watch backfill, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	current, err := getBackfill(redisConn, backfill.GetId())
+	if err != nil {
+		return err
+	}
+	if current.GetGeneration() != expectedGeneration {
+		return status.Errorf(codes.Aborted, "backfill %s has generation %d, expected %d", backfill.GetId(), current.GetGeneration(), expectedGeneration)
+	}
+
+	value, err := proto.Marshal(backfill)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal the backfill proto, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SET", key, value, "XX")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to set the value for backfill, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	reply, err := redisConn.Do("EXEC")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to update the backfill, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	// A nil reply means the watched key was modified concurrently.
+	if reply == nil {
+		return status.Errorf(codes.Aborted, "backfill %s was modified concurrently", backfill.GetId())
+	}
+
+	return nil
+}
+
+// DeleteBackfill removes the Backfill with the specified id from state storage.
+func (rb *redisBackend) DeleteBackfill(ctx context.Context, id string) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "DeleteBackfill, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("DEL", backfillKey(id))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to delete the backfill from state storage, id: %s", id)
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// IndexBackfill adds the Backfill id to the index.
+func (rb *redisBackend) IndexBackfill(ctx context.Context, backfill *pb.Backfill) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "IndexBackfill, id: %s, failed to connect to redis: %v", backfill.GetId(), err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("SADD", allBackfills, backfill.GetId())
+	if err != nil {
+		err = errors.Wrapf(err, "failed to add backfill to all backfills, id: %s", backfill.GetId())
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// DeindexBackfill removes the Backfill id from the index. The Backfill continues to exist.
+func (rb *redisBackend) DeindexBackfill(ctx context.Context, id string) error {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "DeindexBackfill, id: %s, failed to connect to redis: %v", id, err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("SREM", allBackfills, id)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to remove backfill from all backfills, id: %s", id)
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// GetIndexedBackfillIDSet returns the ids of all backfills currently indexed.
+func (rb *redisBackend) GetIndexedBackfillIDSet(ctx context.Context) (map[string]struct{}, error) {
+	redisConn, err := rb.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "GetIndexedBackfillIDSet, failed to connect to redis: %v", err)
+	}
+	defer handleConnectionClose(&redisConn)
+
+	idsIndexed, err := redis.Strings(redisConn.Do("SMEMBERS", allBackfills))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting all indexed backfill ids %v", err)
+	}
+
+	r := make(map[string]struct{}, len(idsIndexed))
+	for _, id := range idsIndexed {
+		r[id] = struct{}{}
+	}
+	return r, nil
+}
+
 func handleConnectionClose(conn *redis.Conn) {
 	err := (*conn).Close()
 	if err != nil {
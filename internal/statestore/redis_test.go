@@ -24,6 +24,8 @@ import (
 
 	"github.com/Bose/minisentinel"
 	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/rs/xid"
 	"github.com/spf13/viper"
@@ -134,9 +136,18 @@ func TestPendingReleases(t *testing.T) {
 	verifyTickets(service, len(tickets))
 
 	// Add the first three tickets to the pending release and verify changes are reflected in the result
+	before := time.Now()
 	require.Nil(t, service.AddTicketsToPendingRelease(ctx, ticketIds[:3]))
 	verifyTickets(service, len(tickets)-3)
 
+	pendingTime, ok, err := service.GetPendingReleaseTime(ctx, ticketIds[0])
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.WithinDuration(t, before, pendingTime, time.Second)
+	_, ok, err = service.GetPendingReleaseTime(ctx, ticketIds[3])
+	require.Nil(t, err)
+	require.False(t, ok)
+
 	// Sleep until the pending release expired and verify we still have all the tickets
 	time.Sleep(cfg.GetDuration("pendingReleaseTimeout"))
 	verifyTickets(service, len(tickets))
@@ -268,7 +279,7 @@ func testConnect(t *testing.T, withSentinel bool, withPassword string) {
 	require.Equal(t, "PONG", rply)
 }
 
-func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.View, func()) {
+func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.Mutable, func()) {
 	cfg := viper.New()
 	closerFuncs := []func(){}
 	mredis := miniredis.NewMiniRedis()
@@ -283,6 +294,7 @@ func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.V
 	cfg.Set("redis.pool.healthCheckTimeout", 100*time.Millisecond)
 	cfg.Set("redis.pool.maxActive", 5)
 	cfg.Set("pendingReleaseTimeout", "200ms")
+	cfg.Set("assignedDeleteTimeout", "200ms")
 	cfg.Set("backoff.initialInterval", 100*time.Millisecond)
 	cfg.Set("backoff.randFactor", 0.5)
 	cfg.Set("backoff.multiplier", 0.5)
@@ -330,3 +342,670 @@ func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.V
 		}
 	}
 }
+
+func TestDeleteExpiredTickets(t *testing.T) {
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	testDeleteExpiredTickets(t, cfg)
+}
+
+func testDeleteExpiredTickets(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	expireTime, err := ptypes.TimestampProto(time.Now().Add(100 * time.Millisecond))
+	require.Nil(t, err)
+	tickets := []*pb.Ticket{
+		{Id: "expiring", ExpireTime: expireTime},
+		{Id: "assigned", ExpireTime: expireTime},
+		{Id: "permanent"},
+	}
+	for _, ticket := range tickets {
+		require.Nil(t, service.CreateTicket(ctx, ticket))
+		require.Nil(t, service.IndexTicket(ctx, ticket))
+	}
+	_, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"assigned"},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	ids, err := service.DeleteExpiredTickets(ctx)
+	require.Nil(t, err)
+	require.Empty(t, ids)
+
+	indexed, err := service.GetIndexedIDSet(ctx)
+	require.Nil(t, err)
+	require.Len(t, indexed, 3)
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Expired tickets are no longer returned, even before they are deleted.
+	indexed, err = service.GetIndexedIDSet(ctx)
+	require.Nil(t, err)
+	require.Len(t, indexed, 2)
+	require.NotContains(t, indexed, "expiring")
+
+	ids, err = service.DeleteExpiredTickets(ctx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"expiring"}, ids)
+
+	_, err = service.GetTicket(ctx, "expiring")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Each expiration is only reported once.
+	ids, err = service.DeleteExpiredTickets(ctx)
+	require.Nil(t, err)
+	require.Empty(t, ids)
+}
+
+func TestBackfillLifecycle(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testBackfillLifecycle(t, cfg)
+}
+
+func testBackfillLifecycle(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	backfill := &pb.Backfill{
+		Id: "1",
+		SearchFields: &pb.SearchFields{
+			Tags: []string{"mode.ctf"},
+		},
+		Generation: 1,
+	}
+
+	_, err := service.GetBackfill(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	err = service.UpdateBackfill(ctx, backfill, 1)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, service.DeleteBackfill(ctx, "1"))
+	require.Nil(t, service.DeindexBackfill(ctx, "1"))
+
+	require.Nil(t, service.CreateBackfill(ctx, backfill))
+	require.Nil(t, service.IndexBackfill(ctx, backfill))
+
+	result, err := service.GetBackfill(ctx, "1")
+	require.Nil(t, err)
+	require.Equal(t, int64(1), result.Generation)
+	require.Equal(t, []string{"mode.ctf"}, result.SearchFields.Tags)
+
+	backfills, err := service.GetBackfills(ctx, []string{"1", "missing"})
+	require.Nil(t, err)
+	require.Len(t, backfills, 1)
+
+	indexed, err := service.GetIndexedBackfillIDSet(ctx)
+	require.Nil(t, err)
+	require.Contains(t, indexed, "1")
+
+	// Updates only succeed against the stored generation.
+	updated := &pb.Backfill{Id: "1", Generation: 2}
+	require.Nil(t, service.UpdateBackfill(ctx, updated, 1))
+	err = service.UpdateBackfill(ctx, &pb.Backfill{Id: "1", Generation: 2}, 1)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	result, err = service.GetBackfill(ctx, "1")
+	require.Nil(t, err)
+	require.Equal(t, int64(2), result.Generation)
+	require.Nil(t, result.SearchFields)
+
+	require.Nil(t, service.DeindexBackfill(ctx, "1"))
+	indexed, err = service.GetIndexedBackfillIDSet(ctx)
+	require.Nil(t, err)
+	require.Empty(t, indexed)
+
+	require.Nil(t, service.DeleteBackfill(ctx, "1"))
+	_, err = service.GetBackfill(ctx, "1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubscribeAssignments(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testSubscribeAssignments(t, cfg)
+}
+
+func testSubscribeAssignments(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
+	defer cancel()
+
+	assign := func(id, connection string) {
+		_, err := service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+			Assignments: []*pb.AssignmentGroup{
+				{
+					TicketIds:  []string{id},
+					Assignment: &pb.Assignment{Connection: connection},
+				},
+			},
+		})
+		require.Nil(t, err)
+	}
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.Nil(t, service.CreateTicket(ctx, &pb.Ticket{Id: id}))
+	}
+	assign("1", "a")
+
+	subscribe := func(ids []string, all bool) (chan *pb.Ticket, chan error) {
+		tickets := make(chan *pb.Ticket, 10)
+		errs := make(chan error, 1)
+		go func() {
+			errs <- service.SubscribeAssignments(ctx, ids, all, func(ticket *pb.Ticket) error {
+				tickets <- ticket
+				return nil
+			})
+		}()
+		return tickets, errs
+	}
+
+	// Existing assignments of the requested tickets are passed first.
+	some, someErrs := subscribe([]string{"1", "2", "2", "missing"}, false)
+	ticket := <-some
+	require.Equal(t, "1", ticket.Id)
+	require.Equal(t, "a", ticket.Assignment.Connection)
+
+	all, allErrs := subscribe([]string{"1"}, true)
+	ticket = <-all
+	require.Equal(t, "1", ticket.Id)
+
+	// Only subscriptions to all tickets are passed unrequested tickets.
+	assign("3", "c")
+	ticket = <-all
+	require.Equal(t, "3", ticket.Id)
+	require.Equal(t, "c", ticket.Assignment.Connection)
+
+	assign("2", "b")
+	ticket = <-some
+	require.Equal(t, "2", ticket.Id)
+	require.Equal(t, "b", ticket.Assignment.Connection)
+	ticket = <-all
+	require.Equal(t, "2", ticket.Id)
+
+	err := service.SubscribeAssignments(ctx, nil, false, func(*pb.Ticket) error { return nil })
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	cancel()
+	require.Equal(t, codes.Canceled, status.Code(<-someErrs))
+	require.Equal(t, codes.Canceled, status.Code(<-allErrs))
+}
+
+func TestTicketChanges(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testTicketChanges(t, cfg)
+}
+
+func testTicketChanges(t *testing.T, cfg config.Mutable) {
+	cfg.Set("statestore.ticketChangeLogLength", 10)
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	snapshot, err := service.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+	require.Empty(t, snapshot.Indexed)
+	require.Empty(t, snapshot.Pending)
+
+	before := time.Now()
+	require.Nil(t, service.IndexTicket(ctx, &pb.Ticket{Id: "1"}))
+	require.Nil(t, service.IndexTicket(ctx, &pb.Ticket{Id: "2"}))
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"1", "2"}))
+	require.Nil(t, service.DeindexTicket(ctx, "2"))
+	require.Nil(t, service.DeleteTicketsFromPendingRelease(ctx, []string{"2"}))
+	require.Nil(t, service.ReleaseAllTickets(ctx))
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"1"}))
+	after := time.Now()
+
+	changes, cursor, err := service.GetTicketChanges(ctx, snapshot.Cursor)
+	require.Nil(t, err)
+	want := []struct {
+		kind TicketChangeKind
+		ids  []string
+	}{
+		{TicketsIndexed, []string{"1"}},
+		{TicketsIndexed, []string{"2"}},
+		{TicketsAddedToPendingRelease, []string{"1", "2"}},
+		{TicketsDeindexed, []string{"2"}},
+		{TicketsDeletedFromPendingRelease, []string{"2"}},
+		{AllTicketsReleased, nil},
+		{TicketsAddedToPendingRelease, []string{"1"}},
+	}
+	require.Len(t, changes, len(want))
+	for i, w := range want {
+		require.Equal(t, w.kind, changes[i].Kind)
+		require.Equal(t, w.ids, changes[i].IDs)
+		require.False(t, changes[i].Time.Before(before.Add(-time.Millisecond)))
+		require.False(t, changes[i].Time.After(after.Add(time.Millisecond)))
+	}
+
+	// Reading from the returned cursor only returns later changes.
+	changes, cursor, err = service.GetTicketChanges(ctx, cursor)
+	require.Nil(t, err)
+	require.Empty(t, changes)
+	require.Nil(t, service.IndexTicket(ctx, &pb.Ticket{Id: "3"}))
+	changes, _, err = service.GetTicketChanges(ctx, cursor)
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, []string{"3"}, changes[0].IDs)
+
+	snapshot, err = service.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+	require.Equal(t, map[string]struct{}{"1": {}, "3": {}}, snapshot.Indexed)
+	require.Len(t, snapshot.Pending, 1)
+	require.WithinDuration(t, changes[0].Time, snapshot.Pending["1"], time.Second)
+
+	// Falling behind the retained changes requires a new snapshot.
+	for i := 0; i < 30; i++ {
+		require.Nil(t, service.IndexTicket(ctx, &pb.Ticket{Id: "3"}))
+	}
+	_, _, err = service.GetTicketChanges(ctx, snapshot.Cursor)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestUpdateTicket(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testUpdateTicket(t, cfg)
+}
+
+func testUpdateTicket(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	_, err := service.UpdateTicket(ctx, &pb.Ticket{Id: "1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	createTime := ptypes.TimestampNow()
+	expireTime, err := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	require.Nil(t, err)
+	for _, id := range []string{"1", "2", "3"} {
+		ticket := &pb.Ticket{
+			Id: id,
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"skill": 10},
+			},
+			CreateTime: createTime,
+			ExpireTime: expireTime,
+		}
+		require.Nil(t, service.CreateTicket(ctx, ticket))
+		require.Nil(t, service.IndexTicket(ctx, ticket))
+	}
+
+	snapshot, err := service.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+
+	// Only the search fields and extensions are replaced.
+	updated, err := service.UpdateTicket(ctx, &pb.Ticket{
+		Id: "1",
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"skill": 20},
+		},
+		CreateTime: ptypes.TimestampNow(),
+	})
+	require.Nil(t, err)
+	require.Equal(t, 20.0, updated.SearchFields.DoubleArgs["skill"])
+	require.True(t, proto.Equal(createTime, updated.CreateTime))
+	require.True(t, proto.Equal(expireTime, updated.ExpireTime))
+
+	result, err := service.GetTicket(ctx, "1")
+	require.Nil(t, err)
+	require.True(t, proto.Equal(updated, result))
+
+	changes, _, err := service.GetTicketChanges(ctx, snapshot.Cursor)
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, TicketsUpdated, changes[0].Kind)
+	require.Equal(t, []string{"1"}, changes[0].IDs)
+
+	// Assigned and pending release tickets can't be updated.
+	_, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"2"},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	_, err = service.UpdateTicket(ctx, &pb.Ticket{Id: "2"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"3"}))
+	_, err = service.UpdateTicket(ctx, &pb.Ticket{Id: "3"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.Nil(t, service.DeleteTicketsFromPendingRelease(ctx, []string{"3"}))
+	_, err = service.UpdateTicket(ctx, &pb.Ticket{Id: "3"})
+	require.Nil(t, err)
+}
+
+func TestTicketBatches(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testTicketBatches(t, cfg)
+}
+
+func testTicketBatches(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	expireTime, err := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	require.Nil(t, err)
+	tickets := []*pb.Ticket{
+		{Id: "1"},
+		{Id: "2", ExpireTime: expireTime},
+	}
+
+	require.Nil(t, service.CreateTickets(ctx, nil))
+	require.Nil(t, service.CreateTickets(ctx, tickets))
+	result, err := service.GetTickets(ctx, []string{"1", "2"})
+	require.Nil(t, err)
+	require.Len(t, result, 2)
+
+	snapshot, err := service.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+	require.Nil(t, service.IndexTickets(ctx, tickets))
+	indexed, err := service.GetIndexedIDSet(ctx)
+	require.Nil(t, err)
+	require.Equal(t, map[string]struct{}{"1": {}, "2": {}}, indexed)
+
+	require.Nil(t, service.DeindexTickets(ctx, []string{"1", "2"}))
+	indexed, err = service.GetIndexedIDSet(ctx)
+	require.Nil(t, err)
+	require.Empty(t, indexed)
+
+	// Each batch is a single change.
+	changes, _, err := service.GetTicketChanges(ctx, snapshot.Cursor)
+	require.Nil(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, TicketsIndexed, changes[0].Kind)
+	require.Equal(t, []string{"1", "2"}, changes[0].IDs)
+	require.Equal(t, TicketsDeindexed, changes[1].Kind)
+	require.Equal(t, []string{"1", "2"}, changes[1].IDs)
+
+	require.Nil(t, service.DeleteTickets(ctx, []string{"1", "2", "missing"}))
+	result, err = service.GetTickets(ctx, []string{"1", "2"})
+	require.Nil(t, err)
+	require.Empty(t, result)
+	ids, err := service.DeleteExpiredTickets(ctx)
+	require.Nil(t, err)
+	require.Empty(t, ids)
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testIdempotencyKeys(t, cfg)
+}
+
+func testIdempotencyKeys(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	hash := []byte("hash")
+	existing, err := service.ReserveIdempotencyKey(ctx, "key", hash, &pb.Ticket{Id: "1"}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, existing)
+
+	// Repeats of the request return the first ticket.
+	existing, err = service.ReserveIdempotencyKey(ctx, "key", hash, &pb.Ticket{Id: "2"}, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "1", existing.GetId())
+
+	// Other requests repeating the key fail.
+	existing, err = service.ReserveIdempotencyKey(ctx, "key", []byte("other"), &pb.Ticket{Id: "2"}, time.Minute)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, existing)
+
+	existing, err = service.ReserveIdempotencyKey(ctx, "other", hash, &pb.Ticket{Id: "3"}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, existing)
+
+	require.Nil(t, service.DeleteIdempotencyKey(ctx, "key"))
+	require.Nil(t, service.DeleteIdempotencyKey(ctx, "missing"))
+	existing, err = service.ReserveIdempotencyKey(ctx, "key", []byte("other"), &pb.Ticket{Id: "4"}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, existing)
+}
+
+func TestConditionalAssignments(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testConditionalAssignments(t, cfg)
+}
+
+func testConditionalAssignments(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, id := range []string{"1", "2", "3", "4", "5", "6"} {
+		require.Nil(t, service.CreateTicket(ctx, &pb.Ticket{Id: id}))
+	}
+	require.Nil(t, service.AddMatchTicketsToPendingRelease(ctx, map[string][]string{
+		"a": {"1", "2"},
+		"b": {"3", "4"},
+	}))
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"5"}))
+
+	resp, err := service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:        []string{"1", "2"},
+				Assignment:       &pb.Assignment{Connection: "a"},
+				ReservationToken: "a",
+			},
+			{
+				// Ticket 3 is reserved for match b, so none of the group is
+				// assigned.
+				TicketIds:        []string{"3", "6"},
+				Assignment:       &pb.Assignment{Connection: "c"},
+				ReservationToken: "c",
+			},
+			{
+				// Ticket 5 is pending, but wasn't reserved for a match.
+				TicketIds:        []string{"5"},
+				Assignment:       &pb.Assignment{Connection: "c"},
+				ReservationToken: "c",
+			},
+			{
+				TicketIds:        []string{"missing"},
+				Assignment:       &pb.Assignment{Connection: "d"},
+				ReservationToken: "d",
+			},
+		},
+	})
+	require.Nil(t, err)
+	causes := make(map[string]pb.AssignmentFailure_Cause)
+	for _, f := range resp.Failures {
+		causes[f.TicketId] = f.Cause
+	}
+	require.Equal(t, map[string]pb.AssignmentFailure_Cause{
+		"3":       pb.AssignmentFailure_RESERVATION_LOST,
+		"6":       pb.AssignmentFailure_RESERVATION_LOST,
+		"5":       pb.AssignmentFailure_RESERVATION_LOST,
+		"missing": pb.AssignmentFailure_TICKET_NOT_FOUND,
+	}, causes)
+
+	for _, id := range []string{"1", "2"} {
+		ticket, err := service.GetTicket(ctx, id)
+		require.Nil(t, err)
+		require.Equal(t, "a", ticket.GetAssignment().GetConnection())
+	}
+	for _, id := range []string{"3", "5", "6"} {
+		ticket, err := service.GetTicket(ctx, id)
+		require.Nil(t, err)
+		require.Nil(t, ticket.Assignment)
+	}
+
+	// Released tickets are no longer reserved for their match.
+	require.Nil(t, service.DeleteTicketsFromPendingRelease(ctx, []string{"4"}))
+	resp, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:        []string{"3", "4"},
+				Assignment:       &pb.Assignment{Connection: "b"},
+				ReservationToken: "b",
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, resp.Failures, 2)
+	for _, f := range resp.Failures {
+		require.Equal(t, pb.AssignmentFailure_RESERVATION_LOST, f.Cause)
+	}
+
+	// Groups without a reservation token are assigned unconditionally.
+	resp, err = service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{"3", "4", "6"},
+				Assignment: &pb.Assignment{Connection: "e"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Empty(t, resp.Failures)
+}
+
+func TestReleaseMatches(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	testReleaseMatches(t, cfg)
+}
+
+func testReleaseMatches(t *testing.T, cfg config.View) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	ids, err := service.ReleaseMatches(ctx, []string{"a"})
+	require.Nil(t, err)
+	require.Empty(t, ids)
+
+	require.Nil(t, service.AddMatchTicketsToPendingRelease(ctx, map[string][]string{
+		"a": {"1", "2"},
+		"b": {"3"},
+		"c": {"4"},
+	}))
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"5"}))
+	// Ticket 4 was proposed again without a match, so it no longer belongs to
+	// match c.
+	require.Nil(t, service.AddTicketsToPendingRelease(ctx, []string{"4"}))
+
+	snapshot, err := service.GetTicketSnapshot(ctx)
+	require.Nil(t, err)
+
+	ids, err = service.ReleaseMatches(ctx, []string{"a", "c", "missing"})
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"1", "2"}, ids)
+
+	for id, pending := range map[string]bool{"1": false, "2": false, "3": true, "4": true, "5": true} {
+		_, ok, err := service.GetPendingReleaseTime(ctx, id)
+		require.Nil(t, err)
+		require.Equal(t, pending, ok, id)
+	}
+
+	changes, _, err := service.GetTicketChanges(ctx, snapshot.Cursor)
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, TicketsDeletedFromPendingRelease, changes[0].Kind)
+	require.ElementsMatch(t, []string{"1", "2"}, changes[0].IDs)
+
+	// Released matches can't be released again.
+	ids, err = service.ReleaseMatches(ctx, []string{"a"})
+	require.Nil(t, err)
+	require.Empty(t, ids)
+
+	// Tickets reserved again are only released by their latest reservation.
+	require.Nil(t, service.AddMatchTicketsToPendingRelease(ctx, map[string][]string{
+		"d": {"6", "7"},
+	}))
+	require.Nil(t, service.AddMatchTicketsToPendingRelease(ctx, map[string][]string{
+		"e": {"7"},
+	}))
+	ids, err = service.ReleaseMatches(ctx, []string{"d"})
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"6"}, ids)
+	ids, err = service.ReleaseMatches(ctx, []string{"d", "e"})
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"7"}, ids)
+}
+
+func TestAssignedAndDeletedTicketsUnreserved(t *testing.T) {
+	cfg, closer := createRedis(t, false, "")
+	defer closer()
+	rb := newRedis(cfg).(*redisBackend)
+	defer rb.Close()
+
+	testAssignedAndDeletedTicketsUnreserved(t, cfg, func(id string) bool {
+		conn, err := rb.redisPool.GetContext(context.Background())
+		require.Nil(t, err)
+		defer handleConnectionClose(&conn)
+		reserved, err := redis.Bool(conn.Do("HEXISTS", ticketReservations, id))
+		require.Nil(t, err)
+		return reserved
+	})
+}
+
+func testAssignedAndDeletedTicketsUnreserved(t *testing.T, cfg config.View, reserved func(id string) bool) {
+	service := New(cfg)
+	require.NotNil(t, service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.Nil(t, service.CreateTicket(ctx, &pb.Ticket{Id: id}))
+	}
+	require.Nil(t, service.AddMatchTicketsToPendingRelease(ctx, map[string][]string{
+		"a": {"1", "2"},
+		"b": {"3"},
+	}))
+	for _, id := range []string{"1", "2", "3"} {
+		require.True(t, reserved(id), id)
+	}
+
+	resp, err := service.UpdateAssignments(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:        []string{"1", "2"},
+				Assignment:       &pb.Assignment{Connection: "a"},
+				ReservationToken: "a",
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Empty(t, resp.Failures)
+	require.Nil(t, service.DeleteTicket(ctx, "3"))
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.False(t, reserved(id), id)
+	}
+
+	// Tickets which are no longer reserved aren't released with their match.
+	ids, err := service.ReleaseMatches(ctx, []string{"a", "b"})
+	require.Nil(t, err)
+	require.Empty(t, ids)
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// TestBackfillLifecycle covers creating, updating, querying and deleting a
+// backfill through the frontend.
+func TestBackfillLifecycle(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	b, err := om.Frontend().CreateBackfill(ctx, &pb.CreateBackfillRequest{
+		Backfill: &pb.Backfill{
+			SearchFields: &pb.SearchFields{Tags: []string{"mode.ctf"}},
+		},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, b.Id)
+	require.NotNil(t, b.CreateTime)
+	require.Equal(t, int64(1), b.Generation)
+
+	updated, err := om.Frontend().UpdateBackfill(ctx, &pb.UpdateBackfillRequest{
+		Backfill: &pb.Backfill{
+			Id:           b.Id,
+			SearchFields: &pb.SearchFields{Tags: []string{"mode.koth"}},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, int64(2), updated.Generation)
+	require.Equal(t, b.CreateTime.String(), updated.CreateTime.String())
+
+	get, err := om.Frontend().GetBackfill(ctx, &pb.GetBackfillRequest{BackfillId: b.Id})
+	require.Nil(t, err)
+	require.Equal(t, []string{"mode.koth"}, get.SearchFields.Tags)
+
+	require.Equal(t, []string{b.Id}, queryBackfillIDs(t, om, &pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.koth"}},
+	}))
+	require.Empty(t, queryBackfillIDs(t, om, &pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "mode.ctf"}},
+	}))
+
+	_, err = om.Frontend().DeleteBackfill(ctx, &pb.DeleteBackfillRequest{BackfillId: b.Id})
+	require.Nil(t, err)
+
+	_, err = om.Frontend().GetBackfill(ctx, &pb.GetBackfillRequest{BackfillId: b.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Empty(t, queryBackfillIDs(t, om, &pb.Pool{}))
+}
+
+// TestMatchUpdatesBackfill covers a match updating an existing backfill, and a
+// later match proposed against the stale generation being dropped.
+func TestMatchUpdatesBackfill(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	b, err := om.Frontend().CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+	require.Nil(t, err)
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	var proposal *pb.Match
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- proposal
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for p := range in {
+			out <- p.MatchId
+		}
+		return nil
+	})
+
+	fetch := func(m *pb.Match) []*pb.Match {
+		proposal = m
+		stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+			Config:  om.MMFConfigGRPC(),
+			Profile: &pb.MatchProfile{},
+		})
+		require.Nil(t, err)
+
+		var matches []*pb.Match
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return matches
+			}
+			require.Nil(t, err)
+			matches = append(matches, resp.Match)
+		}
+	}
+
+	matches := fetch(&pb.Match{
+		MatchId:  "1",
+		Tickets:  []*pb.Ticket{t1},
+		Backfill: b,
+	})
+	require.Len(t, matches, 1)
+	require.Equal(t, b.Id, matches[0].Backfill.Id)
+	require.Equal(t, int64(2), matches[0].Backfill.Generation)
+
+	get, err := om.Frontend().GetBackfill(ctx, &pb.GetBackfillRequest{BackfillId: b.Id})
+	require.Nil(t, err)
+	require.Equal(t, int64(2), get.Generation)
+
+	// b still carries generation 1, which is now stale.
+	matches = fetch(&pb.Match{
+		MatchId:  "2",
+		Tickets:  []*pb.Ticket{t2},
+		Backfill: b,
+	})
+	require.Empty(t, matches)
+}
+
+// TestMatchCreatesBackfill covers a match proposing a backfill without an id,
+// which creates a new backfill.
+func TestMatchCreatesBackfill(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{t1},
+			Backfill: &pb.Backfill{
+				SearchFields: &pb.SearchFields{Tags: []string{"open"}},
+			},
+		}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for p := range in {
+			out <- p.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.NotEmpty(t, resp.Match.Backfill.Id)
+	require.Equal(t, int64(1), resp.Match.Backfill.Generation)
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	require.Equal(t, []string{resp.Match.Backfill.Id}, queryBackfillIDs(t, om, &pb.Pool{
+		TagPresentFilters: []*pb.TagPresentFilter{{Tag: "open"}},
+	}))
+}
+
+func queryBackfillIDs(t *testing.T, om *om, pool *pb.Pool) []string {
+	stream, err := om.Query().QueryBackfills(context.Background(), &pb.QueryBackfillsRequest{Pool: pool})
+	require.Nil(t, err)
+
+	ids := []string{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return ids
+		}
+		require.Nil(t, err)
+		for _, b := range resp.Backfills {
+			ids = append(ids, b.Id)
+		}
+	}
+}
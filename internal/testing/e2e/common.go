@@ -172,6 +172,8 @@ registrationInterval: 200ms
 proposalCollectionInterval: 200ms
 pendingReleaseTimeout: 200ms
 assignedDeleteTimeout: 200ms
+ticketTTL: 0s
+idempotencyKeyWindow: 1m
 queryPageSize: 10
 
 logging:
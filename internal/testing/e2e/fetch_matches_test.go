@@ -16,12 +16,14 @@ package e2e
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -76,6 +78,108 @@ func TestHappyPath(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestFetchMatchesDryRun covers a dry run returning every proposal with the
+// evaluator's decision, without reserving tickets or writing backfills.
+func TestFetchMatchesDryRun(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	tickets := []*pb.Ticket{}
+	for i := 0; i < 5; i++ {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.Nil(t, err)
+		tickets = append(tickets, ticket)
+	}
+	backfill, err := om.Frontend().CreateBackfill(ctx, &pb.CreateBackfillRequest{Backfill: &pb.Backfill{}})
+	require.Nil(t, err)
+
+	matches := []*pb.Match{
+		{
+			MatchId:  "1",
+			Tickets:  []*pb.Ticket{tickets[0], tickets[1]},
+			Backfill: &pb.Backfill{},
+		},
+		{
+			MatchId: "2",
+			Tickets: []*pb.Ticket{tickets[1], tickets[2]},
+		},
+		{
+			MatchId: "3",
+			Tickets: []*pb.Ticket{tickets[2]},
+		},
+		{
+			MatchId:  "4",
+			Tickets:  []*pb.Ticket{tickets[3]},
+			Backfill: backfill,
+		},
+		{
+			MatchId:  "5",
+			Tickets:  []*pb.Ticket{tickets[4]},
+			Backfill: backfill,
+		},
+	}
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		for _, m := range matches {
+			out <- m
+		}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			if m.MatchId == "1" || m.MatchId == "4" {
+				out <- m.MatchId
+			}
+		}
+		return nil
+	})
+
+	fetch := func(dryRun bool) []*pb.FetchMatchesResponse {
+		stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+			Config:  om.MMFConfigGRPC(),
+			Profile: &pb.MatchProfile{Name: "profile"},
+			DryRun:  dryRun,
+		})
+		require.Nil(t, err)
+
+		var resps []*pb.FetchMatchesResponse
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return resps
+			}
+			require.Nil(t, err)
+			resps = append(resps, resp)
+		}
+	}
+
+	resps := fetch(true)
+	require.Len(t, resps, 5)
+	for i, want := range []*pb.DryRunResult{
+		{Accepted: true},
+		{CollidedMatchId: "1"},
+		{},
+		{Accepted: true},
+		{CollidedMatchId: "4"},
+	} {
+		require.True(t, proto.Equal(matches[i], resps[i].Match))
+		require.True(t, proto.Equal(want, resps[i].DryRunResult), "%v", resps[i].DryRunResult)
+	}
+
+	// Match 1's new backfill wasn't created.
+	require.Equal(t, []string{backfill.Id}, queryBackfillIDs(t, om, &pb.Pool{}))
+
+	// The tickets weren't reserved, so a real call returns the same matches.
+	resps = fetch(false)
+	ids := []string{}
+	for _, resp := range resps {
+		ids = append(ids, resp.Match.MatchId)
+		require.Nil(t, resp.DryRunResult)
+	}
+	require.ElementsMatch(t, []string{"1", "4"}, ids)
+}
+
 // TestMatchFunctionMatchCollision covers two matches with the same id coming
 // from the same MMF generates an error to the fetch matches call.  Also ensures
 // another function running in the same cycle does not experience an error.
@@ -357,6 +461,7 @@ func TestEvaluatorError(t *testing.T) {
 	resp, err := stream.Recv()
 
 	require.Contains(t, err.Error(), "my custom error")
+	require.Equal(t, codes.Unknown, status.Convert(err).Code())
 	require.Nil(t, resp)
 }
 
@@ -444,11 +549,28 @@ func TestNoConfig(t *testing.T) {
 	require.Nil(t, err)
 
 	resp, err := stream.Recv()
-	require.Equal(t, ".config is required", status.Convert(err).Message())
+	require.Equal(t, ".config or .function_name is required", status.Convert(err).Message())
 	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
 	require.Nil(t, resp)
 }
 
+// TestUnknownFunctionName covers fetch matches calling a match function which
+// isn't in the registry.
+func TestUnknownFunctionName(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		FunctionName: "unknown",
+		Profile:      &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Equal(t, codes.NotFound, status.Convert(err).Code())
+	require.Nil(t, resp)
+}
+
 // TestCancel covers a fetch matches call canceling also causing mmf and
 // evaluator to cancel.
 func TestCancel(t *testing.T) {
@@ -619,11 +741,83 @@ func TestProposalWindowClose(t *testing.T) {
 
 	resp, err := stream.Recv()
 	require.Contains(t, err.Error(), "match function ran longer than proposal window, canceling")
+	require.Equal(t, codes.Aborted, status.Convert(err).Code())
 	require.Nil(t, resp)
 
 	require.True(t, time.Since(startTime) > registrationInterval+proposalCollectionInterval, "%s", time.Since(startTime))
 }
 
+// TestFunctionTimeout covers the match function being canceled once its
+// function_timeout passes, with the proposals it already returned still
+// evaluated and returned.
+func TestFunctionTimeout(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		dryRun := dryRun
+		t.Run(fmt.Sprintf("dryRun=%v", dryRun), func(t *testing.T) {
+			ctx := context.Background()
+			om := newOM(t)
+
+			t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+			require.Nil(t, err)
+
+			m := &pb.Match{
+				MatchId: "1",
+				Tickets: []*pb.Ticket{t1},
+			}
+
+			om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+				out <- m
+				<-ctx.Done()
+				return nil
+			})
+
+			om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+				p, ok := <-in
+				require.True(t, ok)
+				require.True(t, proto.Equal(p, m))
+				_, ok = <-in
+				require.False(t, ok)
+
+				out <- m.MatchId
+				return nil
+			})
+
+			stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+				Config:          om.MMFConfigGRPC(),
+				Profile:         &pb.MatchProfile{},
+				DryRun:          dryRun,
+				FunctionTimeout: ptypes.DurationProto(50 * time.Millisecond),
+			})
+			require.Nil(t, err)
+
+			resp, err := stream.Recv()
+			require.Nil(t, err)
+			require.True(t, proto.Equal(m, resp.Match))
+
+			resp, err = stream.Recv()
+			require.Contains(t, err.Error(), "match function ran longer than function_timeout, canceling")
+			require.Equal(t, codes.DeadlineExceeded, status.Convert(err).Code())
+			require.Nil(t, resp)
+		})
+	}
+}
+
+func TestInvalidFunctionTimeout(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:          om.MMFConfigGRPC(),
+		Profile:         &pb.MatchProfile{},
+		FunctionTimeout: ptypes.DurationProto(-time.Second),
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+	require.Nil(t, resp)
+}
+
 // TestMultipleFetchCalls covers multiple fetch matches calls running in the
 // same cycle, using the same evaluator call, and having matches routed back to
 // the correct caller.
@@ -703,6 +897,108 @@ func TestMultipleFetchCalls(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestFetchMatchesBatch covers a batch running every profile's match function
+// in the same cycle, with matches and match function failures tagged with the
+// request they were made for.
+func TestFetchMatchesBatch(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	m1 := &pb.Match{
+		MatchId: "1",
+		Tickets: []*pb.Ticket{t1},
+	}
+	m2 := &pb.Match{
+		MatchId: "2",
+		Tickets: []*pb.Ticket{t2},
+	}
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		switch profile.Name {
+		case "one":
+			out <- m1
+		case "two":
+			out <- m2
+		default:
+			return errors.New("Unknown profile")
+		}
+
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		ids := []string{}
+		for m := range in {
+			ids = append(ids, m.MatchId)
+		}
+		require.ElementsMatch(t, ids, []string{"1", "2"})
+		for _, id := range ids {
+			out <- id
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatchesBatch(ctx, &pb.FetchMatchesBatchRequest{
+		Requests: []*pb.FetchMatchesRequest{
+			{Config: om.MMFConfigGRPC(), Profile: &pb.MatchProfile{Name: "one"}},
+			{Config: om.MMFConfigGRPC(), Profile: &pb.MatchProfile{Name: "two"}},
+			{Config: om.MMFConfigGRPC(), Profile: &pb.MatchProfile{Name: "three"}},
+		},
+	})
+	require.Nil(t, err)
+
+	matches := make(map[int32]*pb.Match)
+	for i := 0; i < 2; i++ {
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.Nil(t, resp.Status)
+		require.Equal(t, []string{"one", "two"}[resp.Index], resp.ProfileName)
+		matches[resp.Index] = resp.Match
+	}
+	require.True(t, proto.Equal(m1, matches[0]))
+	require.True(t, proto.Equal(m2, matches[1]))
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, int32(2), resp.Index)
+	require.Equal(t, "three", resp.ProfileName)
+	require.Nil(t, resp.Match)
+	require.Equal(t, int32(codes.Unknown), resp.Status.Code)
+	require.Contains(t, resp.Status.Message, "Unknown profile")
+
+	resp, err = stream.Recv()
+	require.Equal(t, err, io.EOF)
+	require.Nil(t, resp)
+}
+
+func TestFetchMatchesBatchInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	for _, req := range []*pb.FetchMatchesBatchRequest{
+		{},
+		{Requests: []*pb.FetchMatchesRequest{
+			{Config: om.MMFConfigGRPC(), Profile: &pb.MatchProfile{}},
+			{Config: om.MMFConfigGRPC()},
+		}},
+		{Requests: []*pb.FetchMatchesRequest{
+			{Config: om.MMFConfigGRPC(), Profile: &pb.MatchProfile{}, DryRun: true},
+		}},
+	} {
+		stream, err := om.Backend().FetchMatchesBatch(ctx, req)
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+		require.Nil(t, resp)
+	}
+}
+
 // TestSlowBackendDoesntBlock covers that after the evaluator has returned, a
 // new cycle can start despite and slow fetch matches caller.  Additionally, it
 // confirms that the tickets are marked as pending, so the second cycle won't be
@@ -17,6 +17,7 @@
 package e2e
 
 import (
+	"flag"
 	"net"
 	"strings"
 	"testing"
@@ -34,6 +35,10 @@ import (
 	mmfService "open-match.dev/open-match/internal/testing/mmf"
 )
 
+var (
+	testOnlyStatestoreBackend = flag.String("test_only_statestore_backend", "redis", "Sets the statestore backend, either redis or memory.")
+)
+
 func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
 	mredis := miniredis.NewMiniRedis()
 	err := mredis.StartAddr("localhost:0")
@@ -78,8 +83,11 @@ func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction)
 	cfg.Set("redis.sentinelPort", msentinal.Port())
 	cfg.Set("redis.sentinelMaster", msentinal.MasterInfo().Name)
 	services := []string{apptest.ServiceName, "synchronizer", "backend", "frontend", "query", "evaluator"}
+	// An IP address is used rather than localhost, as gRPC looks up the
+	// service config of hostnames over DNS, which can stall long enough for
+	// fetch matches calls to miss their registration and proposal windows.
 	for _, name := range services {
-		cfg.Set("api."+name+".hostname", "localhost")
+		cfg.Set("api."+name+".hostname", "127.0.0.1")
 		cfg.Set("api."+name+".grpcport", grpcPort)
 		cfg.Set("api."+name+".httpport", httpPort)
 	}
@@ -87,6 +95,12 @@ func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction)
 	cfg.Set("logging.level", *testOnlyLoggingLevel)
 	cfg.Set(telemetry.ConfigNameEnableMetrics, *testOnlyEnableMetrics)
 
+	cfg.Set("statestore.backend", *testOnlyStatestoreBackend)
+
 	apptest.TestApp(t, cfg, listeners, minimatch.BindService, mmfService.BindServiceFor(mmf), evaluator.BindServiceFor(eval))
+	if *testOnlyStatestoreBackend == "memory" {
+		// The in memory statestore uses the wall clock for expiration.
+		return cfg, time.Sleep
+	}
 	return cfg, mredis.FastForward
 }
@@ -18,7 +18,10 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -117,6 +120,192 @@ func TestPaging(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestQuerySelection covers max_results and order being applied before the
+// results are paged.
+func TestQuerySelection(t *testing.T) {
+	om := newOM(t)
+
+	var expectedIds []string
+	for i := 0; i < 25; i++ {
+		resp, err := om.Frontend().CreateTicket(context.Background(), &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"mmr": float64(i)},
+			},
+		}})
+		require.Nil(t, err)
+
+		if i >= 13 {
+			expectedIds = append([]string{resp.Id}, expectedIds...)
+		}
+	}
+
+	order := &pb.TicketOrder{
+		Field:      pb.TicketOrder_DOUBLE_ARG,
+		DoubleArg:  "mmr",
+		Descending: true,
+	}
+
+	{
+		stream, err := om.Query().QueryTickets(context.Background(), &pb.QueryTicketsRequest{
+			Pool:       &pb.Pool{},
+			MaxResults: 12,
+			Order:      order,
+		})
+		require.Nil(t, err)
+
+		var foundIds []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err)
+			for _, ticket := range resp.Tickets {
+				foundIds = append(foundIds, ticket.Id)
+			}
+		}
+		require.Equal(t, expectedIds, foundIds)
+	}
+
+	{
+		stream, err := om.Query().QueryTicketIds(context.Background(), &pb.QueryTicketIdsRequest{
+			Pool:       &pb.Pool{},
+			MaxResults: 12,
+			Order:      order,
+		})
+		require.Nil(t, err)
+
+		var foundIds []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err)
+			foundIds = append(foundIds, resp.Ids...)
+		}
+		require.Equal(t, expectedIds, foundIds)
+	}
+
+	{
+		stream, err := om.Query().QueryTickets(context.Background(), &pb.QueryTicketsRequest{
+			Pool:   &pb.Pool{},
+			Sample: true,
+		})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+		require.Nil(t, resp)
+	}
+}
+
+// TestQueryMaxStaleness covers queries being served from the ticket cache
+// without refreshing it when it is fresh enough.
+func TestQueryMaxStaleness(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	query := func(maxStaleness time.Duration) ([]string, time.Time) {
+		stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{
+			Pool:         &pb.Pool{},
+			MaxStaleness: ptypes.DurationProto(maxStaleness),
+		})
+		require.Nil(t, err)
+
+		var ids []string
+		var snapshotTime time.Time
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err)
+			ids = append(ids, resp.Ids...)
+			snapshotTime, err = ptypes.Timestamp(resp.SnapshotTime)
+			require.Nil(t, err)
+		}
+		return ids, snapshotTime
+	}
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	ids, first := query(0)
+	require.Equal(t, []string{t1.Id}, ids)
+
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	ids, snapshotTime := query(time.Hour)
+	require.Equal(t, []string{t1.Id}, ids)
+	require.Equal(t, first, snapshotTime)
+
+	stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+	require.Nil(t, err)
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Len(t, resp.Tickets, 2)
+	snapshotTime, err = ptypes.Timestamp(resp.SnapshotTime)
+	require.Nil(t, err)
+	require.True(t, snapshotTime.After(first))
+	require.ElementsMatch(t, []string{t1.Id, t2.Id}, []string{resp.Tickets[0].Id, resp.Tickets[1].Id})
+
+	stream, err = om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{
+		Pool:         &pb.Pool{},
+		MaxStaleness: ptypes.DurationProto(-time.Second),
+	})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	om := newOM(t)
+
+	ranked := &pb.SearchFields{Tags: []string{"ranked"}}
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{SearchFields: ranked}})
+	require.Nil(t, err)
+
+	stream, err := om.Query().WatchPool(ctx, &pb.WatchPoolRequest{
+		Pool: &pb.Pool{TagPresentFilters: []*pb.TagPresentFilter{{Tag: "ranked"}}},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Len(t, resp.Tickets, 1)
+	require.Equal(t, t1.Id, resp.Tickets[0].Id)
+	require.Empty(t, resp.RemovedIds)
+	require.True(t, resp.Complete)
+	require.NotNil(t, resp.SnapshotTime)
+
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{SearchFields: ranked}})
+	require.Nil(t, err)
+	_, err = om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	_, err = om.Frontend().DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: t1.Id})
+	require.Nil(t, err)
+
+	// The watch sees the changes once the ticket cache is next refreshed.
+	var added, removed []string
+	for len(added) < 1 || len(removed) < 1 {
+		resp, err = stream.Recv()
+		require.Nil(t, err)
+		for _, ticket := range resp.Tickets {
+			added = append(added, ticket.Id)
+		}
+		removed = append(removed, resp.RemovedIds...)
+	}
+	require.Equal(t, []string{t2.Id}, added)
+	require.Equal(t, []string{t1.Id}, removed)
+
+	stream, err = om.Query().WatchPool(ctx, &pb.WatchPoolRequest{})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestTicketFound(t *testing.T) {
 	for _, tc := range testcases.IncludedTestCases() {
 		tc := tc
@@ -149,6 +338,138 @@ func TestTicketNotFound(t *testing.T) {
 	}
 }
 
+func TestExplainTicket(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"skill": 15},
+			Tags:       []string{"beginner"},
+		},
+	}})
+	require.Nil(t, err)
+
+	profile := &pb.MatchProfile{
+		Name: "profile",
+		Pools: []*pb.Pool{
+			{
+				Name:               "skilled",
+				DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "skill", Min: 10, Max: 20}},
+			},
+			{
+				Name:             "experienced",
+				TagAbsentFilters: []*pb.TagAbsentFilter{{Tag: "beginner"}},
+			},
+		},
+	}
+
+	resp, err := om.Query().ExplainTicket(ctx, &pb.ExplainTicketRequest{TicketId: ticket.Id, Profile: profile})
+	require.Nil(t, err)
+	require.Equal(t, ticket.Id, resp.Ticket.Id)
+	require.Len(t, resp.Pools, 2)
+	require.Equal(t, "skilled", resp.Pools[0].PoolName)
+	require.True(t, resp.Pools[0].Included)
+	require.Empty(t, resp.Pools[0].ExcludedBy)
+	require.Equal(t, "experienced", resp.Pools[1].PoolName)
+	require.False(t, resp.Pools[1].Included)
+	require.Contains(t, resp.Pools[1].ExcludedBy, "tag is present")
+	require.False(t, resp.PendingRelease)
+	require.Nil(t, resp.PendingReleaseExpireTime)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{ticket},
+		}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: profile,
+	})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Nil(t, err)
+	matchedAt := time.Now()
+
+	resp, err = om.Query().ExplainTicket(ctx, &pb.ExplainTicketRequest{TicketId: ticket.Id, Profile: profile})
+	require.Nil(t, err)
+	require.True(t, resp.PendingRelease)
+	expireTime, err := ptypes.Timestamp(resp.PendingReleaseExpireTime)
+	require.Nil(t, err)
+	require.WithinDuration(t, matchedAt.Add(pendingReleaseTimeout), expireTime, pendingReleaseTimeout)
+
+	_, err = om.Query().ExplainTicket(ctx, &pb.ExplainTicketRequest{TicketId: "missing", Profile: profile})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = om.Query().ExplainTicket(ctx, &pb.ExplainTicketRequest{TicketId: ticket.Id})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetPoolStats(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	var first *pb.Ticket
+	for i := 0; i < 5; i++ {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+			SearchFields: &pb.SearchFields{
+				DoubleArgs: map[string]float64{"skill": float64(i * 10)},
+			},
+		}})
+		require.Nil(t, err)
+		if first == nil {
+			first = ticket
+		}
+	}
+	_, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	resp, err := om.Query().GetPoolStats(ctx, &pb.GetPoolStatsRequest{
+		Pools: []*pb.Pool{
+			{Name: "all"},
+			{
+				Name:               "skilled",
+				DoubleRangeFilters: []*pb.DoubleRangeFilter{{DoubleArg: "skill", Min: 15, Max: 100}},
+			},
+		},
+		Histograms: []*pb.HistogramRequest{{DoubleArg: "skill", Bounds: []float64{25}}},
+	})
+	require.Nil(t, err)
+	require.Len(t, resp.Pools, 2)
+
+	all := resp.Pools[0]
+	require.Equal(t, "all", all.PoolName)
+	require.Equal(t, int32(6), all.TicketCount)
+	require.True(t, proto.Equal(first.CreateTime, all.OldestCreateTime))
+	require.Len(t, all.Histograms, 1)
+	require.Equal(t, []int32{3, 2}, all.Histograms[0].Counts)
+	require.Equal(t, int32(1), all.Histograms[0].Missing)
+
+	skilled := resp.Pools[1]
+	require.Equal(t, "skilled", skilled.PoolName)
+	require.Equal(t, int32(3), skilled.TicketCount)
+	require.Equal(t, []int32{1, 2}, skilled.Histograms[0].Counts)
+	require.Equal(t, int32(0), skilled.Histograms[0].Missing)
+
+	_, err = om.Query().GetPoolStats(ctx, &pb.GetPoolStatsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = om.Query().GetPoolStats(ctx, &pb.GetPoolStatsRequest{
+		Pools:      []*pb.Pool{{Name: "all"}},
+		Histograms: []*pb.HistogramRequest{{DoubleArg: "skill", Bounds: []float64{2, 1}}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func returnedByQuery(t *testing.T, tc testcases.TestCase) (found bool) {
 	om := newOM(t)
 
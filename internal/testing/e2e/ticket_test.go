@@ -16,10 +16,12 @@ package e2e
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -307,6 +309,97 @@ func TestReleaseTickets(t *testing.T) {
 	require.True(t, time.Since(matchReturnedAt) < pendingReleaseTimeout, "%s", time.Since(matchReturnedAt))
 }
 
+// TestReleaseMatches covers that only the tickets of the released matches are
+// returned by query after calling ReleaseMatches.
+func TestReleaseMatches(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	tickets := make([]*pb.Ticket, 2)
+	for i := range tickets {
+		var err error
+		tickets[i], err = om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.Nil(t, err)
+	}
+
+	var matchReturnedAt time.Time
+	// Maps the match ids to the reservation tokens of their tickets.
+	tokens := make(map[string]string)
+
+	{ // Tickets returned from matches
+		om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+			for i, ticket := range tickets {
+				out <- &pb.Match{
+					MatchId: fmt.Sprint(i),
+					Tickets: []*pb.Ticket{ticket},
+				}
+			}
+			return nil
+		})
+		om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+			matches := []*pb.Match{}
+			for m := range in {
+				matches = append(matches, m)
+			}
+			matchReturnedAt = time.Now()
+			for _, m := range matches {
+				out <- m.MatchId
+			}
+			return nil
+		})
+
+		stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+			Config: om.MMFConfigGRPC(),
+			Profile: &pb.MatchProfile{
+				Name: "test-profile",
+				Pools: []*pb.Pool{
+					{Name: "pool"},
+				},
+			},
+		})
+		require.Nil(t, err)
+
+		for range tickets {
+			resp, err := stream.Recv()
+			require.Nil(t, err)
+			require.NotEmpty(t, resp.ReservationToken)
+			tokens[resp.Match.MatchId] = resp.ReservationToken
+		}
+		resp, err := stream.Recv()
+		require.Equal(t, io.EOF, err)
+		require.Nil(t, resp)
+	}
+
+	{ // Return the first match
+		resp, err := om.Backend().ReleaseMatches(ctx, &pb.ReleaseMatchesRequest{
+			ReservationTokens: []string{tokens["0"], "missing"},
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, &pb.ReleaseMatchesResponse{}, resp)
+	}
+
+	{ // First match's ticket present in query
+		queriedAt := time.Now()
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		ids := []string{}
+		for _, ticket := range resp.Tickets {
+			ids = append(ids, ticket.Id)
+		}
+		require.Contains(t, ids, tickets[0].Id)
+
+		// The second match's ticket is still pending, unless the release
+		// timeout has passed.
+		if queriedAt.Sub(matchReturnedAt) < pendingReleaseTimeout {
+			require.NotContains(t, ids, tickets[1].Id)
+		}
+	}
+}
+
 // TestReleaseAllTickets covers that tickets are released and returned by query
 // after calling ReleaseAllTickets.  Does test available fetch matches, not
 // after fetch matches, as that's covered by TestReleaseTickets.
@@ -515,6 +608,23 @@ func TestCreateTicketErrors(t *testing.T) {
 			},
 			"tickets cannot be created with create time set",
 		},
+		{
+			"already has expire time",
+			&pb.CreateTicketRequest{
+				Ticket: &pb.Ticket{
+					ExpireTime: ptypes.TimestampNow(),
+				},
+			},
+			"tickets cannot be created with expire time set, use .ttl instead",
+		},
+		{
+			"negative ttl",
+			&pb.CreateTicketRequest{
+				Ticket: &pb.Ticket{},
+				Ttl:    ptypes.DurationProto(-time.Second),
+			},
+			".ttl must be positive",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -598,3 +708,340 @@ func TestAssignedTicketDeleteTimeout(t *testing.T) {
 	require.Equal(t, codes.NotFound, status.Convert(err).Code())
 
 }
+
+// TestTicketExpires covers tickets created with a ttl no longer being returned
+// by query or get ticket once it elapses.
+func TestTicketExpires(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	returned := func() bool {
+		stream, err := om.Query().QueryTickets(context.Background(), &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+
+		_, err = stream.Recv()
+		return err != io.EOF
+	}
+
+	const ttl = 200 * time.Millisecond
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{
+		Ticket: &pb.Ticket{},
+		Ttl:    ptypes.DurationProto(ttl),
+	})
+	require.Nil(t, err)
+	require.NotNil(t, t1.ExpireTime)
+
+	require.True(t, returned())
+
+	// Expiry is checked against the wall clock, so really wait rather than
+	// advancing the ttl time.
+	time.Sleep(ttl)
+
+	require.False(t, returned())
+
+	// State storage expires the ticket itself on its own clock.
+	om.AdvanceTTLTime(ttl)
+	get, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: t1.Id})
+	require.Nil(t, get)
+	require.Equal(t, codes.NotFound, status.Convert(err).Code())
+}
+
+// TestStreamAssignments covers streaming the assignments of several tickets,
+// selected by id and by pool, over a single stream.
+func TestStreamAssignments(t *testing.T) {
+	om := newOM(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	invalid, err := om.Frontend().StreamAssignments(ctx, &pb.StreamAssignmentsRequest{})
+	require.Nil(t, err)
+	_, err = invalid.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+		SearchFields: &pb.SearchFields{Tags: []string{"lobby.1"}},
+	}})
+	require.Nil(t, err)
+
+	_, err = om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{t1.Id},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	stream, err := om.Frontend().StreamAssignments(ctx, &pb.StreamAssignmentsRequest{
+		TicketIds: []string{t1.Id},
+		Pool: &pb.Pool{
+			TagPresentFilters: []*pb.TagPresentFilter{{Tag: "lobby.1"}},
+		},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, t1.Id, resp.TicketId)
+	require.Equal(t, "a", resp.Assignment.Connection)
+
+	_, err = om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{t2.Id},
+				Assignment: &pb.Assignment{Connection: "b"},
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	resp, err = stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, t2.Id, resp.TicketId)
+	require.Equal(t, "b", resp.Assignment.Connection)
+}
+
+// TestUpdateTicket covers updating the search fields of a ticket, and that
+// query returns the updated ticket while it keeps its create time.
+func TestUpdateTicket(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	pool := &pb.Pool{
+		DoubleRangeFilters: []*pb.DoubleRangeFilter{
+			{DoubleArg: "skill", Min: 15, Max: 25},
+		},
+	}
+	query := func() []*pb.Ticket {
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: pool})
+		require.Nil(t, err)
+
+		var tickets []*pb.Ticket
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return tickets
+			}
+			require.Nil(t, err)
+			tickets = append(tickets, resp.Tickets...)
+		}
+	}
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"skill": 10},
+		},
+	}})
+	require.Nil(t, err)
+	require.Empty(t, query())
+
+	updated, err := om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{Ticket: &pb.Ticket{
+		Id: t1.Id,
+		SearchFields: &pb.SearchFields{
+			DoubleArgs: map[string]float64{"skill": 20},
+		},
+	}})
+	require.Nil(t, err)
+	require.Equal(t, t1.Id, updated.Id)
+	require.True(t, proto.Equal(t1.CreateTime, updated.CreateTime))
+
+	tickets := query()
+	require.Len(t, tickets, 1)
+	require.True(t, proto.Equal(updated, tickets[0]))
+
+	// Assigned tickets can't be updated.
+	_, err = om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				TicketIds:  []string{t1.Id},
+				Assignment: &pb.Assignment{Connection: "a"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	_, err = om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{Ticket: &pb.Ticket{Id: t1.Id}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{Ticket: &pb.Ticket{Id: "missing"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = om.Frontend().UpdateTicket(ctx, &pb.UpdateTicketRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// TestTicketBatches covers creating and deleting several tickets in one call,
+// with invalid tickets reported as failures.
+func TestTicketBatches(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	created, err := om.Frontend().CreateTickets(ctx, &pb.CreateTicketsRequest{
+		Tickets: []*pb.CreateTicketRequest{
+			{Ticket: &pb.Ticket{SearchFields: &pb.SearchFields{Tags: []string{"a"}}}},
+			{Ticket: &pb.Ticket{Assignment: &pb.Assignment{}}},
+			{Ticket: &pb.Ticket{SearchFields: &pb.SearchFields{Tags: []string{"b"}}}},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, created.Tickets, 2)
+	require.Equal(t, []string{"a"}, created.Tickets[0].SearchFields.Tags)
+	require.Equal(t, []string{"b"}, created.Tickets[1].SearchFields.Tags)
+	require.Len(t, created.Failures, 1)
+	require.Equal(t, int32(1), created.Failures[0].Index)
+	require.Equal(t, int32(codes.InvalidArgument), created.Failures[0].Status.Code)
+
+	for _, ticket := range created.Tickets {
+		get, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: ticket.Id})
+		require.Nil(t, err)
+		require.True(t, proto.Equal(ticket, get))
+	}
+
+	deleted, err := om.Frontend().DeleteTickets(ctx, &pb.DeleteTicketsRequest{
+		TicketIds: []string{created.Tickets[0].Id, "", created.Tickets[1].Id},
+	})
+	require.Nil(t, err)
+	require.Len(t, deleted.Failures, 1)
+	require.Equal(t, int32(codes.InvalidArgument), deleted.Failures[0].Status.Code)
+
+	stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+// TestCreateTicketIdempotencyKey covers repeated create ticket requests with
+// the same idempotency key returning the first ticket created, unless the
+// request has changed.
+func TestCreateTicketIdempotencyKey(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	create := func(key string) *pb.Ticket {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{
+			Ticket:         &pb.Ticket{},
+			IdempotencyKey: key,
+		})
+		require.Nil(t, err)
+		return ticket
+	}
+
+	t1 := create("a")
+	require.True(t, proto.Equal(t1, create("a")))
+	require.NotEqual(t, t1.Id, create("b").Id)
+	require.NotEqual(t, create("").Id, create("").Id)
+
+	created, err := om.Frontend().CreateTickets(ctx, &pb.CreateTicketsRequest{
+		Tickets: []*pb.CreateTicketRequest{
+			{Ticket: &pb.Ticket{}, IdempotencyKey: "a"},
+			{Ticket: &pb.Ticket{}, IdempotencyKey: "c"},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, created.Tickets, 2)
+	require.True(t, proto.Equal(t1, created.Tickets[0]))
+	require.True(t, proto.Equal(created.Tickets[1], create("c")))
+
+	// Repeating a key with a different ticket fails.
+	_, err = om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{
+		Ticket:         &pb.Ticket{SearchFields: &pb.SearchFields{Tags: []string{"changed"}}},
+		IdempotencyKey: "a",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Only one ticket was created for each key.
+	stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: &pb.Pool{}})
+	require.Nil(t, err)
+	count := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		count += len(resp.Ids)
+	}
+	require.Equal(t, 5, count)
+}
+
+// TestAssignTicketsForMatch covers assignments which give a reservation token
+// the tickets are not reserved under being refused.
+func TestAssignTicketsForMatch(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	tickets := make([]*pb.Ticket, 2)
+	for i := range tickets {
+		var err error
+		tickets[i], err = om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		require.Nil(t, err)
+	}
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{tickets[0]},
+		}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config: om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{
+			Name: "test-profile",
+			Pools: []*pb.Pool{
+				{Name: "pool"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "1", resp.Match.MatchId)
+	require.NotEmpty(t, resp.ReservationToken)
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	assignResp, err := om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{
+			{
+				// Reserved under a different token.
+				TicketIds:        []string{tickets[0].Id},
+				Assignment:       &pb.Assignment{Connection: "a"},
+				ReservationToken: "other",
+			},
+			{
+				// Never reserved.
+				TicketIds:        []string{tickets[1].Id},
+				Assignment:       &pb.Assignment{Connection: "b"},
+				ReservationToken: resp.ReservationToken,
+			},
+		},
+	})
+	require.Nil(t, err)
+	require.Len(t, assignResp.Failures, 2)
+	for _, f := range assignResp.Failures {
+		require.Equal(t, pb.AssignmentFailure_RESERVATION_LOST, f.Cause)
+	}
+
+	for _, ticket := range tickets {
+		got, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: ticket.Id})
+		require.Nil(t, err)
+		require.Nil(t, got.Assignment)
+	}
+
+	// Refused tickets stay indexed, and are returned by query once released.
+	time.Sleep(pendingReleaseTimeout)
+	queryStream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+	require.Nil(t, err)
+	queryResp, err := queryStream.Recv()
+	require.Nil(t, err)
+	require.Len(t, queryResp.Tickets, 2)
+}
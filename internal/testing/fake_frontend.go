@@ -37,6 +37,11 @@ func (s *FakeFrontend) CreateTicket(ctx context.Context, req *pb.CreateTicketReq
 	return &pb.Ticket{}, nil
 }
 
+// CreateTickets creates multiple tickets in a single call.
+func (s *FakeFrontend) CreateTickets(ctx context.Context, req *pb.CreateTicketsRequest) (*pb.CreateTicketsResponse, error) {
+	return &pb.CreateTicketsResponse{}, nil
+}
+
 // DeleteTicket removes the Ticket from state storage and from corresponding
 // configured indices. Deleting the ticket stops the ticket from being
 // considered for future matchmaking requests.
@@ -44,6 +49,16 @@ func (s *FakeFrontend) DeleteTicket(ctx context.Context, req *pb.DeleteTicketReq
 	return nil, status.Error(codes.Unimplemented, "not implemented")
 }
 
+// DeleteTickets removes multiple tickets in a single call.
+func (s *FakeFrontend) DeleteTickets(ctx context.Context, req *pb.DeleteTicketsRequest) (*pb.DeleteTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// UpdateTicket updates the search fields and extensions of a ticket.
+func (s *FakeFrontend) UpdateTicket(ctx context.Context, req *pb.UpdateTicketRequest) (*pb.Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
 // GetTicket fetches the ticket associated with the specified Ticket id.
 func (s *FakeFrontend) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented")
@@ -54,3 +69,29 @@ func (s *FakeFrontend) GetTicket(ctx context.Context, req *pb.GetTicketRequest)
 func (s *FakeFrontend) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
 	return status.Error(codes.Unimplemented, "not implemented")
 }
+
+// StreamAssignments streams matchmaking results from Open Match for the
+// provided Ticket ids and Pool.
+func (s *FakeFrontend) StreamAssignments(req *pb.StreamAssignmentsRequest, stream pb.FrontendService_StreamAssignmentsServer) error {
+	return status.Error(codes.Unimplemented, "not implemented")
+}
+
+// CreateBackfill creates a new backfill and puts it in state storage.
+func (s *FakeFrontend) CreateBackfill(ctx context.Context, req *pb.CreateBackfillRequest) (*pb.Backfill, error) {
+	return &pb.Backfill{}, nil
+}
+
+// UpdateBackfill updates the search fields and extensions of a backfill.
+func (s *FakeFrontend) UpdateBackfill(ctx context.Context, req *pb.UpdateBackfillRequest) (*pb.Backfill, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// DeleteBackfill removes the Backfill from state storage and from the index.
+func (s *FakeFrontend) DeleteBackfill(ctx context.Context, req *pb.DeleteBackfillRequest) (*empty.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// GetBackfill fetches the backfill associated with the specified Backfill id.
+func (s *FakeFrontend) GetBackfill(ctx context.Context, req *pb.GetBackfillRequest) (*pb.Backfill, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
@@ -7,11 +7,13 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	status1 "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -56,16 +58,21 @@ type AssignmentFailure_Cause int32
 const (
 	AssignmentFailure_UNKNOWN          AssignmentFailure_Cause = 0
 	AssignmentFailure_TICKET_NOT_FOUND AssignmentFailure_Cause = 1
+	// The Ticket's group named a reservation token, and the Tickets are no
+	// longer pending release for its match.
+	AssignmentFailure_RESERVATION_LOST AssignmentFailure_Cause = 2
 )
 
 var AssignmentFailure_Cause_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "TICKET_NOT_FOUND",
+	2: "RESERVATION_LOST",
 }
 
 var AssignmentFailure_Cause_value = map[string]int32{
 	"UNKNOWN":          0,
 	"TICKET_NOT_FOUND": 1,
+	"RESERVATION_LOST": 2,
 }
 
 func (x AssignmentFailure_Cause) String() string {
@@ -73,7 +80,7 @@ func (x AssignmentFailure_Cause) String() string {
 }
 
 func (AssignmentFailure_Cause) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{8, 0}
+	return fileDescriptor_8dab762378f455cd, []int{13, 0}
 }
 
 // FunctionConfig specifies a MMF address and client type for Backend to establish connections with the MMF
@@ -134,12 +141,24 @@ func (m *FunctionConfig) GetType() FunctionConfig_Type {
 
 type FetchMatchesRequest struct {
 	// A configuration for the MatchFunction server of this FetchMatches call.
+	// Exactly one of config and function_name must be set.
 	Config *FunctionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 	// A MatchProfile that will be sent to the MatchFunction server of this FetchMatches call.
-	Profile              *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// If true, the MatchFunction's proposals are evaluated, but no tickets are
+	// moved to pending and no backfills are written.  Every proposal is
+	// returned, with the evaluator's decision in dry_run_result.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// The name of a MatchFunction server configured in Open Match's
+	// matchFunctions registry, to call instead of the server given by config.
+	FunctionName string `protobuf:"bytes,4,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// Optional time the MatchFunction may run for.  Once it passes, the
+	// MatchFunction is canceled, the proposals it has already returned are
+	// still evaluated, and the call ends with DEADLINE_EXCEEDED.
+	FunctionTimeout      *duration.Duration `protobuf:"bytes,5,opt,name=function_timeout,json=functionTimeout,proto3" json:"function_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *FetchMatchesRequest) Reset()         { *m = FetchMatchesRequest{} }
@@ -181,10 +200,90 @@ func (m *FetchMatchesRequest) GetProfile() *MatchProfile {
 	return nil
 }
 
+func (m *FetchMatchesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *FetchMatchesRequest) GetFunctionName() string {
+	if m != nil {
+		return m.FunctionName
+	}
+	return ""
+}
+
+func (m *FetchMatchesRequest) GetFunctionTimeout() *duration.Duration {
+	if m != nil {
+		return m.FunctionTimeout
+	}
+	return nil
+}
+
+// DryRunResult is the evaluator's decision on a proposal from a dry run
+// FetchMatches call.
+type DryRunResult struct {
+	// Whether the evaluator accepted the proposal.
+	Accepted bool `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// If the proposal was rejected and shares a ticket or backfill with an
+	// accepted proposal, the match id of that accepted proposal.
+	CollidedMatchId      string   `protobuf:"bytes,2,opt,name=collided_match_id,json=collidedMatchId,proto3" json:"collided_match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DryRunResult) Reset()         { *m = DryRunResult{} }
+func (m *DryRunResult) String() string { return proto.CompactTextString(m) }
+func (*DryRunResult) ProtoMessage()    {}
+func (*DryRunResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{2}
+}
+
+func (m *DryRunResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DryRunResult.Unmarshal(m, b)
+}
+func (m *DryRunResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DryRunResult.Marshal(b, m, deterministic)
+}
+func (m *DryRunResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunResult.Merge(m, src)
+}
+func (m *DryRunResult) XXX_Size() int {
+	return xxx_messageInfo_DryRunResult.Size(m)
+}
+func (m *DryRunResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunResult proto.InternalMessageInfo
+
+func (m *DryRunResult) GetAccepted() bool {
+	if m != nil {
+		return m.Accepted
+	}
+	return false
+}
+
+func (m *DryRunResult) GetCollidedMatchId() string {
+	if m != nil {
+		return m.CollidedMatchId
+	}
+	return ""
+}
+
 type FetchMatchesResponse struct {
 	// A Match generated by the user-defined MMF with the specified MatchProfiles.
 	// A valid Match response will contain at least one ticket.
-	Match                *Match   `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// Set only for dry run calls.
+	DryRunResult *DryRunResult `protobuf:"bytes,2,opt,name=dry_run_result,json=dryRunResult,proto3" json:"dry_run_result,omitempty"`
+	// Identifies the reservation of the match's Tickets, which are pending
+	// release for this match alone. Unlike match ids, which are chosen by the
+	// MatchFunction, reservation tokens are unique. Empty if the Tickets could
+	// not be reserved, and for dry run calls.
+	ReservationToken     string   `protobuf:"bytes,3,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -194,7 +293,7 @@ func (m *FetchMatchesResponse) Reset()         { *m = FetchMatchesResponse{} }
 func (m *FetchMatchesResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchMatchesResponse) ProtoMessage()    {}
 func (*FetchMatchesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{2}
+	return fileDescriptor_8dab762378f455cd, []int{3}
 }
 
 func (m *FetchMatchesResponse) XXX_Unmarshal(b []byte) error {
@@ -222,6 +321,141 @@ func (m *FetchMatchesResponse) GetMatch() *Match {
 	return nil
 }
 
+func (m *FetchMatchesResponse) GetDryRunResult() *DryRunResult {
+	if m != nil {
+		return m.DryRunResult
+	}
+	return nil
+}
+
+func (m *FetchMatchesResponse) GetReservationToken() string {
+	if m != nil {
+		return m.ReservationToken
+	}
+	return ""
+}
+
+type FetchMatchesBatchRequest struct {
+	// The profiles to fetch matches for, each in the same form as a
+	// FetchMatchesRequest, along with the MatchFunction to call for it.  Dry
+	// runs are not supported.
+	Requests             []*FetchMatchesRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *FetchMatchesBatchRequest) Reset()         { *m = FetchMatchesBatchRequest{} }
+func (m *FetchMatchesBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchMatchesBatchRequest) ProtoMessage()    {}
+func (*FetchMatchesBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{4}
+}
+
+func (m *FetchMatchesBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchMatchesBatchRequest.Unmarshal(m, b)
+}
+func (m *FetchMatchesBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchMatchesBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchMatchesBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchMatchesBatchRequest.Merge(m, src)
+}
+func (m *FetchMatchesBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchMatchesBatchRequest.Size(m)
+}
+func (m *FetchMatchesBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchMatchesBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchMatchesBatchRequest proto.InternalMessageInfo
+
+func (m *FetchMatchesBatchRequest) GetRequests() []*FetchMatchesRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type FetchMatchesBatchResponse struct {
+	// The index of the FetchMatchesRequest in FetchMatchesBatchRequest.requests
+	// this response is for.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The name of that request's MatchProfile.
+	ProfileName string `protobuf:"bytes,2,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
+	// A Match generated by the request's MatchFunction, and accepted by the
+	// evaluator.
+	Match *Match `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	// Set instead of match if the request's MatchFunction failed, after the
+	// matches it returned.  The status codes are the same as FetchMatches.
+	Status *status.Status `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// The reservation of the match's Tickets, as in FetchMatchesResponse.
+	ReservationToken     string   `protobuf:"bytes,5,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchMatchesBatchResponse) Reset()         { *m = FetchMatchesBatchResponse{} }
+func (m *FetchMatchesBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchMatchesBatchResponse) ProtoMessage()    {}
+func (*FetchMatchesBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{5}
+}
+
+func (m *FetchMatchesBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchMatchesBatchResponse.Unmarshal(m, b)
+}
+func (m *FetchMatchesBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchMatchesBatchResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchMatchesBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchMatchesBatchResponse.Merge(m, src)
+}
+func (m *FetchMatchesBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchMatchesBatchResponse.Size(m)
+}
+func (m *FetchMatchesBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchMatchesBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchMatchesBatchResponse proto.InternalMessageInfo
+
+func (m *FetchMatchesBatchResponse) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *FetchMatchesBatchResponse) GetProfileName() string {
+	if m != nil {
+		return m.ProfileName
+	}
+	return ""
+}
+
+func (m *FetchMatchesBatchResponse) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (m *FetchMatchesBatchResponse) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *FetchMatchesBatchResponse) GetReservationToken() string {
+	if m != nil {
+		return m.ReservationToken
+	}
+	return ""
+}
+
 type ReleaseTicketsRequest struct {
 	// TicketIds is a list of string representing Open Match generated Ids to be re-enabled for MMF querying
 	// because they are no longer awaiting assignment from a previous match result
@@ -235,7 +469,7 @@ func (m *ReleaseTicketsRequest) Reset()         { *m = ReleaseTicketsRequest{} }
 func (m *ReleaseTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseTicketsRequest) ProtoMessage()    {}
 func (*ReleaseTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{3}
+	return fileDescriptor_8dab762378f455cd, []int{6}
 }
 
 func (m *ReleaseTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -273,7 +507,7 @@ func (m *ReleaseTicketsResponse) Reset()         { *m = ReleaseTicketsResponse{}
 func (m *ReleaseTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseTicketsResponse) ProtoMessage()    {}
 func (*ReleaseTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{4}
+	return fileDescriptor_8dab762378f455cd, []int{7}
 }
 
 func (m *ReleaseTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -304,7 +538,7 @@ func (m *ReleaseAllTicketsRequest) Reset()         { *m = ReleaseAllTicketsReque
 func (m *ReleaseAllTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseAllTicketsRequest) ProtoMessage()    {}
 func (*ReleaseAllTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{5}
+	return fileDescriptor_8dab762378f455cd, []int{8}
 }
 
 func (m *ReleaseAllTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -335,7 +569,7 @@ func (m *ReleaseAllTicketsResponse) Reset()         { *m = ReleaseAllTicketsResp
 func (m *ReleaseAllTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseAllTicketsResponse) ProtoMessage()    {}
 func (*ReleaseAllTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{6}
+	return fileDescriptor_8dab762378f455cd, []int{9}
 }
 
 func (m *ReleaseAllTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -356,22 +590,99 @@ func (m *ReleaseAllTicketsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReleaseAllTicketsResponse proto.InternalMessageInfo
 
+type ReleaseMatchesRequest struct {
+	// ReservationTokens is a list of the reservation tokens of matches returned
+	// by FetchMatches, whose Tickets are to be re-enabled for MMF querying.
+	ReservationTokens    []string `protobuf:"bytes,1,rep,name=reservation_tokens,json=reservationTokens,proto3" json:"reservation_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseMatchesRequest) Reset()         { *m = ReleaseMatchesRequest{} }
+func (m *ReleaseMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseMatchesRequest) ProtoMessage()    {}
+func (*ReleaseMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{10}
+}
+
+func (m *ReleaseMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseMatchesRequest.Unmarshal(m, b)
+}
+func (m *ReleaseMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseMatchesRequest.Merge(m, src)
+}
+func (m *ReleaseMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseMatchesRequest.Size(m)
+}
+func (m *ReleaseMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseMatchesRequest proto.InternalMessageInfo
+
+func (m *ReleaseMatchesRequest) GetReservationTokens() []string {
+	if m != nil {
+		return m.ReservationTokens
+	}
+	return nil
+}
+
+type ReleaseMatchesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseMatchesResponse) Reset()         { *m = ReleaseMatchesResponse{} }
+func (m *ReleaseMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseMatchesResponse) ProtoMessage()    {}
+func (*ReleaseMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{11}
+}
+
+func (m *ReleaseMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseMatchesResponse.Unmarshal(m, b)
+}
+func (m *ReleaseMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseMatchesResponse.Merge(m, src)
+}
+func (m *ReleaseMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseMatchesResponse.Size(m)
+}
+func (m *ReleaseMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseMatchesResponse proto.InternalMessageInfo
+
 // AssignmentGroup contains an Assignment and the Tickets to which it should be applied.
 type AssignmentGroup struct {
 	// TicketIds is a list of strings representing Open Match generated Ids which apply to an Assignment.
 	TicketIds []string `protobuf:"bytes,1,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
 	// An Assignment specifies game connection related information to be associated with the TicketIds.
-	Assignment           *Assignment `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Assignment *Assignment `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	// Optional reservation token of the match returned by FetchMatches which
+	// the Tickets were proposed in. When set, the Tickets are only assigned
+	// while all of them are still pending release for that match, otherwise
+	// every Ticket in the group fails with RESERVATION_LOST.
+	ReservationToken     string   `protobuf:"bytes,3,opt,name=reservation_token,json=reservationToken,proto3" json:"reservation_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AssignmentGroup) Reset()         { *m = AssignmentGroup{} }
 func (m *AssignmentGroup) String() string { return proto.CompactTextString(m) }
 func (*AssignmentGroup) ProtoMessage()    {}
 func (*AssignmentGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{7}
+	return fileDescriptor_8dab762378f455cd, []int{12}
 }
 
 func (m *AssignmentGroup) XXX_Unmarshal(b []byte) error {
@@ -406,6 +717,13 @@ func (m *AssignmentGroup) GetAssignment() *Assignment {
 	return nil
 }
 
+func (m *AssignmentGroup) GetReservationToken() string {
+	if m != nil {
+		return m.ReservationToken
+	}
+	return ""
+}
+
 // AssignmentFailure contains the id of the Ticket that failed the Assignment and the failure status.
 type AssignmentFailure struct {
 	TicketId             string                  `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
@@ -419,7 +737,7 @@ func (m *AssignmentFailure) Reset()         { *m = AssignmentFailure{} }
 func (m *AssignmentFailure) String() string { return proto.CompactTextString(m) }
 func (*AssignmentFailure) ProtoMessage()    {}
 func (*AssignmentFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{8}
+	return fileDescriptor_8dab762378f455cd, []int{13}
 }
 
 func (m *AssignmentFailure) XXX_Unmarshal(b []byte) error {
@@ -466,7 +784,7 @@ func (m *AssignTicketsRequest) Reset()         { *m = AssignTicketsRequest{} }
 func (m *AssignTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*AssignTicketsRequest) ProtoMessage()    {}
 func (*AssignTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{9}
+	return fileDescriptor_8dab762378f455cd, []int{14}
 }
 
 func (m *AssignTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -506,7 +824,7 @@ func (m *AssignTicketsResponse) Reset()         { *m = AssignTicketsResponse{} }
 func (m *AssignTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*AssignTicketsResponse) ProtoMessage()    {}
 func (*AssignTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{10}
+	return fileDescriptor_8dab762378f455cd, []int{15}
 }
 
 func (m *AssignTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -539,11 +857,16 @@ func init() {
 	proto.RegisterEnum("openmatch.AssignmentFailure_Cause", AssignmentFailure_Cause_name, AssignmentFailure_Cause_value)
 	proto.RegisterType((*FunctionConfig)(nil), "openmatch.FunctionConfig")
 	proto.RegisterType((*FetchMatchesRequest)(nil), "openmatch.FetchMatchesRequest")
+	proto.RegisterType((*DryRunResult)(nil), "openmatch.DryRunResult")
 	proto.RegisterType((*FetchMatchesResponse)(nil), "openmatch.FetchMatchesResponse")
+	proto.RegisterType((*FetchMatchesBatchRequest)(nil), "openmatch.FetchMatchesBatchRequest")
+	proto.RegisterType((*FetchMatchesBatchResponse)(nil), "openmatch.FetchMatchesBatchResponse")
 	proto.RegisterType((*ReleaseTicketsRequest)(nil), "openmatch.ReleaseTicketsRequest")
 	proto.RegisterType((*ReleaseTicketsResponse)(nil), "openmatch.ReleaseTicketsResponse")
 	proto.RegisterType((*ReleaseAllTicketsRequest)(nil), "openmatch.ReleaseAllTicketsRequest")
 	proto.RegisterType((*ReleaseAllTicketsResponse)(nil), "openmatch.ReleaseAllTicketsResponse")
+	proto.RegisterType((*ReleaseMatchesRequest)(nil), "openmatch.ReleaseMatchesRequest")
+	proto.RegisterType((*ReleaseMatchesResponse)(nil), "openmatch.ReleaseMatchesResponse")
 	proto.RegisterType((*AssignmentGroup)(nil), "openmatch.AssignmentGroup")
 	proto.RegisterType((*AssignmentFailure)(nil), "openmatch.AssignmentFailure")
 	proto.RegisterType((*AssignTicketsRequest)(nil), "openmatch.AssignTicketsRequest")
@@ -553,66 +876,88 @@ func init() {
 func init() { proto.RegisterFile("api/backend.proto", fileDescriptor_8dab762378f455cd) }
 
 var fileDescriptor_8dab762378f455cd = []byte{
-	// 929 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xee, 0x3a, 0xce, 0x8f, 0x4f, 0xc0, 0x38, 0x43, 0x52, 0xdc, 0x6d, 0xa1, 0x9b, 0x29, 0x2d,
-	0x91, 0xa9, 0xbd, 0xce, 0x12, 0x50, 0x65, 0x7e, 0xd4, 0xd4, 0x4d, 0xaa, 0xa8, 0xc5, 0x29, 0x1b,
-	0x17, 0x24, 0x6e, 0xa2, 0xf5, 0xfa, 0x78, 0xbd, 0x64, 0xbd, 0xb3, 0xec, 0xcc, 0xa6, 0x54, 0x48,
-	0x08, 0x21, 0x2e, 0x10, 0x57, 0x08, 0x24, 0x2e, 0x78, 0x04, 0x24, 0x2e, 0x78, 0x16, 0x6e, 0x78,
-	0x00, 0x1e, 0x04, 0xed, 0xcc, 0xfa, 0xdf, 0x49, 0xaf, 0xbc, 0x33, 0xe7, 0x3b, 0xdf, 0xf7, 0xcd,
-	0x99, 0x73, 0x3c, 0xb0, 0xe1, 0x44, 0xbe, 0xd9, 0x71, 0xdc, 0x33, 0x0c, 0xbb, 0xb5, 0x28, 0x66,
-	0x82, 0x91, 0x02, 0x8b, 0x30, 0x1c, 0x38, 0xc2, 0xed, 0xeb, 0x24, 0x8d, 0x0e, 0x90, 0x73, 0xc7,
-	0x43, 0xae, 0xc2, 0xfa, 0x0d, 0x8f, 0x31, 0x2f, 0x40, 0x33, 0x0d, 0x39, 0x61, 0xc8, 0x84, 0x23,
-	0x7c, 0x16, 0x0e, 0xa3, 0x77, 0xe5, 0x8f, 0x5b, 0xf5, 0x30, 0xac, 0xf2, 0xe7, 0x8e, 0xe7, 0x61,
-	0x6c, 0xb2, 0x48, 0x22, 0xe6, 0xd1, 0xf4, 0x27, 0x0d, 0x8a, 0x87, 0x49, 0xe8, 0xa6, 0x7b, 0x4d,
-	0x16, 0xf6, 0x7c, 0x8f, 0x10, 0xc8, 0xf7, 0x19, 0x17, 0x65, 0xcd, 0xd0, 0x76, 0x0a, 0xb6, 0xfc,
-	0x4e, 0xf7, 0x22, 0x16, 0x8b, 0x72, 0xce, 0xd0, 0x76, 0x96, 0x6d, 0xf9, 0x4d, 0x2c, 0xc8, 0x8b,
-	0x17, 0x11, 0x96, 0x97, 0x0c, 0x6d, 0xa7, 0x68, 0xbd, 0x55, 0x1b, 0x99, 0xae, 0x4d, 0x13, 0xd6,
-	0xda, 0x2f, 0x22, 0xb4, 0x25, 0x96, 0xea, 0x90, 0x4f, 0x57, 0x64, 0x0d, 0xf2, 0x8f, 0xec, 0xa7,
-	0xcd, 0xd2, 0x95, 0xf4, 0xcb, 0x3e, 0x38, 0x69, 0x97, 0x34, 0xfa, 0x2d, 0xbc, 0x7e, 0x88, 0xc2,
-	0xed, 0x7f, 0x9a, 0x72, 0x20, 0xb7, 0xf1, 0xeb, 0x04, 0xb9, 0x20, 0xbb, 0xb0, 0xe2, 0x4a, 0x1e,
-	0x69, 0x68, 0xdd, 0xba, 0x76, 0xa1, 0x90, 0x9d, 0x01, 0xc9, 0x2e, 0xac, 0x46, 0x31, 0xeb, 0xf9,
-	0x01, 0x4a, 0xc3, 0xeb, 0xd6, 0x1b, 0x13, 0x39, 0x92, 0xfe, 0xa9, 0x0a, 0xdb, 0x43, 0x1c, 0xfd,
-	0x04, 0x36, 0xa7, 0xc5, 0x79, 0xc4, 0x42, 0x8e, 0xe4, 0x0e, 0x2c, 0xcb, 0xb4, 0x4c, 0xbc, 0x34,
-	0x4b, 0x64, 0xab, 0x30, 0xfd, 0x00, 0xb6, 0x6c, 0x0c, 0xd0, 0xe1, 0xd8, 0xf6, 0xdd, 0x33, 0x14,
-	0x23, 0xfb, 0x6f, 0x02, 0x08, 0xb9, 0x73, 0xea, 0x77, 0x79, 0x59, 0x33, 0x96, 0x76, 0x0a, 0x76,
-	0x41, 0xed, 0x1c, 0x75, 0x39, 0x2d, 0xc3, 0xd5, 0xd9, 0x3c, 0xa5, 0x4c, 0x75, 0x28, 0x67, 0x91,
-	0xfd, 0x20, 0x98, 0x26, 0xa5, 0xd7, 0xe1, 0xda, 0x82, 0x58, 0x96, 0xe8, 0xc1, 0x6b, 0xfb, 0x9c,
-	0xfb, 0x5e, 0x38, 0xc0, 0x50, 0x3c, 0x8a, 0x59, 0x12, 0xbd, 0xc4, 0x04, 0x79, 0x1f, 0xc0, 0x19,
-	0x65, 0x64, 0x25, 0xdb, 0x9a, 0x38, 0xe9, 0x98, 0xce, 0x9e, 0x00, 0xd2, 0xdf, 0x35, 0xd8, 0x18,
-	0x87, 0x0e, 0x1d, 0x3f, 0x48, 0x62, 0x24, 0xd7, 0xa1, 0x30, 0xd2, 0xca, 0x7a, 0x68, 0x6d, 0x28,
-	0x45, 0xee, 0xc1, 0xb2, 0xeb, 0x24, 0x5c, 0xdd, 0x4b, 0xd1, 0xa2, 0x0b, 0x45, 0x32, 0xa6, 0x5a,
-	0x33, 0x45, 0xda, 0x2a, 0x81, 0x56, 0x60, 0x59, 0xae, 0xc9, 0x3a, 0xac, 0x3e, 0x6b, 0x3d, 0x6e,
-	0x1d, 0x7f, 0xd1, 0x2a, 0x5d, 0x21, 0x9b, 0x50, 0x6a, 0x1f, 0x35, 0x1f, 0x1f, 0xb4, 0x4f, 0x5b,
-	0xc7, 0xed, 0xd3, 0xc3, 0xe3, 0x67, 0xad, 0x87, 0x25, 0x8d, 0xb6, 0x61, 0x53, 0xb1, 0xcd, 0xdc,
-	0xc5, 0x47, 0xb0, 0x3e, 0xb6, 0xaf, 0xea, 0xb0, 0x6e, 0xe9, 0x0b, 0x3d, 0xc8, 0xba, 0xd9, 0x93,
-	0x70, 0xfa, 0x19, 0x6c, 0xcd, 0xb0, 0x66, 0x3d, 0x72, 0x0f, 0xd6, 0x7a, 0xca, 0xf2, 0x90, 0xf3,
-	0xc6, 0x65, 0xe7, 0xb2, 0x47, 0x68, 0xeb, 0xaf, 0x3c, 0x14, 0x1f, 0xa8, 0xd1, 0x3f, 0xc1, 0xf8,
-	0xdc, 0x77, 0x91, 0x7c, 0x07, 0xaf, 0x4c, 0x36, 0x22, 0x99, 0x9a, 0xab, 0xf9, 0xf1, 0xd0, 0x6f,
-	0x5e, 0x18, 0xcf, 0xda, 0xe1, 0xdd, 0x1f, 0xfe, 0xf9, 0xef, 0xb7, 0xdc, 0x6d, 0x6a, 0x98, 0xe7,
-	0xbb, 0xc3, 0xff, 0x19, 0xae, 0xc4, 0xcc, 0x81, 0xc2, 0x36, 0x7a, 0x69, 0x62, 0x43, 0xab, 0xd4,
-	0x35, 0xf2, 0xbd, 0x06, 0xaf, 0x4e, 0x1d, 0x93, 0xdc, 0x9c, 0x3b, 0xcc, 0x74, 0x59, 0x75, 0xe3,
-	0x62, 0x40, 0xe6, 0xe1, 0xae, 0xf4, 0x70, 0x87, 0x6e, 0x2f, 0xf0, 0xa0, 0x7a, 0x83, 0x37, 0x54,
-	0xa9, 0x1b, 0x5a, 0x85, 0xfc, 0xa8, 0x41, 0x71, 0x7a, 0x28, 0xc8, 0xa4, 0xc4, 0xc2, 0x39, 0xd3,
-	0xb7, 0x2f, 0x41, 0x64, 0x2e, 0xaa, 0xd2, 0xc5, 0x3b, 0x94, 0x5e, 0xe2, 0x22, 0x56, 0xa9, 0xa9,
-	0x8d, 0x5f, 0x34, 0xd8, 0x98, 0x9b, 0x32, 0x72, 0x6b, 0x5e, 0x67, 0x6e, 0x3e, 0xf5, 0xb7, 0x2f,
-	0x07, 0x65, 0x7e, 0xea, 0xd2, 0x4f, 0x85, 0xde, 0x7e, 0xb9, 0x1f, 0x27, 0x08, 0x1a, 0x5a, 0xe5,
-	0xc1, 0xcf, 0x4b, 0xbf, 0xee, 0xff, 0x9b, 0x23, 0x7f, 0x6b, 0xb0, 0x9a, 0xb5, 0x0d, 0x3d, 0x02,
-	0x38, 0x8e, 0x30, 0x34, 0xe4, 0xb5, 0x93, 0xab, 0x7d, 0x21, 0x22, 0xde, 0x30, 0xcd, 0x54, 0xbf,
-	0xaa, 0x0c, 0x74, 0xf1, 0x5c, 0xbf, 0x35, 0x5e, 0x57, 0xbb, 0x3e, 0x77, 0x13, 0xce, 0xef, 0xab,
-	0x57, 0xc4, 0x4b, 0x1b, 0x9d, 0xd7, 0x5c, 0x36, 0xa8, 0x7c, 0x0e, 0x64, 0x3f, 0x72, 0xdc, 0x3e,
-	0x1a, 0x56, 0xad, 0x6e, 0x3c, 0xf1, 0x5d, 0x4c, 0xbb, 0xfb, 0xfe, 0x90, 0xd2, 0xf3, 0x45, 0x3f,
-	0xe9, 0xa4, 0x48, 0x53, 0xa5, 0xf6, 0x58, 0xec, 0x39, 0x03, 0xe4, 0x13, 0x62, 0x66, 0x27, 0x60,
-	0x1d, 0x73, 0xe0, 0x70, 0x81, 0xb1, 0xf9, 0xe4, 0xa8, 0x79, 0xd0, 0x3a, 0x39, 0xb0, 0x96, 0x76,
-	0x6b, 0xf5, 0x4a, 0x4e, 0xcb, 0x59, 0x25, 0x27, 0x8a, 0x02, 0xdf, 0x95, 0x0f, 0x90, 0xf9, 0x15,
-	0x67, 0x61, 0x63, 0x6e, 0xc7, 0xfe, 0x10, 0x96, 0xf6, 0xea, 0x7b, 0x64, 0x0f, 0x2a, 0x36, 0x8a,
-	0x24, 0x0e, 0xb1, 0x6b, 0x3c, 0xef, 0x63, 0x68, 0x88, 0x3e, 0x1a, 0x31, 0x72, 0x96, 0xc4, 0x2e,
-	0x1a, 0x5d, 0x86, 0xdc, 0x08, 0x99, 0x30, 0xf0, 0x1b, 0x9f, 0x8b, 0x1a, 0x59, 0x81, 0xfc, 0x1f,
-	0x39, 0x6d, 0x35, 0xfe, 0x18, 0xca, 0xe3, 0x62, 0x18, 0x0f, 0x99, 0x9b, 0xa4, 0x73, 0x27, 0xd9,
-	0xc9, 0xf6, 0xe2, 0xd2, 0x98, 0xdc, 0x17, 0x68, 0x76, 0x99, 0xcb, 0xcd, 0x2f, 0x8d, 0x99, 0xd0,
-	0xc4, 0xb9, 0xa2, 0x33, 0xcf, 0x8c, 0x3a, 0x7f, 0xe6, 0x0a, 0x29, 0xbf, 0xa4, 0xef, 0xac, 0xc8,
-	0x17, 0xf4, 0xbd, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xea, 0xe8, 0xfb, 0x0c, 0xc1, 0x07, 0x00,
-	0x00,
+	// 1290 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x6e, 0xdb, 0x46,
+	0x13, 0x0f, 0x65, 0xcb, 0x96, 0x46, 0x8e, 0x23, 0xed, 0xe7, 0x24, 0x8a, 0x92, 0x2f, 0xa1, 0x99,
+	0x2f, 0xf9, 0x0c, 0x25, 0x16, 0x6d, 0x35, 0x2d, 0x02, 0xb5, 0x01, 0xe2, 0xf8, 0x4f, 0x60, 0x24,
+	0x95, 0xd3, 0xb5, 0xe2, 0x02, 0xbd, 0x08, 0x14, 0xb9, 0x92, 0x58, 0x53, 0x5c, 0x96, 0xbb, 0x74,
+	0xe2, 0x4b, 0x51, 0x14, 0x3d, 0x14, 0x3d, 0x15, 0x69, 0x4f, 0x7d, 0x84, 0xa0, 0x97, 0x3e, 0x46,
+	0xcf, 0x45, 0x81, 0x3e, 0x40, 0xdf, 0xa3, 0x05, 0x77, 0x97, 0x32, 0x25, 0x4b, 0x4a, 0x7b, 0x32,
+	0x77, 0xe7, 0x37, 0x33, 0xbf, 0xf9, 0xcd, 0xec, 0xc8, 0x50, 0xb2, 0x02, 0xd7, 0xec, 0x58, 0xf6,
+	0x31, 0xf1, 0x9d, 0x5a, 0x10, 0x52, 0x4e, 0x51, 0x9e, 0x06, 0xc4, 0x1f, 0x58, 0xdc, 0xee, 0x57,
+	0x50, 0x6c, 0x1d, 0x10, 0xc6, 0xac, 0x1e, 0x61, 0xd2, 0x5c, 0xb9, 0xd1, 0xa3, 0xb4, 0xe7, 0x11,
+	0x33, 0x36, 0x59, 0xbe, 0x4f, 0xb9, 0xc5, 0x5d, 0xea, 0x27, 0xd6, 0xfb, 0xe2, 0x8f, 0xbd, 0xde,
+	0x23, 0xfe, 0x3a, 0x7b, 0x65, 0xf5, 0x7a, 0x24, 0x34, 0x69, 0x20, 0x10, 0x13, 0xd0, 0x37, 0x55,
+	0x2c, 0x71, 0xea, 0x44, 0x5d, 0xd3, 0x89, 0x42, 0x01, 0x50, 0xf6, 0xab, 0xca, 0x1e, 0x06, 0xb6,
+	0xc9, 0xb8, 0xc5, 0x23, 0xe5, 0x68, 0x7c, 0xab, 0xc1, 0xf2, 0x5e, 0xe4, 0xdb, 0x31, 0x76, 0x9b,
+	0xfa, 0x5d, 0xb7, 0x87, 0x10, 0xcc, 0xf7, 0x29, 0xe3, 0x65, 0x4d, 0xd7, 0xd6, 0xf2, 0x58, 0x7c,
+	0xc7, 0x77, 0x01, 0x0d, 0x79, 0x39, 0xa3, 0x6b, 0x6b, 0x59, 0x2c, 0xbe, 0x51, 0x1d, 0xe6, 0xf9,
+	0x69, 0x40, 0xca, 0x73, 0xba, 0xb6, 0xb6, 0x5c, 0xbf, 0x59, 0x1b, 0x56, 0x5b, 0x1b, 0x0d, 0x58,
+	0x6b, 0x9d, 0x06, 0x04, 0x0b, 0xac, 0x51, 0x81, 0xf9, 0xf8, 0x84, 0x72, 0x30, 0xff, 0x14, 0xbf,
+	0xd8, 0x2e, 0x5e, 0x88, 0xbf, 0xf0, 0xee, 0x61, 0xab, 0xa8, 0x19, 0x7f, 0x69, 0xf0, 0x9f, 0x3d,
+	0xc2, 0xed, 0xfe, 0xc7, 0x71, 0x10, 0xc2, 0x30, 0xf9, 0x22, 0x22, 0x8c, 0xa3, 0x4d, 0x58, 0xb0,
+	0x45, 0x20, 0xc1, 0xa8, 0x50, 0xbf, 0x36, 0x35, 0x13, 0x56, 0x40, 0xb4, 0x09, 0x8b, 0x41, 0x48,
+	0xbb, 0xae, 0x47, 0x04, 0xe3, 0x42, 0xfd, 0x6a, 0xca, 0x47, 0x84, 0x7f, 0x21, 0xcd, 0x38, 0xc1,
+	0xa1, 0xab, 0xb0, 0xe8, 0x84, 0xa7, 0xed, 0x30, 0xf2, 0x45, 0x41, 0x39, 0xbc, 0xe0, 0x84, 0xa7,
+	0x38, 0xf2, 0xd1, 0x6d, 0xb8, 0xd8, 0x55, 0x59, 0xda, 0xbe, 0x35, 0x20, 0xe5, 0x79, 0xa1, 0xcb,
+	0x52, 0x72, 0xd9, 0xb4, 0x06, 0x04, 0xed, 0x40, 0x71, 0x08, 0xe2, 0xee, 0x80, 0xd0, 0x88, 0x97,
+	0xb3, 0x8a, 0xad, 0x94, 0xbe, 0x96, 0xb4, 0xa6, 0xb6, 0xa3, 0x5a, 0x83, 0x2f, 0x25, 0x2e, 0x2d,
+	0xe9, 0x61, 0x1c, 0xc1, 0xd2, 0x8e, 0x48, 0x8a, 0x09, 0x8b, 0x3c, 0x8e, 0x2a, 0x90, 0xb3, 0x6c,
+	0x9b, 0x04, 0x9c, 0x38, 0xa2, 0xf6, 0x1c, 0x1e, 0x9e, 0x51, 0x15, 0x4a, 0x36, 0xf5, 0x3c, 0xd7,
+	0x21, 0x4e, 0x5b, 0xd4, 0xd5, 0x76, 0x1d, 0x51, 0x6c, 0x1e, 0x5f, 0x4a, 0x0c, 0xa2, 0xd2, 0x7d,
+	0xc7, 0x78, 0xab, 0xc1, 0xca, 0xa8, 0xb2, 0x2c, 0xa0, 0x3e, 0x23, 0xe8, 0x2e, 0x64, 0x85, 0xaf,
+	0x52, 0xb6, 0x38, 0xae, 0x12, 0x96, 0x66, 0xf4, 0x08, 0x96, 0x95, 0x38, 0xed, 0x50, 0x50, 0x9b,
+	0x20, 0x6b, 0x9a, 0x39, 0x5e, 0x72, 0xd2, 0x75, 0xdc, 0x83, 0x52, 0x48, 0x18, 0x09, 0x4f, 0x2c,
+	0x29, 0x10, 0x3d, 0x26, 0x52, 0xe5, 0x3c, 0x2e, 0xa6, 0x0c, 0xad, 0xf8, 0xde, 0x38, 0x82, 0x72,
+	0x9a, 0xeb, 0x13, 0xc1, 0x43, 0x8d, 0x42, 0x03, 0x72, 0xa1, 0xfc, 0x64, 0x65, 0x4d, 0x9f, 0x5b,
+	0x2b, 0x8c, 0x8e, 0xdd, 0xf9, 0xe1, 0xc1, 0x43, 0xbc, 0xf1, 0xbb, 0x06, 0xd7, 0x26, 0x04, 0x56,
+	0x4a, 0xac, 0x40, 0xd6, 0xf5, 0x1d, 0xf2, 0x5a, 0x28, 0x91, 0xc5, 0xf2, 0x80, 0x56, 0x61, 0x49,
+	0xcd, 0x87, 0x6c, 0xbd, 0xd4, 0xb7, 0xa0, 0xee, 0x44, 0xe7, 0x87, 0x12, 0xce, 0xcd, 0x96, 0xb0,
+	0x0a, 0x0b, 0xf2, 0xe1, 0x89, 0xf9, 0x29, 0xd4, 0x51, 0x32, 0x17, 0x61, 0x60, 0xd7, 0x0e, 0x85,
+	0x05, 0x2b, 0xc4, 0x64, 0xbd, 0xb2, 0x53, 0xf4, 0xfa, 0x00, 0x2e, 0x63, 0xe2, 0x11, 0x8b, 0x91,
+	0x96, 0x6b, 0x1f, 0x13, 0x3e, 0x7c, 0x37, 0xff, 0x05, 0xe0, 0xe2, 0xa6, 0xed, 0x3a, 0x52, 0xae,
+	0x3c, 0xce, 0xcb, 0x9b, 0x7d, 0x87, 0x19, 0x65, 0xb8, 0x32, 0xee, 0x27, 0xb5, 0x30, 0x2a, 0x50,
+	0x56, 0x96, 0x2d, 0xcf, 0x1b, 0x0d, 0x6a, 0x5c, 0x87, 0x6b, 0x13, 0x6c, 0xca, 0x71, 0x6f, 0x48,
+	0x65, 0xec, 0x09, 0xaf, 0x03, 0x3a, 0x57, 0x50, 0x42, 0xa9, 0x34, 0x5e, 0x51, 0x9a, 0xda, 0xd8,
+	0xc0, 0x1a, 0x3f, 0x6a, 0x70, 0x69, 0x8b, 0x31, 0xb7, 0xe7, 0x0f, 0x88, 0xcf, 0x9f, 0x86, 0x34,
+	0x0a, 0xde, 0x51, 0x27, 0x7a, 0x1f, 0xc0, 0x1a, 0x7a, 0xa8, 0xb9, 0xbd, 0x9c, 0xea, 0xd2, 0x59,
+	0x38, 0x9c, 0x02, 0xfe, 0xbb, 0x99, 0xfd, 0x59, 0x83, 0xd2, 0x59, 0x9c, 0x3d, 0xcb, 0xf5, 0xa2,
+	0x90, 0xa0, 0xeb, 0x90, 0x1f, 0x12, 0x53, 0xdb, 0x34, 0x97, 0xf0, 0x42, 0x0f, 0x21, 0x6b, 0x5b,
+	0x11, 0x93, 0x33, 0xb5, 0x5c, 0x37, 0x26, 0x32, 0x52, 0x91, 0x6a, 0xdb, 0x31, 0x12, 0x4b, 0x07,
+	0xe3, 0x31, 0x64, 0xc5, 0x19, 0x15, 0x60, 0xf1, 0x65, 0xf3, 0x59, 0xf3, 0xe0, 0xd3, 0x66, 0xf1,
+	0x02, 0x5a, 0x81, 0x62, 0x6b, 0x7f, 0xfb, 0xd9, 0x6e, 0xab, 0xdd, 0x3c, 0x68, 0xb5, 0xf7, 0x0e,
+	0x5e, 0x36, 0x77, 0x8a, 0x5a, 0x7c, 0x8b, 0x77, 0x0f, 0x77, 0xf1, 0xd1, 0x56, 0x6b, 0xff, 0xa0,
+	0xd9, 0x7e, 0x7e, 0x70, 0xd8, 0x2a, 0x66, 0x8c, 0x16, 0xac, 0xc8, 0x1c, 0x63, 0x13, 0xf3, 0x11,
+	0x14, 0xce, 0x14, 0x48, 0x5e, 0x58, 0x65, 0x22, 0x33, 0x21, 0x3d, 0x4e, 0xc3, 0x8d, 0x4f, 0xe0,
+	0xf2, 0x58, 0x54, 0xf5, 0xb6, 0x1e, 0x42, 0xae, 0x2b, 0x0b, 0x49, 0x62, 0xde, 0x98, 0x55, 0x2d,
+	0x1e, 0xa2, 0xeb, 0xbf, 0x2e, 0xc0, 0xf2, 0x13, 0xf9, 0x9b, 0x7a, 0x48, 0xc2, 0x13, 0xd7, 0x26,
+	0xe8, 0x4b, 0x58, 0x4a, 0xbf, 0x62, 0xf4, 0x8e, 0x05, 0x50, 0xb9, 0x35, 0xd5, 0xae, 0x46, 0xea,
+	0xde, 0xd7, 0xbf, 0xfd, 0xf9, 0x43, 0xe6, 0x8e, 0xa1, 0x9b, 0x27, 0x9b, 0xc9, 0x0f, 0x38, 0x93,
+	0xc9, 0xcc, 0x81, 0xc4, 0x36, 0xba, 0xb1, 0x63, 0x43, 0xab, 0x6e, 0x68, 0xe8, 0x8d, 0x06, 0xa5,
+	0x73, 0x6b, 0x04, 0xdd, 0x9e, 0x92, 0x25, 0xbd, 0xbd, 0x2a, 0xff, 0x9b, 0x0d, 0x52, 0x7c, 0x36,
+	0x04, 0x9f, 0xaa, 0x71, 0xe7, 0x5d, 0x7c, 0x3a, 0xd6, 0x90, 0xd4, 0x57, 0x1a, 0x5c, 0x1c, 0xd1,
+	0x1e, 0xdd, 0x3a, 0xa7, 0xf0, 0x68, 0xaf, 0x2b, 0xfa, 0x74, 0x80, 0x22, 0x72, 0x5f, 0x10, 0xb9,
+	0x6b, 0xac, 0x4e, 0x20, 0x22, 0xc7, 0x98, 0x35, 0x64, 0xff, 0x1b, 0x5a, 0x15, 0x7d, 0xa3, 0xc1,
+	0xf2, 0xe8, 0x3e, 0x41, 0xe9, 0x14, 0x13, 0x57, 0x54, 0x65, 0x75, 0x06, 0x42, 0xb1, 0x58, 0x17,
+	0x2c, 0xfe, 0x6f, 0x18, 0x33, 0x58, 0x84, 0xd2, 0x35, 0xa6, 0xf1, 0xbd, 0x06, 0xa5, 0x73, 0x0b,
+	0x6a, 0xa4, 0x3d, 0xd3, 0x56, 0xdb, 0x48, 0x7b, 0xa6, 0xef, 0xb8, 0x59, 0xed, 0x19, 0xe3, 0x63,
+	0x79, 0xde, 0x98, 0x32, 0xc9, 0xd0, 0x4e, 0x50, 0x66, 0x6c, 0x6c, 0x57, 0x67, 0x20, 0xfe, 0x81,
+	0x32, 0xc9, 0xa0, 0x9c, 0x29, 0xf3, 0xe4, 0xbb, 0xb9, 0x37, 0x5b, 0x7f, 0x64, 0xd0, 0x2f, 0x1a,
+	0x2c, 0xaa, 0x27, 0x65, 0xec, 0x03, 0x1c, 0x04, 0xc4, 0xd7, 0x45, 0x64, 0x74, 0xa5, 0xcf, 0x79,
+	0xc0, 0x1a, 0xa6, 0x19, 0x67, 0x5e, 0x97, 0xa9, 0x1d, 0x72, 0x52, 0xb9, 0x7d, 0x76, 0x5e, 0x77,
+	0x5c, 0x66, 0x47, 0x8c, 0x3d, 0x96, 0xbf, 0x5d, 0xbd, 0x78, 0x09, 0xb0, 0x9a, 0x4d, 0x07, 0xd5,
+	0x23, 0x40, 0x5b, 0x81, 0x65, 0xf7, 0x89, 0x5e, 0xaf, 0x6d, 0xe8, 0xcf, 0x5d, 0x9b, 0xc4, 0x2f,
+	0xff, 0x71, 0x12, 0xb2, 0xe7, 0xf2, 0x7e, 0xd4, 0x89, 0x91, 0xa6, 0x74, 0xed, 0xd2, 0xb0, 0x67,
+	0x0d, 0x08, 0x4b, 0x25, 0x33, 0x3b, 0x1e, 0xed, 0x98, 0x03, 0x8b, 0x71, 0x12, 0x9a, 0xcf, 0xf7,
+	0xb7, 0x77, 0x9b, 0x87, 0xbb, 0xf5, 0xb9, 0xcd, 0xda, 0x46, 0x35, 0xa3, 0x65, 0xea, 0x45, 0x2b,
+	0x08, 0x3c, 0xd7, 0x16, 0x4b, 0xd7, 0xfc, 0x9c, 0x51, 0xbf, 0x71, 0xee, 0x06, 0x7f, 0x08, 0x73,
+	0x0f, 0x36, 0x1e, 0xa0, 0x07, 0x50, 0xc5, 0x84, 0x47, 0xa1, 0x4f, 0x1c, 0xfd, 0x55, 0x9f, 0xf8,
+	0x3a, 0xef, 0x13, 0x3d, 0x24, 0x8c, 0x46, 0xa1, 0x4d, 0x74, 0x87, 0x12, 0xa6, 0xfb, 0x94, 0xeb,
+	0xe4, 0xb5, 0xcb, 0x78, 0x0d, 0x2d, 0xc0, 0xfc, 0x4f, 0x19, 0x6d, 0x31, 0x7c, 0x04, 0xe5, 0x33,
+	0x31, 0xf4, 0x1d, 0x6a, 0x47, 0xf1, 0x4e, 0x12, 0xd1, 0xd1, 0xea, 0x64, 0x69, 0x4c, 0xe6, 0x72,
+	0x62, 0x3a, 0xd4, 0x66, 0xe6, 0x67, 0xfa, 0x98, 0x29, 0x55, 0x57, 0x70, 0xdc, 0x33, 0x83, 0xce,
+	0xdb, 0x4c, 0x3e, 0x8e, 0x2f, 0xc2, 0x77, 0x16, 0xc4, 0x7f, 0x83, 0xef, 0xfd, 0x1d, 0x00, 0x00,
+	0xff, 0xff, 0xea, 0x89, 0xc8, 0x43, 0x36, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -632,8 +977,23 @@ type BackendServiceClient interface {
 	// accepted by the evaluator.
 	// Tickets in matches returned by FetchMatches are moved from active to
 	// pending, and will not be returned by query.
+	// A dry run instead returns every proposal along with whether the evaluator
+	// accepted it, without moving any tickets to pending.
+	//   - If the MatchFunction runs longer than function_timeout, the call ends with DEADLINE_EXCEEDED after its proposals are evaluated.
+	//   - If the synchronization cycle closes before the MatchFunction finishes, the call ends with ABORTED.
+	//   - Otherwise, if the MatchFunction fails the call ends with UNKNOWN.
 	FetchMatches(ctx context.Context, in *FetchMatchesRequest, opts ...grpc.CallOption) (BackendService_FetchMatchesClient, error)
+	// FetchMatchesBatch triggers the MatchFunction of each FetchMatchesRequest,
+	// registering with a single synchronization cycle so that every MatchFunction
+	// runs concurrently in the same cycle.  Matches are returned with the index
+	// and profile name of the request they were made for.
+	//   - A MatchFunction failing does not fail the call, and is returned as a status for its request.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	FetchMatchesBatch(ctx context.Context, in *FetchMatchesBatchRequest, opts ...grpc.CallOption) (BackendService_FetchMatchesBatchClient, error)
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
+	//   - Groups which specify a ReservationToken are only assigned while their Tickets are still pending release for that match.
 	AssignTickets(ctx context.Context, in *AssignTicketsRequest, opts ...grpc.CallOption) (*AssignTicketsResponse, error)
 	// ReleaseTickets moves tickets from the pending state, to the active state.
 	// This enables them to be returned by query, and find different matches.
@@ -648,6 +1008,14 @@ type BackendServiceClient interface {
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
 	ReleaseAllTickets(ctx context.Context, in *ReleaseAllTicketsRequest, opts ...grpc.CallOption) (*ReleaseAllTicketsResponse, error)
+	// ReleaseMatches moves the tickets of matches returned by FetchMatches, given
+	// by their reservation tokens, from the pending state, to the active state.
+	// Tickets which are no longer pending for the match they were returned in
+	// are left as they are.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReleaseMatches(ctx context.Context, in *ReleaseMatchesRequest, opts ...grpc.CallOption) (*ReleaseMatchesResponse, error)
 }
 
 type backendServiceClient struct {
@@ -690,6 +1058,38 @@ func (x *backendServiceFetchMatchesClient) Recv() (*FetchMatchesResponse, error)
 	return m, nil
 }
 
+func (c *backendServiceClient) FetchMatchesBatch(ctx context.Context, in *FetchMatchesBatchRequest, opts ...grpc.CallOption) (BackendService_FetchMatchesBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackendService_serviceDesc.Streams[1], "/openmatch.BackendService/FetchMatchesBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backendServiceFetchMatchesBatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BackendService_FetchMatchesBatchClient interface {
+	Recv() (*FetchMatchesBatchResponse, error)
+	grpc.ClientStream
+}
+
+type backendServiceFetchMatchesBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *backendServiceFetchMatchesBatchClient) Recv() (*FetchMatchesBatchResponse, error) {
+	m := new(FetchMatchesBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *backendServiceClient) AssignTickets(ctx context.Context, in *AssignTicketsRequest, opts ...grpc.CallOption) (*AssignTicketsResponse, error) {
 	out := new(AssignTicketsResponse)
 	err := c.cc.Invoke(ctx, "/openmatch.BackendService/AssignTickets", in, out, opts...)
@@ -717,6 +1117,15 @@ func (c *backendServiceClient) ReleaseAllTickets(ctx context.Context, in *Releas
 	return out, nil
 }
 
+func (c *backendServiceClient) ReleaseMatches(ctx context.Context, in *ReleaseMatchesRequest, opts ...grpc.CallOption) (*ReleaseMatchesResponse, error) {
+	out := new(ReleaseMatchesResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/ReleaseMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackendServiceServer is the server API for BackendService service.
 type BackendServiceServer interface {
 	// FetchMatches triggers a MatchFunction with the specified MatchProfile and
@@ -724,8 +1133,23 @@ type BackendServiceServer interface {
 	// accepted by the evaluator.
 	// Tickets in matches returned by FetchMatches are moved from active to
 	// pending, and will not be returned by query.
+	// A dry run instead returns every proposal along with whether the evaluator
+	// accepted it, without moving any tickets to pending.
+	//   - If the MatchFunction runs longer than function_timeout, the call ends with DEADLINE_EXCEEDED after its proposals are evaluated.
+	//   - If the synchronization cycle closes before the MatchFunction finishes, the call ends with ABORTED.
+	//   - Otherwise, if the MatchFunction fails the call ends with UNKNOWN.
 	FetchMatches(*FetchMatchesRequest, BackendService_FetchMatchesServer) error
+	// FetchMatchesBatch triggers the MatchFunction of each FetchMatchesRequest,
+	// registering with a single synchronization cycle so that every MatchFunction
+	// runs concurrently in the same cycle.  Matches are returned with the index
+	// and profile name of the request they were made for.
+	//   - A MatchFunction failing does not fail the call, and is returned as a status for its request.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	FetchMatchesBatch(*FetchMatchesBatchRequest, BackendService_FetchMatchesBatchServer) error
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
+	//   - Groups which specify a ReservationToken are only assigned while their Tickets are still pending release for that match.
 	AssignTickets(context.Context, *AssignTicketsRequest) (*AssignTicketsResponse, error)
 	// ReleaseTickets moves tickets from the pending state, to the active state.
 	// This enables them to be returned by query, and find different matches.
@@ -740,6 +1164,14 @@ type BackendServiceServer interface {
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
 	ReleaseAllTickets(context.Context, *ReleaseAllTicketsRequest) (*ReleaseAllTicketsResponse, error)
+	// ReleaseMatches moves the tickets of matches returned by FetchMatches, given
+	// by their reservation tokens, from the pending state, to the active state.
+	// Tickets which are no longer pending for the match they were returned in
+	// are left as they are.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReleaseMatches(context.Context, *ReleaseMatchesRequest) (*ReleaseMatchesResponse, error)
 }
 
 // UnimplementedBackendServiceServer can be embedded to have forward compatible implementations.
@@ -747,16 +1179,22 @@ type UnimplementedBackendServiceServer struct {
 }
 
 func (*UnimplementedBackendServiceServer) FetchMatches(req *FetchMatchesRequest, srv BackendService_FetchMatchesServer) error {
-	return status.Errorf(codes.Unimplemented, "method FetchMatches not implemented")
+	return status1.Errorf(codes.Unimplemented, "method FetchMatches not implemented")
+}
+func (*UnimplementedBackendServiceServer) FetchMatchesBatch(req *FetchMatchesBatchRequest, srv BackendService_FetchMatchesBatchServer) error {
+	return status1.Errorf(codes.Unimplemented, "method FetchMatchesBatch not implemented")
 }
 func (*UnimplementedBackendServiceServer) AssignTickets(ctx context.Context, req *AssignTicketsRequest) (*AssignTicketsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AssignTickets not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method AssignTickets not implemented")
 }
 func (*UnimplementedBackendServiceServer) ReleaseTickets(ctx context.Context, req *ReleaseTicketsRequest) (*ReleaseTicketsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTickets not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method ReleaseTickets not implemented")
 }
 func (*UnimplementedBackendServiceServer) ReleaseAllTickets(ctx context.Context, req *ReleaseAllTicketsRequest) (*ReleaseAllTicketsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseAllTickets not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method ReleaseAllTickets not implemented")
+}
+func (*UnimplementedBackendServiceServer) ReleaseMatches(ctx context.Context, req *ReleaseMatchesRequest) (*ReleaseMatchesResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ReleaseMatches not implemented")
 }
 
 func RegisterBackendServiceServer(s *grpc.Server, srv BackendServiceServer) {
@@ -784,6 +1222,27 @@ func (x *backendServiceFetchMatchesServer) Send(m *FetchMatchesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BackendService_FetchMatchesBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchMatchesBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackendServiceServer).FetchMatchesBatch(m, &backendServiceFetchMatchesBatchServer{stream})
+}
+
+type BackendService_FetchMatchesBatchServer interface {
+	Send(*FetchMatchesBatchResponse) error
+	grpc.ServerStream
+}
+
+type backendServiceFetchMatchesBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *backendServiceFetchMatchesBatchServer) Send(m *FetchMatchesBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _BackendService_AssignTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignTicketsRequest)
 	if err := dec(in); err != nil {
@@ -838,6 +1297,24 @@ func _BackendService_ReleaseAllTickets_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendService_ReleaseMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ReleaseMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/ReleaseMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ReleaseMatches(ctx, req.(*ReleaseMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _BackendService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.BackendService",
 	HandlerType: (*BackendServiceServer)(nil),
@@ -854,6 +1331,10 @@ var _BackendService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseAllTickets",
 			Handler:    _BackendService_ReleaseAllTickets_Handler,
 		},
+		{
+			MethodName: "ReleaseMatches",
+			Handler:    _BackendService_ReleaseMatches_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -861,6 +1342,11 @@ var _BackendService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _BackendService_FetchMatches_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "FetchMatchesBatch",
+			Handler:       _BackendService_FetchMatchesBatch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/backend.proto",
 }
@@ -7,12 +7,14 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	status1 "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -29,7 +31,17 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type CreateTicketRequest struct {
 	// A Ticket object with SearchFields defined.
-	Ticket               *Ticket  `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Ticket *Ticket `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// Optional time to live of the Ticket. Once it elapses, Open Match stops
+	// returning the Ticket for matchmaking and deletes it. Overrides the
+	// configured ticketTTL when set.
+	Ttl *duration.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Optional key identifying the request. Within the configured
+	// idempotencyKeyWindow, a request repeating the key returns the Ticket
+	// created by the first request with it, instead of creating another Ticket.
+	// Requests repeating the key with a different ticket or ttl fail with
+	// FAILED_PRECONDITION.
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -67,6 +79,158 @@ func (m *CreateTicketRequest) GetTicket() *Ticket {
 	return nil
 }
 
+func (m *CreateTicketRequest) GetTtl() *duration.Duration {
+	if m != nil {
+		return m.Ttl
+	}
+	return nil
+}
+
+func (m *CreateTicketRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateTicketsRequest struct {
+	// Tickets to create, each in the same form as a CreateTicketRequest.
+	Tickets              []*CreateTicketRequest `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CreateTicketsRequest) Reset()         { *m = CreateTicketsRequest{} }
+func (m *CreateTicketsRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateTicketsRequest) ProtoMessage()    {}
+func (*CreateTicketsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{1}
+}
+
+func (m *CreateTicketsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTicketsRequest.Unmarshal(m, b)
+}
+func (m *CreateTicketsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTicketsRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateTicketsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTicketsRequest.Merge(m, src)
+}
+func (m *CreateTicketsRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateTicketsRequest.Size(m)
+}
+func (m *CreateTicketsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTicketsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTicketsRequest proto.InternalMessageInfo
+
+func (m *CreateTicketsRequest) GetTickets() []*CreateTicketRequest {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+// CreateTicketFailure contains the position of a Ticket that failed to be created and the failure status.
+type CreateTicketFailure struct {
+	// The index of the Ticket in CreateTicketsRequest.tickets.
+	Index                int32          `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status               *status.Status `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateTicketFailure) Reset()         { *m = CreateTicketFailure{} }
+func (m *CreateTicketFailure) String() string { return proto.CompactTextString(m) }
+func (*CreateTicketFailure) ProtoMessage()    {}
+func (*CreateTicketFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{2}
+}
+
+func (m *CreateTicketFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTicketFailure.Unmarshal(m, b)
+}
+func (m *CreateTicketFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTicketFailure.Marshal(b, m, deterministic)
+}
+func (m *CreateTicketFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTicketFailure.Merge(m, src)
+}
+func (m *CreateTicketFailure) XXX_Size() int {
+	return xxx_messageInfo_CreateTicketFailure.Size(m)
+}
+func (m *CreateTicketFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTicketFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTicketFailure proto.InternalMessageInfo
+
+func (m *CreateTicketFailure) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CreateTicketFailure) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type CreateTicketsResponse struct {
+	// The created Tickets, in the order they were requested. Tickets which failed to be created are omitted.
+	Tickets []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// Failures is a list of all the Tickets that failed to be created along with the cause of failure.
+	Failures             []*CreateTicketFailure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *CreateTicketsResponse) Reset()         { *m = CreateTicketsResponse{} }
+func (m *CreateTicketsResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateTicketsResponse) ProtoMessage()    {}
+func (*CreateTicketsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{3}
+}
+
+func (m *CreateTicketsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateTicketsResponse.Unmarshal(m, b)
+}
+func (m *CreateTicketsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateTicketsResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateTicketsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateTicketsResponse.Merge(m, src)
+}
+func (m *CreateTicketsResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateTicketsResponse.Size(m)
+}
+func (m *CreateTicketsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateTicketsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateTicketsResponse proto.InternalMessageInfo
+
+func (m *CreateTicketsResponse) GetTickets() []*Ticket {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+func (m *CreateTicketsResponse) GetFailures() []*CreateTicketFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
 type DeleteTicketRequest struct {
 	// A TicketId of a generated Ticket to be deleted.
 	TicketId             string   `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
@@ -79,7 +243,7 @@ func (m *DeleteTicketRequest) Reset()         { *m = DeleteTicketRequest{} }
 func (m *DeleteTicketRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTicketRequest) ProtoMessage()    {}
 func (*DeleteTicketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_06c902cf58d2ae57, []int{1}
+	return fileDescriptor_06c902cf58d2ae57, []int{4}
 }
 
 func (m *DeleteTicketRequest) XXX_Unmarshal(b []byte) error {
@@ -107,6 +271,175 @@ func (m *DeleteTicketRequest) GetTicketId() string {
 	return ""
 }
 
+type DeleteTicketsRequest struct {
+	// TicketIds of generated Tickets to be deleted.
+	TicketIds            []string `protobuf:"bytes,1,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteTicketsRequest) Reset()         { *m = DeleteTicketsRequest{} }
+func (m *DeleteTicketsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTicketsRequest) ProtoMessage()    {}
+func (*DeleteTicketsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{5}
+}
+
+func (m *DeleteTicketsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTicketsRequest.Unmarshal(m, b)
+}
+func (m *DeleteTicketsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTicketsRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteTicketsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTicketsRequest.Merge(m, src)
+}
+func (m *DeleteTicketsRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteTicketsRequest.Size(m)
+}
+func (m *DeleteTicketsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTicketsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTicketsRequest proto.InternalMessageInfo
+
+func (m *DeleteTicketsRequest) GetTicketIds() []string {
+	if m != nil {
+		return m.TicketIds
+	}
+	return nil
+}
+
+// DeleteTicketFailure contains the id of a Ticket that failed to be deleted and the failure status.
+type DeleteTicketFailure struct {
+	TicketId             string         `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Status               *status.Status `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeleteTicketFailure) Reset()         { *m = DeleteTicketFailure{} }
+func (m *DeleteTicketFailure) String() string { return proto.CompactTextString(m) }
+func (*DeleteTicketFailure) ProtoMessage()    {}
+func (*DeleteTicketFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{6}
+}
+
+func (m *DeleteTicketFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTicketFailure.Unmarshal(m, b)
+}
+func (m *DeleteTicketFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTicketFailure.Marshal(b, m, deterministic)
+}
+func (m *DeleteTicketFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTicketFailure.Merge(m, src)
+}
+func (m *DeleteTicketFailure) XXX_Size() int {
+	return xxx_messageInfo_DeleteTicketFailure.Size(m)
+}
+func (m *DeleteTicketFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTicketFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTicketFailure proto.InternalMessageInfo
+
+func (m *DeleteTicketFailure) GetTicketId() string {
+	if m != nil {
+		return m.TicketId
+	}
+	return ""
+}
+
+func (m *DeleteTicketFailure) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type DeleteTicketsResponse struct {
+	// Failures is a list of all the Tickets that failed to be deleted along with the cause of failure.
+	Failures             []*DeleteTicketFailure `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *DeleteTicketsResponse) Reset()         { *m = DeleteTicketsResponse{} }
+func (m *DeleteTicketsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTicketsResponse) ProtoMessage()    {}
+func (*DeleteTicketsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{7}
+}
+
+func (m *DeleteTicketsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteTicketsResponse.Unmarshal(m, b)
+}
+func (m *DeleteTicketsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteTicketsResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteTicketsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTicketsResponse.Merge(m, src)
+}
+func (m *DeleteTicketsResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteTicketsResponse.Size(m)
+}
+func (m *DeleteTicketsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTicketsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteTicketsResponse proto.InternalMessageInfo
+
+func (m *DeleteTicketsResponse) GetFailures() []*DeleteTicketFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+type UpdateTicketRequest struct {
+	// A Ticket object with the id of an existing Ticket, and the new
+	// SearchFields and extensions.
+	Ticket               *Ticket  `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTicketRequest) Reset()         { *m = UpdateTicketRequest{} }
+func (m *UpdateTicketRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTicketRequest) ProtoMessage()    {}
+func (*UpdateTicketRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{8}
+}
+
+func (m *UpdateTicketRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTicketRequest.Unmarshal(m, b)
+}
+func (m *UpdateTicketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTicketRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTicketRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTicketRequest.Merge(m, src)
+}
+func (m *UpdateTicketRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTicketRequest.Size(m)
+}
+func (m *UpdateTicketRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTicketRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTicketRequest proto.InternalMessageInfo
+
+func (m *UpdateTicketRequest) GetTicket() *Ticket {
+	if m != nil {
+		return m.Ticket
+	}
+	return nil
+}
+
 type GetTicketRequest struct {
 	// A TicketId of a generated Ticket.
 	TicketId             string   `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
@@ -119,7 +452,7 @@ func (m *GetTicketRequest) Reset()         { *m = GetTicketRequest{} }
 func (m *GetTicketRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTicketRequest) ProtoMessage()    {}
 func (*GetTicketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_06c902cf58d2ae57, []int{2}
+	return fileDescriptor_06c902cf58d2ae57, []int{9}
 }
 
 func (m *GetTicketRequest) XXX_Unmarshal(b []byte) error {
@@ -159,7 +492,7 @@ func (m *WatchAssignmentsRequest) Reset()         { *m = WatchAssignmentsRequest
 func (m *WatchAssignmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*WatchAssignmentsRequest) ProtoMessage()    {}
 func (*WatchAssignmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_06c902cf58d2ae57, []int{3}
+	return fileDescriptor_06c902cf58d2ae57, []int{10}
 }
 
 func (m *WatchAssignmentsRequest) XXX_Unmarshal(b []byte) error {
@@ -199,7 +532,7 @@ func (m *WatchAssignmentsResponse) Reset()         { *m = WatchAssignmentsRespon
 func (m *WatchAssignmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*WatchAssignmentsResponse) ProtoMessage()    {}
 func (*WatchAssignmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_06c902cf58d2ae57, []int{4}
+	return fileDescriptor_06c902cf58d2ae57, []int{11}
 }
 
 func (m *WatchAssignmentsResponse) XXX_Unmarshal(b []byte) error {
@@ -227,59 +560,366 @@ func (m *WatchAssignmentsResponse) GetAssignment() *Assignment {
 	return nil
 }
 
+type StreamAssignmentsRequest struct {
+	// TicketIds of generated Tickets to get updates on. Assignments which are
+	// already set on these Tickets are sent when the stream starts.
+	TicketIds []string `protobuf:"bytes,1,rep,name=ticket_ids,json=ticketIds,proto3" json:"ticket_ids,omitempty"`
+	// Optional Pool selecting further Tickets to get updates on. Any Ticket
+	// matching the Pool's filters is included once it is assigned.
+	Pool                 *Pool    `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamAssignmentsRequest) Reset()         { *m = StreamAssignmentsRequest{} }
+func (m *StreamAssignmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamAssignmentsRequest) ProtoMessage()    {}
+func (*StreamAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{12}
+}
+
+func (m *StreamAssignmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamAssignmentsRequest.Unmarshal(m, b)
+}
+func (m *StreamAssignmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamAssignmentsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamAssignmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamAssignmentsRequest.Merge(m, src)
+}
+func (m *StreamAssignmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamAssignmentsRequest.Size(m)
+}
+func (m *StreamAssignmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamAssignmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamAssignmentsRequest proto.InternalMessageInfo
+
+func (m *StreamAssignmentsRequest) GetTicketIds() []string {
+	if m != nil {
+		return m.TicketIds
+	}
+	return nil
+}
+
+func (m *StreamAssignmentsRequest) GetPool() *Pool {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+type StreamAssignmentsResponse struct {
+	// The TicketId of the updated Ticket.
+	TicketId string `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	// An updated Assignment of the Ticket.
+	Assignment           *Assignment `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StreamAssignmentsResponse) Reset()         { *m = StreamAssignmentsResponse{} }
+func (m *StreamAssignmentsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamAssignmentsResponse) ProtoMessage()    {}
+func (*StreamAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{13}
+}
+
+func (m *StreamAssignmentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamAssignmentsResponse.Unmarshal(m, b)
+}
+func (m *StreamAssignmentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamAssignmentsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamAssignmentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamAssignmentsResponse.Merge(m, src)
+}
+func (m *StreamAssignmentsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamAssignmentsResponse.Size(m)
+}
+func (m *StreamAssignmentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamAssignmentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamAssignmentsResponse proto.InternalMessageInfo
+
+func (m *StreamAssignmentsResponse) GetTicketId() string {
+	if m != nil {
+		return m.TicketId
+	}
+	return ""
+}
+
+func (m *StreamAssignmentsResponse) GetAssignment() *Assignment {
+	if m != nil {
+		return m.Assignment
+	}
+	return nil
+}
+
+type CreateBackfillRequest struct {
+	// A Backfill object with SearchFields defined.
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CreateBackfillRequest) Reset()         { *m = CreateBackfillRequest{} }
+func (m *CreateBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateBackfillRequest) ProtoMessage()    {}
+func (*CreateBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{14}
+}
+
+func (m *CreateBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateBackfillRequest.Unmarshal(m, b)
+}
+func (m *CreateBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateBackfillRequest.Merge(m, src)
+}
+func (m *CreateBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateBackfillRequest.Size(m)
+}
+func (m *CreateBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateBackfillRequest proto.InternalMessageInfo
+
+func (m *CreateBackfillRequest) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+type UpdateBackfillRequest struct {
+	// A Backfill object with the id of an existing Backfill, and the new
+	// SearchFields and extensions.
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UpdateBackfillRequest) Reset()         { *m = UpdateBackfillRequest{} }
+func (m *UpdateBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateBackfillRequest) ProtoMessage()    {}
+func (*UpdateBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{15}
+}
+
+func (m *UpdateBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateBackfillRequest.Unmarshal(m, b)
+}
+func (m *UpdateBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateBackfillRequest.Merge(m, src)
+}
+func (m *UpdateBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateBackfillRequest.Size(m)
+}
+func (m *UpdateBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateBackfillRequest proto.InternalMessageInfo
+
+func (m *UpdateBackfillRequest) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+type DeleteBackfillRequest struct {
+	// A BackfillId of a generated Backfill to be deleted.
+	BackfillId           string   `protobuf:"bytes,1,opt,name=backfill_id,json=backfillId,proto3" json:"backfill_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBackfillRequest) Reset()         { *m = DeleteBackfillRequest{} }
+func (m *DeleteBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBackfillRequest) ProtoMessage()    {}
+func (*DeleteBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{16}
+}
+
+func (m *DeleteBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBackfillRequest.Unmarshal(m, b)
+}
+func (m *DeleteBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBackfillRequest.Merge(m, src)
+}
+func (m *DeleteBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteBackfillRequest.Size(m)
+}
+func (m *DeleteBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBackfillRequest proto.InternalMessageInfo
+
+func (m *DeleteBackfillRequest) GetBackfillId() string {
+	if m != nil {
+		return m.BackfillId
+	}
+	return ""
+}
+
+type GetBackfillRequest struct {
+	// A BackfillId of a generated Backfill.
+	BackfillId           string   `protobuf:"bytes,1,opt,name=backfill_id,json=backfillId,proto3" json:"backfill_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBackfillRequest) Reset()         { *m = GetBackfillRequest{} }
+func (m *GetBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBackfillRequest) ProtoMessage()    {}
+func (*GetBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{17}
+}
+
+func (m *GetBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBackfillRequest.Unmarshal(m, b)
+}
+func (m *GetBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBackfillRequest.Merge(m, src)
+}
+func (m *GetBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBackfillRequest.Size(m)
+}
+func (m *GetBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBackfillRequest proto.InternalMessageInfo
+
+func (m *GetBackfillRequest) GetBackfillId() string {
+	if m != nil {
+		return m.BackfillId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CreateTicketRequest)(nil), "openmatch.CreateTicketRequest")
+	proto.RegisterType((*CreateTicketsRequest)(nil), "openmatch.CreateTicketsRequest")
+	proto.RegisterType((*CreateTicketFailure)(nil), "openmatch.CreateTicketFailure")
+	proto.RegisterType((*CreateTicketsResponse)(nil), "openmatch.CreateTicketsResponse")
 	proto.RegisterType((*DeleteTicketRequest)(nil), "openmatch.DeleteTicketRequest")
+	proto.RegisterType((*DeleteTicketsRequest)(nil), "openmatch.DeleteTicketsRequest")
+	proto.RegisterType((*DeleteTicketFailure)(nil), "openmatch.DeleteTicketFailure")
+	proto.RegisterType((*DeleteTicketsResponse)(nil), "openmatch.DeleteTicketsResponse")
+	proto.RegisterType((*UpdateTicketRequest)(nil), "openmatch.UpdateTicketRequest")
 	proto.RegisterType((*GetTicketRequest)(nil), "openmatch.GetTicketRequest")
 	proto.RegisterType((*WatchAssignmentsRequest)(nil), "openmatch.WatchAssignmentsRequest")
 	proto.RegisterType((*WatchAssignmentsResponse)(nil), "openmatch.WatchAssignmentsResponse")
+	proto.RegisterType((*StreamAssignmentsRequest)(nil), "openmatch.StreamAssignmentsRequest")
+	proto.RegisterType((*StreamAssignmentsResponse)(nil), "openmatch.StreamAssignmentsResponse")
+	proto.RegisterType((*CreateBackfillRequest)(nil), "openmatch.CreateBackfillRequest")
+	proto.RegisterType((*UpdateBackfillRequest)(nil), "openmatch.UpdateBackfillRequest")
+	proto.RegisterType((*DeleteBackfillRequest)(nil), "openmatch.DeleteBackfillRequest")
+	proto.RegisterType((*GetBackfillRequest)(nil), "openmatch.GetBackfillRequest")
 }
 
 func init() { proto.RegisterFile("api/frontend.proto", fileDescriptor_06c902cf58d2ae57) }
 
 var fileDescriptor_06c902cf58d2ae57 = []byte{
-	// 643 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5f, 0x4f, 0x13, 0x4f,
-	0x14, 0xcd, 0x16, 0xc2, 0x8f, 0xce, 0x8f, 0x44, 0x1c, 0x22, 0x92, 0xd6, 0x98, 0x71, 0x49, 0x54,
-	0x1a, 0xbb, 0x53, 0x0a, 0xf8, 0x00, 0x31, 0x01, 0x01, 0x0d, 0x09, 0x6a, 0x2c, 0x46, 0x13, 0x5f,
-	0xcc, 0x76, 0xf7, 0xb2, 0x1d, 0xe9, 0xce, 0x8c, 0x7b, 0x67, 0x41, 0x63, 0x4c, 0x8c, 0xaf, 0xbe,
-	0xe9, 0x1b, 0x1f, 0xc1, 0x47, 0xbf, 0x8a, 0x4f, 0xbe, 0xfb, 0x41, 0xcc, 0xce, 0xf6, 0xcf, 0x5a,
-	0x2a, 0xc1, 0xa7, 0x66, 0xe7, 0x9e, 0x7b, 0xce, 0xbd, 0xe7, 0xdc, 0x94, 0x50, 0x5f, 0x0b, 0x7e,
-	0x98, 0x28, 0x69, 0x40, 0x86, 0x9e, 0x4e, 0x94, 0x51, 0xb4, 0xac, 0x34, 0xc8, 0xd8, 0x37, 0x41,
-	0xa7, 0x62, 0xcb, 0x31, 0x20, 0xfa, 0x11, 0x60, 0x5e, 0xae, 0x5c, 0x8b, 0x94, 0x8a, 0xba, 0xc0,
-	0xb3, 0x92, 0x2f, 0xa5, 0x32, 0xbe, 0x11, 0x4a, 0xf6, 0xab, 0x77, 0xec, 0x4f, 0x50, 0x8f, 0x40,
-	0xd6, 0xf1, 0xc4, 0x8f, 0x22, 0x48, 0xb8, 0xd2, 0x16, 0x31, 0x06, 0x5d, 0xed, 0x71, 0xd9, 0xaf,
-	0x76, 0x7a, 0xc8, 0x21, 0xd6, 0xe6, 0x5d, 0x5e, 0x74, 0x37, 0xc9, 0xdc, 0x76, 0x02, 0xbe, 0x81,
-	0x67, 0x22, 0x38, 0x02, 0xd3, 0x82, 0x37, 0x29, 0xa0, 0xa1, 0x4b, 0x64, 0xca, 0xd8, 0x87, 0x05,
-	0x87, 0x39, 0xb7, 0xff, 0x6f, 0x5e, 0xf6, 0x06, 0xf3, 0x7a, 0x3d, 0x64, 0x0f, 0xe0, 0x36, 0xc9,
-	0xdc, 0x0e, 0x74, 0x61, 0x94, 0xa1, 0x4a, 0xca, 0x39, 0xe0, 0x95, 0x08, 0x2d, 0x49, 0xb9, 0x35,
-	0x9d, 0x3f, 0xec, 0x85, 0x2e, 0x27, 0xb3, 0x0f, 0xc1, 0xfc, 0x43, 0xc3, 0x5d, 0x72, 0xf5, 0x45,
-	0x26, 0xbe, 0x85, 0x28, 0x22, 0x19, 0x83, 0x34, 0x78, 0xa1, 0xbe, 0xa7, 0x64, 0xe1, 0x6c, 0x1f,
-	0x6a, 0x25, 0x11, 0xe8, 0x1a, 0x21, 0xfe, 0xe0, 0xb9, 0xb7, 0xe7, 0x95, 0xc2, 0x9e, 0xc3, 0x9e,
-	0x56, 0x01, 0xd8, 0xfc, 0x38, 0x49, 0x2e, 0x3d, 0xe8, 0x85, 0x79, 0x00, 0xc9, 0xb1, 0x08, 0x80,
-	0x0a, 0x32, 0x53, 0x74, 0x91, 0x5e, 0x2f, 0xd0, 0x8c, 0xb1, 0xb7, 0x72, 0xd6, 0x4e, 0xf7, 0xe6,
-	0xa7, 0x1f, 0xbf, 0xbe, 0x96, 0x98, 0x5b, 0xe5, 0xc7, 0xcb, 0x83, 0x63, 0xc1, 0x9c, 0x9f, 0xe7,
-	0xfb, 0xe0, 0xba, 0x53, 0xa3, 0x27, 0x64, 0xa6, 0x68, 0xf7, 0x1f, 0x52, 0x63, 0x72, 0xa8, 0xcc,
-	0x7b, 0x79, 0xfc, 0x5e, 0x3f, 0x7e, 0x6f, 0x37, 0x8b, 0xdf, 0xe5, 0x56, 0x6f, 0xa9, 0x76, 0xeb,
-	0x1c, 0x3d, 0xfe, 0x7e, 0xe0, 0xec, 0x07, 0xda, 0x25, 0xe5, 0x41, 0x66, 0xb4, 0x5a, 0x50, 0x1d,
-	0x4d, 0x72, 0xdc, 0x76, 0x3d, 0x35, 0x7a, 0x61, 0xb5, 0x53, 0x87, 0xcc, 0x8e, 0x26, 0x47, 0xdd,
-	0x02, 0xf1, 0x5f, 0xce, 0xa1, 0xb2, 0x78, 0x2e, 0x26, 0x8f, 0xde, 0xdd, 0xb0, 0xe3, 0xac, 0xd1,
-	0x95, 0x0b, 0x8e, 0xc3, 0x87, 0xf9, 0x63, 0xc3, 0xb9, 0xff, 0x79, 0xe2, 0xcb, 0xd6, 0xcf, 0x12,
-	0xfd, 0xee, 0x90, 0xe9, 0xfe, 0x25, 0xb8, 0x7b, 0x84, 0x3c, 0xd1, 0x20, 0xd9, 0xa3, 0x4c, 0x93,
-	0xce, 0x77, 0x8c, 0xd1, 0xb8, 0xce, 0x79, 0x36, 0x4c, 0x3d, 0x9f, 0x26, 0x84, 0xe3, 0xca, 0xe2,
-	0xf0, 0xbb, 0x1e, 0x0a, 0x0c, 0x52, 0xc4, 0xcd, 0x3c, 0x9f, 0x28, 0x51, 0xa9, 0x46, 0x2f, 0x50,
-	0x71, 0xed, 0x39, 0xa1, 0x5b, 0xda, 0x0f, 0x3a, 0xc0, 0x9a, 0x5e, 0x83, 0xed, 0x8b, 0x00, 0xb2,
-	0x73, 0xdd, 0xec, 0x53, 0x46, 0xc2, 0x74, 0xd2, 0x76, 0x86, 0xe4, 0x79, 0xeb, 0xa1, 0x4a, 0x22,
-	0x3f, 0x06, 0x2c, 0x88, 0xf1, 0x76, 0x57, 0xb5, 0x79, 0xec, 0xa3, 0x81, 0x84, 0xef, 0xef, 0x6d,
-	0xef, 0x3e, 0x3e, 0xd8, 0x6d, 0x4e, 0x2c, 0x7b, 0x8d, 0x5a, 0xc9, 0x29, 0x35, 0x67, 0x7d, 0xad,
-	0xbb, 0x22, 0xb0, 0xff, 0x12, 0xfc, 0x35, 0x2a, 0xb9, 0x7e, 0xe6, 0xa5, 0xb5, 0x41, 0x26, 0x56,
-	0x1b, 0xab, 0x74, 0x95, 0xd4, 0x5a, 0x60, 0xd2, 0x44, 0x42, 0xc8, 0x4e, 0x3a, 0x20, 0x99, 0xe9,
-	0x00, 0x4b, 0x00, 0x55, 0x9a, 0x04, 0xc0, 0x42, 0x05, 0xc8, 0xa4, 0x32, 0x0c, 0xde, 0x0a, 0x34,
-	0x1e, 0x9d, 0x22, 0x93, 0xa7, 0x25, 0xe7, 0xbf, 0xe4, 0x1e, 0x59, 0x18, 0x9a, 0xc1, 0x76, 0x54,
-	0x90, 0x66, 0xd6, 0x59, 0x76, 0x7a, 0x63, 0xbc, 0x35, 0x1c, 0x85, 0x01, 0x1e, 0xaa, 0x00, 0xf9,
-	0x4b, 0x36, 0x52, 0x2a, 0xec, 0xa5, 0x8f, 0x22, 0xae, 0xdb, 0xdf, 0x4a, 0xe5, 0x8c, 0xdf, 0xd2,
-	0xb7, 0xa7, 0xec, 0x65, 0xaf, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x05, 0x01, 0x35, 0x43, 0x67,
-	0x05, 0x00, 0x00,
+	// 1169 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xcf, 0x6e, 0xdb, 0xc6,
+	0x13, 0x06, 0xa5, 0xc4, 0xb1, 0xc6, 0xf9, 0x39, 0xce, 0xda, 0x4e, 0x14, 0xf9, 0xe7, 0x84, 0xa5,
+	0x8b, 0xc6, 0x91, 0x23, 0xad, 0x23, 0xdb, 0x85, 0xa1, 0xa0, 0x80, 0x9d, 0xd8, 0x49, 0x8d, 0xa6,
+	0x6d, 0x4a, 0xb7, 0x0d, 0xd0, 0x43, 0x02, 0x8a, 0x5c, 0x53, 0xac, 0x25, 0x2e, 0xcd, 0x5d, 0xda,
+	0x31, 0x82, 0xa0, 0x45, 0x0f, 0xbd, 0xf4, 0xd6, 0x02, 0x3d, 0xe4, 0x11, 0x7a, 0xec, 0xab, 0xf4,
+	0xd4, 0x7b, 0x5f, 0xa2, 0xb7, 0x82, 0xcb, 0xa5, 0x44, 0x52, 0x14, 0x6d, 0x23, 0x27, 0x81, 0x3b,
+	0x7f, 0xbe, 0x6f, 0x66, 0x67, 0xbe, 0x85, 0x00, 0x19, 0x9e, 0x83, 0x0f, 0x7c, 0xea, 0x72, 0xe2,
+	0x5a, 0x4d, 0xcf, 0xa7, 0x9c, 0xa2, 0x0a, 0xf5, 0x88, 0xdb, 0x37, 0xb8, 0xd9, 0xad, 0x09, 0x73,
+	0x9f, 0x30, 0x66, 0xd8, 0x84, 0x45, 0xe6, 0xda, 0xff, 0x6d, 0x4a, 0xed, 0x1e, 0xc1, 0xa1, 0xc9,
+	0x70, 0x5d, 0xca, 0x0d, 0xee, 0x50, 0x37, 0xb6, 0xde, 0x17, 0x3f, 0x66, 0xc3, 0x26, 0x6e, 0x83,
+	0x9d, 0x18, 0xb6, 0x4d, 0x7c, 0x4c, 0x3d, 0xe1, 0x91, 0xe3, 0xbd, 0x20, 0x73, 0x89, 0xaf, 0x4e,
+	0x70, 0x80, 0x49, 0xdf, 0xe3, 0xa7, 0xd2, 0x78, 0x3b, 0x6b, 0xb4, 0x02, 0x5f, 0x44, 0x4b, 0xfb,
+	0x4d, 0x69, 0xf7, 0x3d, 0x13, 0x33, 0x6e, 0xf0, 0x40, 0x66, 0xd5, 0x7e, 0x57, 0x60, 0xf6, 0xb1,
+	0x4f, 0x0c, 0x4e, 0xbe, 0x76, 0xcc, 0x43, 0xc2, 0x75, 0x72, 0x14, 0x10, 0xc6, 0xd1, 0x3d, 0x98,
+	0xe0, 0xe2, 0xa0, 0xaa, 0xa8, 0xca, 0xf2, 0x54, 0xeb, 0x7a, 0x73, 0x50, 0x69, 0x53, 0x7a, 0x4a,
+	0x07, 0xb4, 0x02, 0x65, 0xce, 0x7b, 0xd5, 0x92, 0xf0, 0xbb, 0xd5, 0x8c, 0x90, 0x9a, 0x31, 0x93,
+	0xe6, 0x8e, 0x64, 0xa2, 0x87, 0x5e, 0xe8, 0x2e, 0x5c, 0x73, 0x2c, 0xd2, 0xf7, 0x28, 0x27, 0xae,
+	0x79, 0xfa, 0xea, 0x90, 0x9c, 0x56, 0xcb, 0xaa, 0xb2, 0x5c, 0xd1, 0xa7, 0x13, 0xc7, 0x9f, 0x91,
+	0x53, 0xed, 0x39, 0xcc, 0x25, 0x79, 0xb1, 0x98, 0xd8, 0x26, 0x5c, 0x89, 0x70, 0x59, 0x55, 0x51,
+	0xcb, 0xcb, 0x53, 0xad, 0xdb, 0x09, 0x66, 0x39, 0x95, 0xe8, 0xb1, 0xbb, 0xf6, 0x22, 0x5d, 0xe9,
+	0x13, 0xc3, 0xe9, 0x05, 0x3e, 0x41, 0x73, 0x70, 0xd9, 0x71, 0x2d, 0xf2, 0x5a, 0x14, 0x7a, 0x59,
+	0x8f, 0x3e, 0x50, 0x1d, 0x26, 0xa2, 0x3e, 0xc9, 0xba, 0x50, 0x5c, 0x97, 0xef, 0x99, 0xcd, 0x7d,
+	0x61, 0xd1, 0xa5, 0x87, 0xf6, 0xa3, 0x02, 0xf3, 0x19, 0xae, 0xcc, 0xa3, 0x2e, 0x23, 0x68, 0x25,
+	0x4b, 0x36, 0xa7, 0x8d, 0xb1, 0x07, 0x6a, 0xc3, 0xe4, 0x41, 0xc4, 0x29, 0x04, 0x2d, 0x2a, 0x4d,
+	0x52, 0xd7, 0x07, 0xfe, 0x5a, 0x0b, 0x66, 0x77, 0x48, 0x8f, 0x64, 0x6f, 0x71, 0x01, 0x2a, 0x51,
+	0xf6, 0x57, 0x8e, 0x25, 0xea, 0xab, 0xe8, 0x93, 0xd1, 0xc1, 0x9e, 0xa5, 0x6d, 0xc0, 0x5c, 0x32,
+	0x66, 0xd0, 0xe1, 0x45, 0x80, 0x41, 0x50, 0xc4, 0xbb, 0xa2, 0x57, 0xe2, 0x28, 0xa6, 0xbd, 0x4c,
+	0x43, 0xc5, 0x6d, 0x2c, 0x82, 0xba, 0x50, 0x37, 0xf7, 0x61, 0x3e, 0x43, 0x4b, 0x36, 0x33, 0xd9,
+	0x9f, 0xd1, 0xab, 0xcf, 0xe1, 0x94, 0xe8, 0xcf, 0x16, 0xcc, 0x7e, 0xe3, 0x59, 0xef, 0x31, 0xe5,
+	0x1a, 0x86, 0x99, 0xa7, 0x84, 0x5f, 0xa0, 0xbd, 0x1f, 0xc3, 0xcd, 0x17, 0x61, 0xa2, 0x6d, 0xc6,
+	0x1c, 0xdb, 0xed, 0x13, 0x77, 0xd8, 0xe1, 0xc2, 0xb8, 0xaf, 0xa0, 0x3a, 0x1a, 0x27, 0x5b, 0xb0,
+	0x01, 0x60, 0x0c, 0x8e, 0x25, 0xe7, 0xf9, 0x04, 0xe7, 0x61, 0x8c, 0x9e, 0x70, 0xd4, 0x5e, 0x42,
+	0x75, 0x9f, 0xfb, 0xc4, 0xe8, 0xe7, 0x70, 0x29, 0xbe, 0x6d, 0xb4, 0x04, 0x97, 0x3c, 0x4a, 0xe3,
+	0xed, 0xbe, 0x96, 0xc0, 0x7a, 0x4e, 0x69, 0x4f, 0x17, 0x46, 0x8d, 0xc2, 0xad, 0x9c, 0xfc, 0x92,
+	0x73, 0xe1, 0x60, 0xa4, 0x0b, 0x2a, 0x9d, 0xb7, 0xa0, 0x4f, 0xe3, 0x85, 0x7b, 0x64, 0x98, 0x87,
+	0x07, 0x4e, 0xaf, 0x17, 0x57, 0x83, 0x61, 0xb2, 0x23, 0x8f, 0x64, 0x7b, 0x66, 0x13, 0xd9, 0x06,
+	0xde, 0x03, 0xa7, 0x30, 0x53, 0x34, 0x18, 0xef, 0x9d, 0x69, 0x33, 0x9e, 0xdb, 0x6c, 0xa6, 0x3b,
+	0x30, 0x15, 0x3b, 0x0d, 0x5b, 0x00, 0xf1, 0x91, 0x58, 0x44, 0xf4, 0x94, 0xf0, 0x8b, 0x86, 0xb5,
+	0xfe, 0x9d, 0x82, 0x6b, 0x4f, 0xe4, 0x73, 0xb4, 0x4f, 0xfc, 0x63, 0xc7, 0x24, 0xc8, 0x81, 0xab,
+	0x49, 0xa1, 0x40, 0x67, 0x88, 0x63, 0x6d, 0x74, 0xe0, 0xb5, 0x8f, 0x7e, 0xfa, 0xeb, 0x9f, 0xdf,
+	0x4a, 0xaa, 0xb6, 0x80, 0x8f, 0x1f, 0x0c, 0x9e, 0x3b, 0x16, 0xe5, 0xc7, 0x52, 0xab, 0xda, 0x4a,
+	0x1d, 0x9d, 0xc0, 0xd5, 0xe4, 0xce, 0xa1, 0x71, 0xcb, 0x18, 0x43, 0xdd, 0x18, 0x79, 0x19, 0x76,
+	0xc3, 0x07, 0x4c, 0xc3, 0x02, 0xef, 0x5e, 0xfd, 0x6e, 0x01, 0x1e, 0x7e, 0x33, 0x18, 0xa1, 0xb7,
+	0xe8, 0x67, 0x05, 0xfe, 0x97, 0x92, 0x5b, 0x74, 0x67, 0x4c, 0x95, 0xf1, 0x90, 0xd7, 0xd4, 0xf1,
+	0x0e, 0xd1, 0x94, 0x6a, 0x2d, 0xc1, 0xe2, 0xbe, 0x56, 0xc4, 0xa2, 0xdd, 0x09, 0x13, 0x98, 0x22,
+	0x3e, 0xec, 0x40, 0x48, 0x24, 0x25, 0x55, 0x29, 0x22, 0x79, 0xda, 0x9a, 0x22, 0x92, 0xab, 0x72,
+	0x17, 0x20, 0x62, 0x89, 0xf8, 0x90, 0x88, 0x03, 0x57, 0x93, 0xea, 0x96, 0xba, 0x8a, 0x1c, 0xd9,
+	0x2b, 0xb8, 0xf5, 0xd6, 0x59, 0xb7, 0xde, 0x83, 0xca, 0x40, 0x06, 0xd1, 0x42, 0x22, 0x4f, 0x56,
+	0x1c, 0xf3, 0x40, 0xe4, 0x55, 0xa3, 0x73, 0x5f, 0xf5, 0x3b, 0x05, 0x66, 0xb2, 0x62, 0x88, 0xb4,
+	0x44, 0xe2, 0x31, 0x0a, 0x5b, 0x5b, 0x2a, 0xf4, 0x91, 0xad, 0x7e, 0x28, 0xe8, 0x6c, 0xa0, 0xb5,
+	0x73, 0xd2, 0xc1, 0x43, 0x05, 0x62, 0xab, 0x4a, 0x48, 0xee, 0xfa, 0x88, 0xec, 0xa1, 0x24, 0xf2,
+	0x38, 0xd1, 0xad, 0x7d, 0x58, 0xec, 0x24, 0xf9, 0x6d, 0x0a, 0x7e, 0x2d, 0xad, 0x51, 0x74, 0x27,
+	0x4c, 0x84, 0x27, 0x98, 0xb5, 0x95, 0xfa, 0xaa, 0x82, 0x8e, 0x60, 0x3a, 0xad, 0x90, 0x68, 0x74,
+	0x07, 0x32, 0x8a, 0x53, 0xcb, 0x13, 0x38, 0x6d, 0x59, 0x90, 0xd0, 0xb4, 0xc5, 0x3c, 0x12, 0xb1,
+	0x1a, 0x89, 0xd1, 0x38, 0x82, 0xe9, 0xb4, 0x94, 0xa6, 0x20, 0x73, 0x55, 0xb6, 0x10, 0xb2, 0x75,
+	0x36, 0xe4, 0x0f, 0x30, 0x9d, 0xd6, 0x5c, 0x34, 0xba, 0x60, 0x59, 0xc8, 0x71, 0x3a, 0xb4, 0x26,
+	0x50, 0x1b, 0xf5, 0x95, 0x42, 0x54, 0xfc, 0x26, 0x21, 0xca, 0x6f, 0xd1, 0x31, 0x4c, 0x25, 0xa4,
+	0x1b, 0x2d, 0xa6, 0x17, 0xe2, 0x5c, 0xd5, 0x4a, 0x5c, 0x74, 0x11, 0xdc, 0x47, 0xbf, 0x94, 0x7f,
+	0xdd, 0xfe, 0xbb, 0x84, 0xfe, 0x54, 0x60, 0x32, 0x7e, 0x02, 0xb4, 0x3d, 0x80, 0x2f, 0x3d, 0xe2,
+	0xaa, 0x9f, 0x87, 0xf9, 0xd1, 0x8d, 0x2e, 0xe7, 0x1e, 0x6b, 0x63, 0x1c, 0x42, 0x36, 0x22, 0x4c,
+	0x8b, 0x1c, 0xd7, 0x96, 0x86, 0xdf, 0x0d, 0xcb, 0x61, 0x66, 0xc0, 0xd8, 0x56, 0xd4, 0x10, 0xdb,
+	0xa7, 0x81, 0xc7, 0x9a, 0x26, 0xed, 0xd7, 0xbf, 0x05, 0xb4, 0xed, 0x19, 0x66, 0x97, 0xa8, 0xad,
+	0xe6, 0xaa, 0xfa, 0xcc, 0x31, 0x49, 0xf8, 0x92, 0x6f, 0xc5, 0x29, 0x6d, 0x87, 0x77, 0x83, 0x4e,
+	0xe8, 0x89, 0xa3, 0xd0, 0x03, 0xea, 0xdb, 0x46, 0x9f, 0xb0, 0x04, 0x18, 0xee, 0xf4, 0x68, 0x07,
+	0xf7, 0x0d, 0xc6, 0x89, 0x8f, 0x9f, 0xed, 0x3d, 0xde, 0xfd, 0x62, 0x7f, 0xb7, 0x55, 0x7e, 0xd0,
+	0x5c, 0xad, 0x97, 0x94, 0x52, 0x6b, 0xc6, 0xf0, 0xbc, 0x9e, 0x63, 0x8a, 0x3f, 0x06, 0xf8, 0x7b,
+	0x46, 0xdd, 0xf6, 0xc8, 0x89, 0xfe, 0x10, 0xca, 0xeb, 0xab, 0xeb, 0x68, 0x1d, 0xea, 0x3a, 0xe1,
+	0x81, 0xef, 0x12, 0x4b, 0x3d, 0xe9, 0x12, 0x57, 0xe5, 0x5d, 0xa2, 0xfa, 0x84, 0xd1, 0xc0, 0x37,
+	0x89, 0x6a, 0x51, 0xc2, 0x54, 0x97, 0x72, 0x95, 0xbc, 0x76, 0x18, 0x6f, 0xa2, 0x09, 0xb8, 0xf4,
+	0xae, 0xa4, 0x5c, 0xf1, 0x3f, 0x81, 0xea, 0xb0, 0x19, 0xea, 0x0e, 0x35, 0x83, 0x70, 0x39, 0x44,
+	0x76, 0xf4, 0x41, 0x7e, 0x6b, 0x30, 0x73, 0x38, 0xc1, 0x16, 0x35, 0x19, 0xfe, 0x4e, 0xcd, 0x98,
+	0x12, 0x75, 0x79, 0x87, 0x36, 0xf6, 0x3a, 0x7f, 0x94, 0x2a, 0x61, 0x7e, 0x91, 0xbe, 0x33, 0x21,
+	0x46, 0x69, 0xed, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x9a, 0x27, 0x35, 0x06, 0x22, 0x0e, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -302,11 +942,35 @@ type FrontendServiceClient interface {
 	// DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
 	// The client should delete the Ticket when finished matchmaking with it.
 	DeleteTicket(ctx context.Context, in *DeleteTicketRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateTickets creates multiple Tickets in a single call, as CreateTicket does for each of them.
+	//   - Tickets which fail validation are reported as failures, and the rest are created.
+	CreateTickets(ctx context.Context, in *CreateTicketsRequest, opts ...grpc.CallOption) (*CreateTicketsResponse, error)
+	// DeleteTickets deletes multiple Tickets in a single call, as DeleteTicket does for each of them.
+	DeleteTickets(ctx context.Context, in *DeleteTicketsRequest, opts ...grpc.CallOption) (*DeleteTicketsResponse, error)
+	// UpdateTicket replaces the SearchFields and extensions of an existing Ticket, keeping its id and create time.
+	//   - Tickets which are assigned or pending release cannot be updated.
+	UpdateTicket(ctx context.Context, in *UpdateTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
 	// GetTicket get the Ticket associated with the specified TicketId.
 	GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
 	// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
 	//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
 	WatchAssignments(ctx context.Context, in *WatchAssignmentsRequest, opts ...grpc.CallOption) (FrontendService_WatchAssignmentsClient, error)
+	// StreamAssignments stream back Assignments of all the specified Tickets, over a single stream, as they are updated.
+	//   - Tickets are selected by TicketId, by Pool, or both.
+	//   - The stream is kept open until the client cancels it.
+	StreamAssignments(ctx context.Context, in *StreamAssignmentsRequest, opts ...grpc.CallOption) (FrontendService_StreamAssignmentsClient, error)
+	// CreateBackfill assigns an unique BackfillId to the input Backfill and record it in state storage.
+	// A backfill is considered as ready for matchmaking once it is created.
+	//   - If a BackfillId exists in a Backfill request, an auto-generated BackfillId will override this field.
+	//   - If SearchFields exist in a Backfill, CreateBackfill will also index these fields such that one can query the backfill with query.QueryBackfills function.
+	CreateBackfill(ctx context.Context, in *CreateBackfillRequest, opts ...grpc.CallOption) (*Backfill, error)
+	// UpdateBackfill replaces the SearchFields and extensions of an existing Backfill, and increments its generation.
+	//   - Matches proposed against the previous generation of the Backfill will be rejected.
+	UpdateBackfill(ctx context.Context, in *UpdateBackfillRequest, opts ...grpc.CallOption) (*Backfill, error)
+	// DeleteBackfill immediately stops Open Match from using the Backfill for matchmaking and removes the Backfill from state storage.
+	DeleteBackfill(ctx context.Context, in *DeleteBackfillRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetBackfill get the Backfill associated with the specified BackfillId.
+	GetBackfill(ctx context.Context, in *GetBackfillRequest, opts ...grpc.CallOption) (*Backfill, error)
 }
 
 type frontendServiceClient struct {
@@ -335,6 +999,33 @@ func (c *frontendServiceClient) DeleteTicket(ctx context.Context, in *DeleteTick
 	return out, nil
 }
 
+func (c *frontendServiceClient) CreateTickets(ctx context.Context, in *CreateTicketsRequest, opts ...grpc.CallOption) (*CreateTicketsResponse, error) {
+	out := new(CreateTicketsResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/CreateTickets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) DeleteTickets(ctx context.Context, in *DeleteTicketsRequest, opts ...grpc.CallOption) (*DeleteTicketsResponse, error) {
+	out := new(DeleteTicketsResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/DeleteTickets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) UpdateTicket(ctx context.Context, in *UpdateTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/UpdateTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *frontendServiceClient) GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
 	out := new(Ticket)
 	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/GetTicket", in, out, opts...)
@@ -376,6 +1067,74 @@ func (x *frontendServiceWatchAssignmentsClient) Recv() (*WatchAssignmentsRespons
 	return m, nil
 }
 
+func (c *frontendServiceClient) StreamAssignments(ctx context.Context, in *StreamAssignmentsRequest, opts ...grpc.CallOption) (FrontendService_StreamAssignmentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FrontendService_serviceDesc.Streams[1], "/openmatch.FrontendService/StreamAssignments", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &frontendServiceStreamAssignmentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FrontendService_StreamAssignmentsClient interface {
+	Recv() (*StreamAssignmentsResponse, error)
+	grpc.ClientStream
+}
+
+type frontendServiceStreamAssignmentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *frontendServiceStreamAssignmentsClient) Recv() (*StreamAssignmentsResponse, error) {
+	m := new(StreamAssignmentsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *frontendServiceClient) CreateBackfill(ctx context.Context, in *CreateBackfillRequest, opts ...grpc.CallOption) (*Backfill, error) {
+	out := new(Backfill)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/CreateBackfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) UpdateBackfill(ctx context.Context, in *UpdateBackfillRequest, opts ...grpc.CallOption) (*Backfill, error) {
+	out := new(Backfill)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/UpdateBackfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) DeleteBackfill(ctx context.Context, in *DeleteBackfillRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/DeleteBackfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) GetBackfill(ctx context.Context, in *GetBackfillRequest, opts ...grpc.CallOption) (*Backfill, error) {
+	out := new(Backfill)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/GetBackfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FrontendServiceServer is the server API for FrontendService service.
 type FrontendServiceServer interface {
 	// CreateTicket assigns an unique TicketId to the input Ticket and record it in state storage.
@@ -386,11 +1145,35 @@ type FrontendServiceServer interface {
 	// DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
 	// The client should delete the Ticket when finished matchmaking with it.
 	DeleteTicket(context.Context, *DeleteTicketRequest) (*empty.Empty, error)
+	// CreateTickets creates multiple Tickets in a single call, as CreateTicket does for each of them.
+	//   - Tickets which fail validation are reported as failures, and the rest are created.
+	CreateTickets(context.Context, *CreateTicketsRequest) (*CreateTicketsResponse, error)
+	// DeleteTickets deletes multiple Tickets in a single call, as DeleteTicket does for each of them.
+	DeleteTickets(context.Context, *DeleteTicketsRequest) (*DeleteTicketsResponse, error)
+	// UpdateTicket replaces the SearchFields and extensions of an existing Ticket, keeping its id and create time.
+	//   - Tickets which are assigned or pending release cannot be updated.
+	UpdateTicket(context.Context, *UpdateTicketRequest) (*Ticket, error)
 	// GetTicket get the Ticket associated with the specified TicketId.
 	GetTicket(context.Context, *GetTicketRequest) (*Ticket, error)
 	// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
 	//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
 	WatchAssignments(*WatchAssignmentsRequest, FrontendService_WatchAssignmentsServer) error
+	// StreamAssignments stream back Assignments of all the specified Tickets, over a single stream, as they are updated.
+	//   - Tickets are selected by TicketId, by Pool, or both.
+	//   - The stream is kept open until the client cancels it.
+	StreamAssignments(*StreamAssignmentsRequest, FrontendService_StreamAssignmentsServer) error
+	// CreateBackfill assigns an unique BackfillId to the input Backfill and record it in state storage.
+	// A backfill is considered as ready for matchmaking once it is created.
+	//   - If a BackfillId exists in a Backfill request, an auto-generated BackfillId will override this field.
+	//   - If SearchFields exist in a Backfill, CreateBackfill will also index these fields such that one can query the backfill with query.QueryBackfills function.
+	CreateBackfill(context.Context, *CreateBackfillRequest) (*Backfill, error)
+	// UpdateBackfill replaces the SearchFields and extensions of an existing Backfill, and increments its generation.
+	//   - Matches proposed against the previous generation of the Backfill will be rejected.
+	UpdateBackfill(context.Context, *UpdateBackfillRequest) (*Backfill, error)
+	// DeleteBackfill immediately stops Open Match from using the Backfill for matchmaking and removes the Backfill from state storage.
+	DeleteBackfill(context.Context, *DeleteBackfillRequest) (*empty.Empty, error)
+	// GetBackfill get the Backfill associated with the specified BackfillId.
+	GetBackfill(context.Context, *GetBackfillRequest) (*Backfill, error)
 }
 
 // UnimplementedFrontendServiceServer can be embedded to have forward compatible implementations.
@@ -398,16 +1181,40 @@ type UnimplementedFrontendServiceServer struct {
 }
 
 func (*UnimplementedFrontendServiceServer) CreateTicket(ctx context.Context, req *CreateTicketRequest) (*Ticket, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTicket not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateTicket not implemented")
 }
 func (*UnimplementedFrontendServiceServer) DeleteTicket(ctx context.Context, req *DeleteTicketRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTicket not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteTicket not implemented")
+}
+func (*UnimplementedFrontendServiceServer) CreateTickets(ctx context.Context, req *CreateTicketsRequest) (*CreateTicketsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateTickets not implemented")
+}
+func (*UnimplementedFrontendServiceServer) DeleteTickets(ctx context.Context, req *DeleteTicketsRequest) (*DeleteTicketsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteTickets not implemented")
+}
+func (*UnimplementedFrontendServiceServer) UpdateTicket(ctx context.Context, req *UpdateTicketRequest) (*Ticket, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method UpdateTicket not implemented")
 }
 func (*UnimplementedFrontendServiceServer) GetTicket(ctx context.Context, req *GetTicketRequest) (*Ticket, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTicket not implemented")
+	return nil, status1.Errorf(codes.Unimplemented, "method GetTicket not implemented")
 }
 func (*UnimplementedFrontendServiceServer) WatchAssignments(req *WatchAssignmentsRequest, srv FrontendService_WatchAssignmentsServer) error {
-	return status.Errorf(codes.Unimplemented, "method WatchAssignments not implemented")
+	return status1.Errorf(codes.Unimplemented, "method WatchAssignments not implemented")
+}
+func (*UnimplementedFrontendServiceServer) StreamAssignments(req *StreamAssignmentsRequest, srv FrontendService_StreamAssignmentsServer) error {
+	return status1.Errorf(codes.Unimplemented, "method StreamAssignments not implemented")
+}
+func (*UnimplementedFrontendServiceServer) CreateBackfill(ctx context.Context, req *CreateBackfillRequest) (*Backfill, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateBackfill not implemented")
+}
+func (*UnimplementedFrontendServiceServer) UpdateBackfill(ctx context.Context, req *UpdateBackfillRequest) (*Backfill, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method UpdateBackfill not implemented")
+}
+func (*UnimplementedFrontendServiceServer) DeleteBackfill(ctx context.Context, req *DeleteBackfillRequest) (*empty.Empty, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteBackfill not implemented")
+}
+func (*UnimplementedFrontendServiceServer) GetBackfill(ctx context.Context, req *GetBackfillRequest) (*Backfill, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GetBackfill not implemented")
 }
 
 func RegisterFrontendServiceServer(s *grpc.Server, srv FrontendServiceServer) {
@@ -450,6 +1257,60 @@ func _FrontendService_DeleteTicket_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FrontendService_CreateTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).CreateTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/CreateTickets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).CreateTickets(ctx, req.(*CreateTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_DeleteTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).DeleteTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/DeleteTickets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).DeleteTickets(ctx, req.(*DeleteTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_UpdateTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).UpdateTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/UpdateTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).UpdateTicket(ctx, req.(*UpdateTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FrontendService_GetTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTicketRequest)
 	if err := dec(in); err != nil {
@@ -489,6 +1350,99 @@ func (x *frontendServiceWatchAssignmentsServer) Send(m *WatchAssignmentsResponse
 	return x.ServerStream.SendMsg(m)
 }
 
+func _FrontendService_StreamAssignments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAssignmentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FrontendServiceServer).StreamAssignments(m, &frontendServiceStreamAssignmentsServer{stream})
+}
+
+type FrontendService_StreamAssignmentsServer interface {
+	Send(*StreamAssignmentsResponse) error
+	grpc.ServerStream
+}
+
+type frontendServiceStreamAssignmentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *frontendServiceStreamAssignmentsServer) Send(m *StreamAssignmentsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FrontendService_CreateBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).CreateBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/CreateBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).CreateBackfill(ctx, req.(*CreateBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_UpdateBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).UpdateBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/UpdateBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).UpdateBackfill(ctx, req.(*UpdateBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_DeleteBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).DeleteBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/DeleteBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).DeleteBackfill(ctx, req.(*DeleteBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_GetBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).GetBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/GetBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).GetBackfill(ctx, req.(*GetBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FrontendService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.FrontendService",
 	HandlerType: (*FrontendServiceServer)(nil),
@@ -501,10 +1455,38 @@ var _FrontendService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTicket",
 			Handler:    _FrontendService_DeleteTicket_Handler,
 		},
+		{
+			MethodName: "CreateTickets",
+			Handler:    _FrontendService_CreateTickets_Handler,
+		},
+		{
+			MethodName: "DeleteTickets",
+			Handler:    _FrontendService_DeleteTickets_Handler,
+		},
+		{
+			MethodName: "UpdateTicket",
+			Handler:    _FrontendService_UpdateTicket_Handler,
+		},
 		{
 			MethodName: "GetTicket",
 			Handler:    _FrontendService_GetTicket_Handler,
 		},
+		{
+			MethodName: "CreateBackfill",
+			Handler:    _FrontendService_CreateBackfill_Handler,
+		},
+		{
+			MethodName: "UpdateBackfill",
+			Handler:    _FrontendService_UpdateBackfill_Handler,
+		},
+		{
+			MethodName: "DeleteBackfill",
+			Handler:    _FrontendService_DeleteBackfill_Handler,
+		},
+		{
+			MethodName: "GetBackfill",
+			Handler:    _FrontendService_GetBackfill_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -512,6 +1494,11 @@ var _FrontendService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _FrontendService_WatchAssignments_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamAssignments",
+			Handler:       _FrontendService_StreamAssignments_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/frontend.proto",
 }
@@ -45,7 +45,11 @@ type Ticket struct {
 	Extensions map[string]*any.Any `protobuf:"bytes,5,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Create time is the time the Ticket was created. It is populated by Open
 	// Match at the time of Ticket creation.
-	CreateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Expire time is the time after which the Ticket is automatically deindexed
+	// and deleted by Open Match. It is populated by Open Match at the time of
+	// Ticket creation when a TTL applies, and unset otherwise.
+	ExpireTime           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -111,6 +115,13 @@ func (m *Ticket) GetCreateTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Ticket) GetExpireTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return nil
+}
+
 // Search fields are the fields which Open Match is aware of, and can be used
 // when specifying filters.
 type SearchFields struct {
@@ -171,6 +182,93 @@ func (m *SearchFields) GetTags() []string {
 	return nil
 }
 
+// A Backfill represents a partially filled match, such as a running game
+// server which has open slots for new players. Backfills are matched against
+// tickets the same way tickets are matched against each other, and a Match may
+// update a Backfill to claim some of its open slots.
+type Backfill struct {
+	// Id represents an auto-generated Id issued by Open Match.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Search fields are the fields which Open Match is aware of, and can be used
+	// when specifying filters.
+	SearchFields *SearchFields `protobuf:"bytes,2,opt,name=search_fields,json=searchFields,proto3" json:"search_fields,omitempty"`
+	// Customized information not inspected by Open Match, to be used by the match
+	// making function, evaluator, and components making calls to Open Match.
+	// Optional, depending on the requirements of the connected systems.
+	Extensions map[string]*any.Any `protobuf:"bytes,3,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Create time is the time the Backfill was created. It is populated by Open
+	// Match at the time of Backfill creation.
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Generation of the Backfill. It is populated by Open Match and incremented
+	// each time the Backfill is updated. A Match which updates a Backfill is only
+	// accepted if the Backfill's generation hasn't changed since the match
+	// function read it.
+	Generation           int64    `protobuf:"varint,5,opt,name=generation,proto3" json:"generation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Backfill) Reset()         { *m = Backfill{} }
+func (m *Backfill) String() string { return proto.CompactTextString(m) }
+func (*Backfill) ProtoMessage()    {}
+func (*Backfill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{2}
+}
+
+func (m *Backfill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backfill.Unmarshal(m, b)
+}
+func (m *Backfill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backfill.Marshal(b, m, deterministic)
+}
+func (m *Backfill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backfill.Merge(m, src)
+}
+func (m *Backfill) XXX_Size() int {
+	return xxx_messageInfo_Backfill.Size(m)
+}
+func (m *Backfill) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backfill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backfill proto.InternalMessageInfo
+
+func (m *Backfill) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Backfill) GetSearchFields() *SearchFields {
+	if m != nil {
+		return m.SearchFields
+	}
+	return nil
+}
+
+func (m *Backfill) GetExtensions() map[string]*any.Any {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+func (m *Backfill) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *Backfill) GetGeneration() int64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
 // An Assignment represents a game server assignment associated with a Ticket.
 // Open Match does not require or inspect any fields on assignment.
 type Assignment struct {
@@ -189,7 +287,7 @@ func (m *Assignment) Reset()         { *m = Assignment{} }
 func (m *Assignment) String() string { return proto.CompactTextString(m) }
 func (*Assignment) ProtoMessage()    {}
 func (*Assignment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{2}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{3}
 }
 
 func (m *Assignment) XXX_Unmarshal(b []byte) error {
@@ -253,7 +351,7 @@ func (m *DoubleRangeFilter) Reset()         { *m = DoubleRangeFilter{} }
 func (m *DoubleRangeFilter) String() string { return proto.CompactTextString(m) }
 func (*DoubleRangeFilter) ProtoMessage()    {}
 func (*DoubleRangeFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{3}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{4}
 }
 
 func (m *DoubleRangeFilter) XXX_Unmarshal(b []byte) error {
@@ -317,7 +415,7 @@ func (m *StringEqualsFilter) Reset()         { *m = StringEqualsFilter{} }
 func (m *StringEqualsFilter) String() string { return proto.CompactTextString(m) }
 func (*StringEqualsFilter) ProtoMessage()    {}
 func (*StringEqualsFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{4}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{5}
 }
 
 func (m *StringEqualsFilter) XXX_Unmarshal(b []byte) error {
@@ -352,6 +450,123 @@ func (m *StringEqualsFilter) GetValue() string {
 	return ""
 }
 
+// Filters strings present and not equaling a value.
+//   string_arg: "foo"
+//   value: "bar"
+// matches:
+//   {"foo": "baz"}
+// does not match:
+//   {"foo": "bar"}
+//   {"bar": "foo"}
+//   {}
+type StringNotEqualsFilter struct {
+	// Name of the ticket's search_fields.string_args this Filter operates on.
+	StringArg            string   `protobuf:"bytes,1,opt,name=string_arg,json=stringArg,proto3" json:"string_arg,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StringNotEqualsFilter) Reset()         { *m = StringNotEqualsFilter{} }
+func (m *StringNotEqualsFilter) String() string { return proto.CompactTextString(m) }
+func (*StringNotEqualsFilter) ProtoMessage()    {}
+func (*StringNotEqualsFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{6}
+}
+
+func (m *StringNotEqualsFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StringNotEqualsFilter.Unmarshal(m, b)
+}
+func (m *StringNotEqualsFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StringNotEqualsFilter.Marshal(b, m, deterministic)
+}
+func (m *StringNotEqualsFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringNotEqualsFilter.Merge(m, src)
+}
+func (m *StringNotEqualsFilter) XXX_Size() int {
+	return xxx_messageInfo_StringNotEqualsFilter.Size(m)
+}
+func (m *StringNotEqualsFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringNotEqualsFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringNotEqualsFilter proto.InternalMessageInfo
+
+func (m *StringNotEqualsFilter) GetStringArg() string {
+	if m != nil {
+		return m.StringArg
+	}
+	return ""
+}
+
+func (m *StringNotEqualsFilter) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// Filters strings exactly equaling any of a set of values.
+//   string_arg: "foo"
+//   values: ["bar", "baz"]
+// matches:
+//   {"foo": "bar"}
+//   {"foo": "baz"}
+// does not match:
+//   {"foo": "qux"}
+//   {"bar": "foo"}
+//   {}
+type StringInFilter struct {
+	// Name of the ticket's search_fields.string_args this Filter operates on.
+	StringArg string `protobuf:"bytes,1,opt,name=string_arg,json=stringArg,proto3" json:"string_arg,omitempty"`
+	// Values the string arg may equal.  Pools with a StringInFilter which has no
+	// values are invalid.
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StringInFilter) Reset()         { *m = StringInFilter{} }
+func (m *StringInFilter) String() string { return proto.CompactTextString(m) }
+func (*StringInFilter) ProtoMessage()    {}
+func (*StringInFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{7}
+}
+
+func (m *StringInFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StringInFilter.Unmarshal(m, b)
+}
+func (m *StringInFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StringInFilter.Marshal(b, m, deterministic)
+}
+func (m *StringInFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringInFilter.Merge(m, src)
+}
+func (m *StringInFilter) XXX_Size() int {
+	return xxx_messageInfo_StringInFilter.Size(m)
+}
+func (m *StringInFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringInFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringInFilter proto.InternalMessageInfo
+
+func (m *StringInFilter) GetStringArg() string {
+	if m != nil {
+		return m.StringArg
+	}
+	return ""
+}
+
+func (m *StringInFilter) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // Filters to the tag being present on the search_fields.
 //   tag: "foo"
 // matches:
@@ -371,7 +586,7 @@ func (m *TagPresentFilter) Reset()         { *m = TagPresentFilter{} }
 func (m *TagPresentFilter) String() string { return proto.CompactTextString(m) }
 func (*TagPresentFilter) ProtoMessage()    {}
 func (*TagPresentFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{5}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{8}
 }
 
 func (m *TagPresentFilter) XXX_Unmarshal(b []byte) error {
@@ -399,6 +614,53 @@ func (m *TagPresentFilter) GetTag() string {
 	return ""
 }
 
+// Filters to the tag being absent from the search_fields.
+//   tag: "foo"
+// matches:
+//   ["bar"]
+//   []
+// does not match:
+//   ["foo"]
+//   ["bar","foo"]
+type TagAbsentFilter struct {
+	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagAbsentFilter) Reset()         { *m = TagAbsentFilter{} }
+func (m *TagAbsentFilter) String() string { return proto.CompactTextString(m) }
+func (*TagAbsentFilter) ProtoMessage()    {}
+func (*TagAbsentFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{9}
+}
+
+func (m *TagAbsentFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagAbsentFilter.Unmarshal(m, b)
+}
+func (m *TagAbsentFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagAbsentFilter.Marshal(b, m, deterministic)
+}
+func (m *TagAbsentFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagAbsentFilter.Merge(m, src)
+}
+func (m *TagAbsentFilter) XXX_Size() int {
+	return xxx_messageInfo_TagAbsentFilter.Size(m)
+}
+func (m *TagAbsentFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagAbsentFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagAbsentFilter proto.InternalMessageInfo
+
+func (m *TagAbsentFilter) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
 // Pool specfies a set of criteria that are used to select a subset of Tickets
 // that meet all the criteria.
 type Pool struct {
@@ -412,17 +674,20 @@ type Pool struct {
 	// If specified, only Tickets created before the specified time are selected.
 	CreatedBefore *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
 	// If specified, only Tickets created after the specified time are selected.
-	CreatedAfter         *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	CreatedAfter           *timestamp.Timestamp     `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	TagAbsentFilters       []*TagAbsentFilter       `protobuf:"bytes,8,rep,name=tag_absent_filters,json=tagAbsentFilters,proto3" json:"tag_absent_filters,omitempty"`
+	StringNotEqualsFilters []*StringNotEqualsFilter `protobuf:"bytes,9,rep,name=string_not_equals_filters,json=stringNotEqualsFilters,proto3" json:"string_not_equals_filters,omitempty"`
+	StringInFilters        []*StringInFilter        `protobuf:"bytes,10,rep,name=string_in_filters,json=stringInFilters,proto3" json:"string_in_filters,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
 }
 
 func (m *Pool) Reset()         { *m = Pool{} }
 func (m *Pool) String() string { return proto.CompactTextString(m) }
 func (*Pool) ProtoMessage()    {}
 func (*Pool) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{6}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{10}
 }
 
 func (m *Pool) XXX_Unmarshal(b []byte) error {
@@ -485,6 +750,27 @@ func (m *Pool) GetCreatedAfter() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Pool) GetTagAbsentFilters() []*TagAbsentFilter {
+	if m != nil {
+		return m.TagAbsentFilters
+	}
+	return nil
+}
+
+func (m *Pool) GetStringNotEqualsFilters() []*StringNotEqualsFilter {
+	if m != nil {
+		return m.StringNotEqualsFilters
+	}
+	return nil
+}
+
+func (m *Pool) GetStringInFilters() []*StringInFilter {
+	if m != nil {
+		return m.StringInFilters
+	}
+	return nil
+}
+
 // A MatchProfile is Open Match's representation of a Match specification. It is
 // used to indicate the criteria for selecting players for a match. A
 // MatchProfile is the input to the API to get matches and is passed to the
@@ -508,7 +794,7 @@ func (m *MatchProfile) Reset()         { *m = MatchProfile{} }
 func (m *MatchProfile) String() string { return proto.CompactTextString(m) }
 func (*MatchProfile) ProtoMessage()    {}
 func (*MatchProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{7}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{11}
 }
 
 func (m *MatchProfile) XXX_Unmarshal(b []byte) error {
@@ -567,17 +853,21 @@ type Match struct {
 	// Customized information not inspected by Open Match, to be used by the match
 	// making function, evaluator, and components making calls to Open Match.
 	// Optional, depending on the requirements of the connected systems.
-	Extensions           map[string]*any.Any `protobuf:"bytes,7,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Extensions map[string]*any.Any `protobuf:"bytes,7,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Backfill updated by this match. If the Backfill's id is empty, a new
+	// Backfill is created. Otherwise the Backfill's generation must match the
+	// stored generation, or the match is rejected. Optional.
+	Backfill             *Backfill `protobuf:"bytes,8,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *Match) Reset()         { *m = Match{} }
 func (m *Match) String() string { return proto.CompactTextString(m) }
 func (*Match) ProtoMessage()    {}
 func (*Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb9fb1f207fd5b8c, []int{8}
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{12}
 }
 
 func (m *Match) XXX_Unmarshal(b []byte) error {
@@ -633,17 +923,29 @@ func (m *Match) GetExtensions() map[string]*any.Any {
 	return nil
 }
 
+func (m *Match) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Ticket)(nil), "openmatch.Ticket")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.Ticket.ExtensionsEntry")
 	proto.RegisterType((*SearchFields)(nil), "openmatch.SearchFields")
 	proto.RegisterMapType((map[string]float64)(nil), "openmatch.SearchFields.DoubleArgsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "openmatch.SearchFields.StringArgsEntry")
+	proto.RegisterType((*Backfill)(nil), "openmatch.Backfill")
+	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.Backfill.ExtensionsEntry")
 	proto.RegisterType((*Assignment)(nil), "openmatch.Assignment")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.Assignment.ExtensionsEntry")
 	proto.RegisterType((*DoubleRangeFilter)(nil), "openmatch.DoubleRangeFilter")
 	proto.RegisterType((*StringEqualsFilter)(nil), "openmatch.StringEqualsFilter")
+	proto.RegisterType((*StringNotEqualsFilter)(nil), "openmatch.StringNotEqualsFilter")
+	proto.RegisterType((*StringInFilter)(nil), "openmatch.StringInFilter")
 	proto.RegisterType((*TagPresentFilter)(nil), "openmatch.TagPresentFilter")
+	proto.RegisterType((*TagAbsentFilter)(nil), "openmatch.TagAbsentFilter")
 	proto.RegisterType((*Pool)(nil), "openmatch.Pool")
 	proto.RegisterType((*MatchProfile)(nil), "openmatch.MatchProfile")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.MatchProfile.ExtensionsEntry")
@@ -654,57 +956,69 @@ func init() {
 func init() { proto.RegisterFile("api/messages.proto", fileDescriptor_cb9fb1f207fd5b8c) }
 
 var fileDescriptor_cb9fb1f207fd5b8c = []byte{
-	// 830 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0x56, 0x6c, 0x27, 0x69, 0x4e, 0xd2, 0xd6, 0x9d, 0xed, 0x6a, 0xbd, 0x81, 0x85, 0x60, 0xa8,
-	0xa8, 0x40, 0x38, 0x52, 0x11, 0x12, 0xe2, 0x47, 0x90, 0x15, 0x2d, 0x6c, 0x11, 0x50, 0xdc, 0x8a,
-	0x0b, 0x6e, 0xac, 0x49, 0x3c, 0xf1, 0x5a, 0xb5, 0xc7, 0xc6, 0x33, 0x59, 0x6d, 0xde, 0x83, 0xa7,
-	0xe0, 0x9a, 0x6b, 0x9e, 0x82, 0x87, 0xe0, 0x9e, 0x17, 0x40, 0xf3, 0x63, 0x67, 0xd6, 0x09, 0xbb,
-	0xdc, 0xa0, 0xde, 0xcd, 0x9c, 0x9f, 0x6f, 0xce, 0xf9, 0xce, 0xe7, 0x63, 0x40, 0xb8, 0x4c, 0xa7,
-	0x39, 0x61, 0x0c, 0x27, 0x84, 0x05, 0x65, 0x55, 0xf0, 0x02, 0x0d, 0x8a, 0x92, 0xd0, 0x1c, 0xf3,
-	0xc5, 0xd3, 0xf1, 0x83, 0xa4, 0x28, 0x92, 0x8c, 0x4c, 0xab, 0x72, 0x31, 0x65, 0x1c, 0xf3, 0x95,
-	0x8e, 0x19, 0x3f, 0xd4, 0x0e, 0x79, 0x9b, 0xaf, 0x96, 0x53, 0x4c, 0xd7, 0xda, 0xf5, 0x66, 0xdb,
-	0xc5, 0xd3, 0x9c, 0x30, 0x8e, 0xf3, 0x52, 0x05, 0xf8, 0x7f, 0x59, 0xd0, 0xbb, 0x49, 0x17, 0xb7,
-	0x84, 0xa3, 0x03, 0xb0, 0xd2, 0xd8, 0xeb, 0x4c, 0x3a, 0xa7, 0x83, 0xd0, 0x4a, 0x63, 0xf4, 0x11,
-	0x00, 0x66, 0x2c, 0x4d, 0x68, 0x4e, 0x28, 0xf7, 0xec, 0x49, 0xe7, 0x74, 0x78, 0x76, 0x3f, 0x68,
-	0xea, 0x09, 0x66, 0x8d, 0x33, 0x34, 0x02, 0xd1, 0x67, 0xb0, 0xcf, 0x08, 0xae, 0x16, 0x4f, 0xa3,
-	0x65, 0x4a, 0xb2, 0x98, 0x79, 0x8e, 0xcc, 0x7c, 0x60, 0x64, 0x5e, 0x4b, 0xff, 0x85, 0x74, 0x87,
-	0x23, 0x66, 0xdc, 0xd0, 0x0c, 0x80, 0x3c, 0xe7, 0x84, 0xb2, 0xb4, 0xa0, 0xcc, 0xeb, 0x4e, 0xec,
-	0xd3, 0xe1, 0xd9, 0x5b, 0x46, 0xaa, 0xaa, 0x35, 0x38, 0x6f, 0x62, 0xce, 0x29, 0xaf, 0xd6, 0xa1,
-	0x91, 0x84, 0x3e, 0x85, 0xe1, 0xa2, 0x22, 0x98, 0x93, 0x48, 0x34, 0xeb, 0xf5, 0xe4, 0xf3, 0xe3,
-	0x40, 0x31, 0x11, 0xd4, 0x4c, 0x04, 0x37, 0x35, 0x13, 0x21, 0xa8, 0x70, 0x61, 0x18, 0x5f, 0xc3,
-	0x61, 0x0b, 0x1b, 0xb9, 0x60, 0xdf, 0x92, 0xb5, 0x26, 0x46, 0x1c, 0xd1, 0x7b, 0xd0, 0x7d, 0x86,
-	0xb3, 0x15, 0xf1, 0x2c, 0x89, 0x7d, 0xbc, 0x85, 0x3d, 0xa3, 0xeb, 0x50, 0x85, 0x7c, 0x62, 0x7d,
-	0xdc, 0xb9, 0x74, 0xf6, 0x2c, 0xd7, 0xf6, 0x7f, 0xb7, 0x60, 0x64, 0x76, 0x8e, 0xbe, 0x81, 0x61,
-	0x5c, 0xac, 0xe6, 0x19, 0x89, 0x70, 0x95, 0x30, 0xaf, 0x23, 0x9b, 0x7d, 0xf7, 0x5f, 0x78, 0x0a,
-	0xbe, 0x92, 0xa1, 0xb3, 0x2a, 0xa9, 0x5b, 0x8e, 0x1b, 0x83, 0x40, 0x62, 0xbc, 0x4a, 0x69, 0xa2,
-	0x90, 0xac, 0x97, 0x23, 0x5d, 0xcb, 0x50, 0x03, 0x89, 0x35, 0x06, 0x84, 0xc0, 0xe1, 0x38, 0x61,
-	0x9e, 0x3d, 0xb1, 0x4f, 0x07, 0xa1, 0x3c, 0x8f, 0x3f, 0x87, 0xc3, 0xd6, 0xe3, 0x3b, 0x38, 0x39,
-	0x36, 0x39, 0xe9, 0x18, 0xdd, 0x8b, 0xf4, 0xd6, 0x8b, 0xaf, 0x4a, 0x1f, 0x18, 0xe9, 0xfe, 0x9f,
-	0x1d, 0x80, 0x8d, 0xd4, 0xd0, 0x1b, 0x00, 0x8b, 0x82, 0x52, 0xb2, 0xe0, 0x69, 0x41, 0x35, 0x82,
-	0x61, 0x41, 0xe7, 0x2f, 0x08, 0xc8, 0x91, 0x4c, 0x9c, 0xec, 0x54, 0xed, 0xcb, 0x44, 0xf4, 0x3f,
-	0xea, 0xe0, 0xd2, 0xd9, 0xb3, 0x5d, 0xc7, 0xff, 0x09, 0x8e, 0x14, 0xa9, 0x21, 0xa6, 0x09, 0xb9,
-	0x48, 0x33, 0x4e, 0x2a, 0xf4, 0x08, 0x60, 0xa3, 0x08, 0xfd, 0xd2, 0xa0, 0x99, 0xb3, 0xa8, 0x20,
-	0xc7, 0xcf, 0x35, 0xc3, 0xe2, 0x28, 0x2d, 0x29, 0x95, 0x1f, 0xa7, 0xb0, 0xa4, 0xd4, 0x7f, 0x02,
-	0x48, 0xb1, 0x7d, 0xfe, 0xcb, 0x0a, 0x67, 0x6c, 0x03, 0xbc, 0x11, 0x48, 0x0d, 0xdc, 0x8c, 0x7d,
-	0x37, 0xfb, 0xfe, 0x3b, 0xe0, 0xde, 0xe0, 0xe4, 0xaa, 0x22, 0x8c, 0x50, 0xae, 0x81, 0x5c, 0xb0,
-	0x39, 0xae, 0x11, 0xc4, 0xd1, 0xff, 0xd5, 0x06, 0xe7, 0xaa, 0x28, 0x32, 0x21, 0x1d, 0x8a, 0x73,
-	0xa2, 0x7d, 0xf2, 0x8c, 0xbe, 0x87, 0x63, 0xdd, 0x50, 0x25, 0xda, 0x8c, 0x96, 0x12, 0xa5, 0x56,
-	0xe8, 0xeb, 0xc6, 0x5c, 0xb6, 0xc8, 0x08, 0x51, 0xdc, 0x36, 0x31, 0xf4, 0x23, 0xdc, 0xd7, 0x7d,
-	0x10, 0xd9, 0x5e, 0x03, 0xa8, 0x06, 0xfd, 0xc8, 0x94, 0xfc, 0x16, 0x0b, 0xe1, 0x3d, 0xb6, 0x65,
-	0x63, 0xe8, 0x5b, 0xb8, 0xc7, 0x71, 0x12, 0x95, 0xaa, 0xcd, 0x06, 0x50, 0xad, 0x9e, 0xd7, 0xcc,
-	0xd5, 0xd3, 0xe2, 0x22, 0x3c, 0xe2, 0x2d, 0x8b, 0x58, 0x5f, 0x07, 0x6a, 0x99, 0xc4, 0xd1, 0x9c,
-	0x2c, 0x8b, 0xea, 0xbf, 0xac, 0x9f, 0x7d, 0x9d, 0xf1, 0x58, 0x26, 0xa0, 0x2f, 0xa0, 0x36, 0x44,
-	0x78, 0xc9, 0x49, 0xe5, 0xf5, 0x5f, 0x89, 0x30, 0xd2, 0x09, 0x33, 0x11, 0xaf, 0xf5, 0xf5, 0x77,
-	0x07, 0x46, 0xdf, 0x89, 0xba, 0xaf, 0xaa, 0x62, 0x99, 0x66, 0x64, 0xe7, 0x78, 0x4e, 0xa0, 0x5b,
-	0x16, 0x45, 0xa6, 0x3e, 0xf7, 0xe1, 0xd9, 0xa1, 0xd1, 0xad, 0x18, 0x69, 0xa8, 0xbc, 0xe8, 0xeb,
-	0x1d, 0x4b, 0xd9, 0xdc, 0x2e, 0xe6, 0x3b, 0x77, 0xf7, 0x55, 0x39, 0x6e, 0xd7, 0xff, 0xc3, 0x82,
-	0xae, 0xac, 0x06, 0x3d, 0x84, 0x3d, 0x59, 0x5c, 0xd4, 0xfc, 0xd3, 0xfa, 0xf2, 0xfe, 0x24, 0x46,
-	0x6f, 0xc3, 0xbe, 0x72, 0x95, 0xaa, 0x64, 0xad, 0xfa, 0x51, 0x6e, 0xd2, 0x75, 0x02, 0x07, 0x2a,
-	0x68, 0xb9, 0xa2, 0x6a, 0xd7, 0xd8, 0x32, 0x4a, 0xa5, 0x5e, 0x68, 0x23, 0x7a, 0x1f, 0xfa, 0x5c,
-	0xfe, 0x92, 0x6a, 0x09, 0x1e, 0x6d, 0xfd, 0xac, 0xc2, 0x3a, 0x02, 0x7d, 0xf9, 0x02, 0x8f, 0x7d,
-	0x19, 0x3f, 0x69, 0xf3, 0x78, 0x17, 0x04, 0x76, 0xdd, 0xde, 0xa5, 0xb3, 0xd7, 0x73, 0xfb, 0x8f,
-	0x83, 0x9f, 0x27, 0xa2, 0x9e, 0x0f, 0x54, 0x41, 0x31, 0x79, 0x36, 0xdd, 0x5c, 0xa7, 0xe5, 0x6d,
-	0x32, 0x2d, 0xe7, 0xbf, 0x59, 0x83, 0x1f, 0x4a, 0x42, 0x65, 0xb1, 0xf3, 0x9e, 0x04, 0xfd, 0xf0,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7f, 0x53, 0xe9, 0x37, 0xbc, 0x08, 0x00, 0x00,
+	// 1022 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0x56, 0x6c, 0x27, 0x4d, 0x4e, 0xb3, 0x4d, 0x3a, 0x6d, 0x77, 0xdd, 0xc0, 0x42, 0x70, 0xa9,
+	0x88, 0x40, 0x24, 0xd2, 0x22, 0x24, 0xc4, 0x8f, 0x20, 0x85, 0x74, 0xb7, 0x05, 0x96, 0xe2, 0x56,
+	0x5c, 0xc0, 0x45, 0x34, 0x49, 0x26, 0x5e, 0xab, 0xce, 0xd8, 0x78, 0x26, 0xab, 0xf6, 0x96, 0x77,
+	0xe1, 0x86, 0x6b, 0xde, 0x83, 0x1b, 0xde, 0x83, 0x0b, 0x5e, 0x00, 0xcd, 0x8f, 0x9d, 0x49, 0x1c,
+	0xba, 0xad, 0x50, 0xc5, 0x9d, 0xe7, 0xfc, 0x7c, 0x73, 0xe6, 0x9b, 0x6f, 0xce, 0x31, 0x20, 0x9c,
+	0x84, 0xbd, 0x19, 0x61, 0x0c, 0x07, 0x84, 0x75, 0x93, 0x34, 0xe6, 0x31, 0xaa, 0xc5, 0x09, 0xa1,
+	0x33, 0xcc, 0xc7, 0x2f, 0x5a, 0x8f, 0x82, 0x38, 0x0e, 0x22, 0xd2, 0x4b, 0x93, 0x71, 0x8f, 0x71,
+	0xcc, 0xe7, 0x3a, 0xa6, 0xb5, 0xaf, 0x1d, 0x72, 0x35, 0x9a, 0x4f, 0x7b, 0x98, 0x5e, 0x6b, 0xd7,
+	0x9b, 0xab, 0x2e, 0x1e, 0xce, 0x08, 0xe3, 0x78, 0x96, 0xa8, 0x00, 0xef, 0x57, 0x1b, 0x2a, 0x17,
+	0xe1, 0xf8, 0x92, 0x70, 0xb4, 0x05, 0x56, 0x38, 0x71, 0x4b, 0xed, 0x52, 0xa7, 0xe6, 0x5b, 0xe1,
+	0x04, 0x7d, 0x08, 0x80, 0x19, 0x0b, 0x03, 0x3a, 0x23, 0x94, 0xbb, 0x76, 0xbb, 0xd4, 0xd9, 0x7c,
+	0xb2, 0xd7, 0xcd, 0xeb, 0xe9, 0xf6, 0x73, 0xa7, 0x6f, 0x04, 0xa2, 0x4f, 0xe1, 0x01, 0x23, 0x38,
+	0x1d, 0xbf, 0x18, 0x4e, 0x43, 0x12, 0x4d, 0x98, 0xeb, 0xc8, 0xcc, 0x47, 0x46, 0xe6, 0xb9, 0xf4,
+	0x1f, 0x4b, 0xb7, 0x5f, 0x67, 0xc6, 0x0a, 0xf5, 0x01, 0xc8, 0x15, 0x27, 0x94, 0x85, 0x31, 0x65,
+	0x6e, 0xb9, 0x6d, 0x77, 0x36, 0x9f, 0xbc, 0x65, 0xa4, 0xaa, 0x5a, 0xbb, 0x83, 0x3c, 0x66, 0x40,
+	0x79, 0x7a, 0xed, 0x1b, 0x49, 0xe8, 0x13, 0xd8, 0x1c, 0xa7, 0x04, 0x73, 0x32, 0x14, 0x87, 0x75,
+	0x2b, 0x72, 0xfb, 0x56, 0x57, 0x31, 0xd1, 0xcd, 0x98, 0xe8, 0x5e, 0x64, 0x4c, 0xf8, 0xa0, 0xc2,
+	0x85, 0x41, 0x24, 0x93, 0xab, 0x24, 0x4c, 0x75, 0xf2, 0xc6, 0xab, 0x93, 0x55, 0xb8, 0x30, 0xb4,
+	0xce, 0xa1, 0xb1, 0x52, 0x18, 0x6a, 0x82, 0x7d, 0x49, 0xae, 0x35, 0xab, 0xe2, 0x13, 0xbd, 0x0b,
+	0xe5, 0x97, 0x38, 0x9a, 0x13, 0xd7, 0x92, 0xd8, 0xbb, 0x05, 0xec, 0x3e, 0xbd, 0xf6, 0x55, 0xc8,
+	0xc7, 0xd6, 0x47, 0xa5, 0x53, 0xa7, 0x6a, 0x35, 0x6d, 0xef, 0x77, 0x0b, 0xea, 0x26, 0x6d, 0xe8,
+	0x19, 0x6c, 0x4e, 0xe2, 0xf9, 0x28, 0x22, 0x43, 0x9c, 0x06, 0xcc, 0x2d, 0x49, 0xa6, 0xde, 0xf9,
+	0x17, 0x92, 0xbb, 0x5f, 0xc9, 0xd0, 0x7e, 0x1a, 0x64, 0x7c, 0x4d, 0x72, 0x83, 0x40, 0x62, 0x3c,
+	0x0d, 0x69, 0xa0, 0x90, 0xac, 0x9b, 0x91, 0xce, 0x65, 0xa8, 0x81, 0xc4, 0x72, 0x03, 0x42, 0xe0,
+	0x70, 0x1c, 0x30, 0xd7, 0x6e, 0xdb, 0x9d, 0x9a, 0x2f, 0xbf, 0x5b, 0x9f, 0x41, 0x63, 0x65, 0xf3,
+	0x35, 0x9c, 0xec, 0x9a, 0x9c, 0x94, 0x8c, 0xd3, 0x8b, 0xf4, 0x95, 0x1d, 0x5f, 0x95, 0x5e, 0x33,
+	0xd2, 0xbd, 0x3f, 0x2c, 0xa8, 0x1e, 0xe1, 0xf1, 0xe5, 0x34, 0x8c, 0xa2, 0x82, 0xc0, 0x0b, 0x4a,
+	0xb5, 0xee, 0xa2, 0xd4, 0x2f, 0x97, 0x94, 0x6a, 0x4b, 0xd6, 0x0e, 0x8c, 0xd4, 0x6c, 0xdb, 0xbb,
+	0x68, 0xd5, 0xb9, 0x93, 0x56, 0xdf, 0x00, 0x08, 0x08, 0x25, 0x29, 0xe6, 0x61, 0x4c, 0xdd, 0x72,
+	0xbb, 0xd4, 0xb1, 0x7d, 0xc3, 0x72, 0x2f, 0x72, 0xf4, 0xfe, 0x2c, 0x01, 0x2c, 0x5e, 0xbe, 0xa8,
+	0x61, 0x1c, 0x53, 0x4a, 0xc6, 0xb2, 0x06, 0x85, 0x6b, 0x58, 0xd0, 0x60, 0x89, 0x25, 0x47, 0xb2,
+	0x74, 0xb8, 0xb6, 0x89, 0xdc, 0xc4, 0xd3, 0x3d, 0xbe, 0xac, 0x53, 0xa7, 0x6a, 0x37, 0x1d, 0xef,
+	0x07, 0xd8, 0x56, 0x32, 0xf5, 0x31, 0x0d, 0xc8, 0x71, 0x18, 0x71, 0x92, 0xa2, 0xc7, 0x00, 0x8b,
+	0x37, 0xa6, 0x77, 0xaa, 0xe5, 0x2f, 0x47, 0x54, 0x30, 0xc3, 0x57, 0x5a, 0xb3, 0xe2, 0x53, 0x5a,
+	0x42, 0x2a, 0x7b, 0xa5, 0xb0, 0x84, 0xd4, 0x3b, 0x01, 0xa4, 0xf4, 0x3b, 0xf8, 0x79, 0x8e, 0x23,
+	0xb6, 0x00, 0x5e, 0x3c, 0xb9, 0x0c, 0x38, 0x7f, 0x48, 0xeb, 0xf5, 0xec, 0x7d, 0x03, 0x7b, 0x0a,
+	0xea, 0x79, 0xcc, 0xff, 0x3b, 0xda, 0x53, 0xd8, 0x52, 0x68, 0x27, 0xf4, 0x76, 0x30, 0x0f, 0xa1,
+	0x22, 0x33, 0x55, 0x87, 0xa8, 0xf9, 0x7a, 0xe5, 0xbd, 0x0d, 0xcd, 0x0b, 0x1c, 0x9c, 0xa5, 0x84,
+	0x11, 0xca, 0x35, 0x54, 0x13, 0x6c, 0x8e, 0x33, 0x0c, 0xf1, 0xe9, 0x1d, 0x40, 0xe3, 0x02, 0x07,
+	0xfd, 0xd1, 0x8d, 0x41, 0xbf, 0x94, 0xc1, 0x39, 0x8b, 0xe3, 0x48, 0x34, 0x12, 0x8a, 0x67, 0x44,
+	0xfb, 0xe4, 0x37, 0x7a, 0x0e, 0xbb, 0xfa, 0x32, 0x52, 0x71, 0x45, 0xc3, 0xa9, 0x44, 0xc9, 0xfa,
+	0xd5, 0xeb, 0x86, 0xa6, 0x0a, 0x17, 0xe9, 0xa3, 0xc9, 0xaa, 0x89, 0xa1, 0xef, 0x61, 0x4f, 0x1f,
+	0x97, 0x48, 0x32, 0x73, 0x40, 0x25, 0xd2, 0xc7, 0x66, 0x17, 0x28, 0xdc, 0xa0, 0xbf, 0xc3, 0x0a,
+	0x36, 0x86, 0xbe, 0x86, 0x1d, 0x8e, 0x83, 0x61, 0xa2, 0xb8, 0xc8, 0x01, 0xd5, 0x14, 0x7b, 0xcd,
+	0x9c, 0x62, 0x2b, 0x84, 0xf9, 0xdb, 0x7c, 0xc5, 0x22, 0x26, 0xe1, 0x96, 0x7a, 0xeb, 0x93, 0xe1,
+	0x88, 0x4c, 0xe3, 0xf4, 0x36, 0x93, 0xec, 0x81, 0xce, 0x38, 0x92, 0x09, 0xe8, 0x73, 0xc8, 0x0c,
+	0x43, 0x3c, 0xe5, 0x24, 0xbd, 0xc5, 0x38, 0xab, 0xeb, 0x84, 0xbe, 0x88, 0x47, 0xcf, 0x00, 0x89,
+	0x03, 0xe1, 0xd1, 0xd2, 0x79, 0xaa, 0xf2, 0x3c, 0xad, 0xe5, 0xf3, 0x98, 0x57, 0xeb, 0x37, 0xf9,
+	0xb2, 0x81, 0xa1, 0x9f, 0x60, 0x5f, 0xb3, 0x4d, 0x63, 0xbe, 0xca, 0x78, 0x4d, 0x02, 0xb6, 0x0b,
+	0x8c, 0xaf, 0x08, 0xdd, 0x7f, 0xc8, 0xd6, 0x99, 0x19, 0x1a, 0xc0, 0xb6, 0x06, 0x0f, 0x69, 0x0e,
+	0x0a, 0x12, 0x74, 0xbf, 0x00, 0x9a, 0xe9, 0xdd, 0x6f, 0xb0, 0xa5, 0x35, 0xd3, 0x9d, 0xe0, 0xef,
+	0x12, 0xd4, 0xbf, 0x15, 0xf1, 0x67, 0x69, 0x3c, 0x0d, 0x23, 0xb2, 0x56, 0x8c, 0x87, 0x50, 0x4e,
+	0xe2, 0x38, 0xca, 0xfa, 0x7e, 0xc3, 0xd8, 0x45, 0x08, 0xd8, 0x57, 0x5e, 0xf4, 0x74, 0xcd, 0xdf,
+	0x8c, 0x39, 0x59, 0xcd, 0x7d, 0xfe, 0xbf, 0xfe, 0xe7, 0x34, 0xcb, 0xde, 0x5f, 0x16, 0x94, 0x65,
+	0x35, 0x68, 0x1f, 0xaa, 0xb2, 0xb8, 0x61, 0x3e, 0x2b, 0x37, 0xe4, 0xfa, 0x64, 0x82, 0x0e, 0xe0,
+	0x81, 0x72, 0x25, 0xaa, 0x64, 0xdd, 0x51, 0xea, 0x33, 0x93, 0xae, 0x43, 0xd8, 0x52, 0x41, 0xd3,
+	0x39, 0x55, 0x53, 0xc1, 0x96, 0x51, 0x2a, 0xf5, 0x58, 0x1b, 0xd1, 0x7b, 0xb0, 0xc1, 0xe5, 0xbf,
+	0x5c, 0xf6, 0xe0, 0xb6, 0x0b, 0x7f, 0x79, 0x7e, 0x16, 0x81, 0xbe, 0x58, 0xe2, 0x71, 0xa3, 0x20,
+	0x17, 0x59, 0xf9, 0x8d, 0x83, 0xb6, 0x07, 0xd5, 0x91, 0x1e, 0xc8, 0x6e, 0x55, 0xd2, 0xb3, 0xb3,
+	0x66, 0x56, 0xfb, 0x79, 0xd0, 0x7d, 0x31, 0x5e, 0x6e, 0x56, 0x4e, 0x9d, 0x6a, 0xa5, 0xb9, 0x71,
+	0xd4, 0xfd, 0xb1, 0x2d, 0x0a, 0x78, 0x5f, 0x55, 0x30, 0x21, 0x2f, 0x7b, 0x8b, 0x65, 0x2f, 0xb9,
+	0x0c, 0x7a, 0xc9, 0xe8, 0x37, 0xab, 0xf6, 0x5d, 0x42, 0xa8, 0x3c, 0xdd, 0xa8, 0x22, 0x41, 0x3f,
+	0xf8, 0x27, 0x00, 0x00, 0xff, 0xff, 0x4f, 0x30, 0x62, 0x39, 0x26, 0x0c, 0x00, 0x00,
 }
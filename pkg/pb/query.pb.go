@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
@@ -26,19 +28,125 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-type QueryTicketsRequest struct {
-	// The Pool representing the set of Filters to be queried.
-	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+type TicketOrder_Field int32
+
+const (
+	// Tickets are returned in no particular order.
+	TicketOrder_UNORDERED TicketOrder_Field = 0
+	// Tickets are ordered by create_time, oldest first.
+	TicketOrder_CREATE_TIME TicketOrder_Field = 1
+	// Tickets are ordered by the search_fields.double_args value named by
+	// double_arg, lowest first.  Tickets without the value are returned last.
+	TicketOrder_DOUBLE_ARG TicketOrder_Field = 2
+)
+
+var TicketOrder_Field_name = map[int32]string{
+	0: "UNORDERED",
+	1: "CREATE_TIME",
+	2: "DOUBLE_ARG",
+}
+
+var TicketOrder_Field_value = map[string]int32{
+	"UNORDERED":   0,
+	"CREATE_TIME": 1,
+	"DOUBLE_ARG":  2,
+}
+
+func (x TicketOrder_Field) String() string {
+	return proto.EnumName(TicketOrder_Field_name, int32(x))
+}
+
+func (TicketOrder_Field) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{0, 0}
+}
+
+// TicketOrder is the order queried Tickets are returned in.
+type TicketOrder struct {
+	Field TicketOrder_Field `protobuf:"varint,1,opt,name=field,proto3,enum=openmatch.TicketOrder_Field" json:"field,omitempty"`
+	// The name of the double_arg to order by, when field is DOUBLE_ARG.
+	DoubleArg string `protobuf:"bytes,2,opt,name=double_arg,json=doubleArg,proto3" json:"double_arg,omitempty"`
+	// If true, the order is reversed, except that Tickets without the
+	// double_arg are still returned last.
+	Descending           bool     `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *TicketOrder) Reset()         { *m = TicketOrder{} }
+func (m *TicketOrder) String() string { return proto.CompactTextString(m) }
+func (*TicketOrder) ProtoMessage()    {}
+func (*TicketOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{0}
+}
+
+func (m *TicketOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TicketOrder.Unmarshal(m, b)
+}
+func (m *TicketOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TicketOrder.Marshal(b, m, deterministic)
+}
+func (m *TicketOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TicketOrder.Merge(m, src)
+}
+func (m *TicketOrder) XXX_Size() int {
+	return xxx_messageInfo_TicketOrder.Size(m)
+}
+func (m *TicketOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_TicketOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TicketOrder proto.InternalMessageInfo
+
+func (m *TicketOrder) GetField() TicketOrder_Field {
+	if m != nil {
+		return m.Field
+	}
+	return TicketOrder_UNORDERED
+}
+
+func (m *TicketOrder) GetDoubleArg() string {
+	if m != nil {
+		return m.DoubleArg
+	}
+	return ""
+}
+
+func (m *TicketOrder) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+type QueryTicketsRequest struct {
+	// The Pool representing the set of Filters to be queried.
+	Pool *Pool `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	// The maximum number of Tickets to return.  0 returns every Ticket which
+	// meets the Pool's filtering criteria.
+	MaxResults int32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	// The order Tickets are returned in.  max_results keeps the first Tickets in
+	// this order, so ordering by create_time returns the oldest Tickets.
+	Order *TicketOrder `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	// If true, max_results Tickets are sampled uniformly at random from the
+	// Tickets which meet the Pool's filtering criteria, and then put in order.
+	// Requires max_results.
+	Sample bool `protobuf:"varint,4,opt,name=sample,proto3" json:"sample,omitempty"`
+	// The staleness of cached Tickets which the query accepts.  If the query
+	// service's ticket cache was refreshed from state storage no longer than
+	// max_staleness ago, the query is served from the cache without refreshing
+	// it.  If unset, the cache is always refreshed first.
+	MaxStaleness         *duration.Duration `protobuf:"bytes,5,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
 func (m *QueryTicketsRequest) Reset()         { *m = QueryTicketsRequest{} }
 func (m *QueryTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryTicketsRequest) ProtoMessage()    {}
 func (*QueryTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ec7651f31a90698, []int{0}
+	return fileDescriptor_5ec7651f31a90698, []int{1}
 }
 
 func (m *QueryTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -66,19 +174,50 @@ func (m *QueryTicketsRequest) GetPool() *Pool {
 	return nil
 }
 
+func (m *QueryTicketsRequest) GetMaxResults() int32 {
+	if m != nil {
+		return m.MaxResults
+	}
+	return 0
+}
+
+func (m *QueryTicketsRequest) GetOrder() *TicketOrder {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *QueryTicketsRequest) GetSample() bool {
+	if m != nil {
+		return m.Sample
+	}
+	return false
+}
+
+func (m *QueryTicketsRequest) GetMaxStaleness() *duration.Duration {
+	if m != nil {
+		return m.MaxStaleness
+	}
+	return nil
+}
+
 type QueryTicketsResponse struct {
 	// Tickets that meet all the filtering criteria requested by the pool.
-	Tickets              []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Tickets []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// The time the ticket cache was last refreshed from state storage.  The
+	// Tickets reflect state storage as of this time.
+	SnapshotTime         *timestamp.Timestamp `protobuf:"bytes,2,opt,name=snapshot_time,json=snapshotTime,proto3" json:"snapshot_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *QueryTicketsResponse) Reset()         { *m = QueryTicketsResponse{} }
 func (m *QueryTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryTicketsResponse) ProtoMessage()    {}
 func (*QueryTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ec7651f31a90698, []int{1}
+	return fileDescriptor_5ec7651f31a90698, []int{2}
 }
 
 func (m *QueryTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -106,19 +245,35 @@ func (m *QueryTicketsResponse) GetTickets() []*Ticket {
 	return nil
 }
 
+func (m *QueryTicketsResponse) GetSnapshotTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SnapshotTime
+	}
+	return nil
+}
+
 type QueryTicketIdsRequest struct {
 	// The Pool representing the set of Filters to be queried.
-	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Pool *Pool `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	// The maximum number of TicketIDs to return, as in QueryTicketsRequest.
+	MaxResults int32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	// The order TicketIDs are returned in, as in QueryTicketsRequest.
+	Order *TicketOrder `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	// Whether TicketIDs are sampled at random, as in QueryTicketsRequest.
+	Sample bool `protobuf:"varint,4,opt,name=sample,proto3" json:"sample,omitempty"`
+	// The staleness of cached Tickets which the query accepts, as in
+	// QueryTicketsRequest.
+	MaxStaleness         *duration.Duration `protobuf:"bytes,5,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *QueryTicketIdsRequest) Reset()         { *m = QueryTicketIdsRequest{} }
 func (m *QueryTicketIdsRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryTicketIdsRequest) ProtoMessage()    {}
 func (*QueryTicketIdsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ec7651f31a90698, []int{2}
+	return fileDescriptor_5ec7651f31a90698, []int{3}
 }
 
 func (m *QueryTicketIdsRequest) XXX_Unmarshal(b []byte) error {
@@ -146,19 +301,50 @@ func (m *QueryTicketIdsRequest) GetPool() *Pool {
 	return nil
 }
 
+func (m *QueryTicketIdsRequest) GetMaxResults() int32 {
+	if m != nil {
+		return m.MaxResults
+	}
+	return 0
+}
+
+func (m *QueryTicketIdsRequest) GetOrder() *TicketOrder {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *QueryTicketIdsRequest) GetSample() bool {
+	if m != nil {
+		return m.Sample
+	}
+	return false
+}
+
+func (m *QueryTicketIdsRequest) GetMaxStaleness() *duration.Duration {
+	if m != nil {
+		return m.MaxStaleness
+	}
+	return nil
+}
+
 type QueryTicketIdsResponse struct {
 	// TicketIDs that meet all the filtering criteria requested by the pool.
-	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// The time the ticket cache was last refreshed from state storage, as in
+	// QueryTicketsResponse.
+	SnapshotTime         *timestamp.Timestamp `protobuf:"bytes,2,opt,name=snapshot_time,json=snapshotTime,proto3" json:"snapshot_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *QueryTicketIdsResponse) Reset()         { *m = QueryTicketIdsResponse{} }
 func (m *QueryTicketIdsResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryTicketIdsResponse) ProtoMessage()    {}
 func (*QueryTicketIdsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ec7651f31a90698, []int{3}
+	return fileDescriptor_5ec7651f31a90698, []int{4}
 }
 
 func (m *QueryTicketIdsResponse) XXX_Unmarshal(b []byte) error {
@@ -186,53 +372,775 @@ func (m *QueryTicketIdsResponse) GetIds() []string {
 	return nil
 }
 
+func (m *QueryTicketIdsResponse) GetSnapshotTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SnapshotTime
+	}
+	return nil
+}
+
+type QueryBackfillsRequest struct {
+	// The Pool representing the set of Filters to be queried.
+	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryBackfillsRequest) Reset()         { *m = QueryBackfillsRequest{} }
+func (m *QueryBackfillsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryBackfillsRequest) ProtoMessage()    {}
+func (*QueryBackfillsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{5}
+}
+
+func (m *QueryBackfillsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryBackfillsRequest.Unmarshal(m, b)
+}
+func (m *QueryBackfillsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryBackfillsRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryBackfillsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryBackfillsRequest.Merge(m, src)
+}
+func (m *QueryBackfillsRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryBackfillsRequest.Size(m)
+}
+func (m *QueryBackfillsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryBackfillsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryBackfillsRequest proto.InternalMessageInfo
+
+func (m *QueryBackfillsRequest) GetPool() *Pool {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+type QueryBackfillsResponse struct {
+	// Backfills that meet all the filtering criteria requested by the pool.
+	Backfills            []*Backfill `protobuf:"bytes,1,rep,name=backfills,proto3" json:"backfills,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *QueryBackfillsResponse) Reset()         { *m = QueryBackfillsResponse{} }
+func (m *QueryBackfillsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryBackfillsResponse) ProtoMessage()    {}
+func (*QueryBackfillsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{6}
+}
+
+func (m *QueryBackfillsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryBackfillsResponse.Unmarshal(m, b)
+}
+func (m *QueryBackfillsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryBackfillsResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryBackfillsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryBackfillsResponse.Merge(m, src)
+}
+func (m *QueryBackfillsResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryBackfillsResponse.Size(m)
+}
+func (m *QueryBackfillsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryBackfillsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryBackfillsResponse proto.InternalMessageInfo
+
+func (m *QueryBackfillsResponse) GetBackfills() []*Backfill {
+	if m != nil {
+		return m.Backfills
+	}
+	return nil
+}
+
+type ExplainTicketRequest struct {
+	// The id of the Ticket to explain.
+	TicketId string `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	// The MatchProfile whose Pools the Ticket is checked against.
+	Profile              *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExplainTicketRequest) Reset()         { *m = ExplainTicketRequest{} }
+func (m *ExplainTicketRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainTicketRequest) ProtoMessage()    {}
+func (*ExplainTicketRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{7}
+}
+
+func (m *ExplainTicketRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainTicketRequest.Unmarshal(m, b)
+}
+func (m *ExplainTicketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainTicketRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainTicketRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainTicketRequest.Merge(m, src)
+}
+func (m *ExplainTicketRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainTicketRequest.Size(m)
+}
+func (m *ExplainTicketRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainTicketRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainTicketRequest proto.InternalMessageInfo
+
+func (m *ExplainTicketRequest) GetTicketId() string {
+	if m != nil {
+		return m.TicketId
+	}
+	return ""
+}
+
+func (m *ExplainTicketRequest) GetProfile() *MatchProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+// PoolExplanation describes whether a Ticket falls into a Pool.
+type PoolExplanation struct {
+	// The name of the Pool.
+	PoolName string `protobuf:"bytes,1,opt,name=pool_name,json=poolName,proto3" json:"pool_name,omitempty"`
+	// Whether the Ticket meets all the filtering criteria of the Pool.
+	Included bool `protobuf:"varint,2,opt,name=included,proto3" json:"included,omitempty"`
+	// If the Ticket is not included, a description of the first filter which
+	// excludes it.
+	ExcludedBy           string   `protobuf:"bytes,3,opt,name=excluded_by,json=excludedBy,proto3" json:"excluded_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PoolExplanation) Reset()         { *m = PoolExplanation{} }
+func (m *PoolExplanation) String() string { return proto.CompactTextString(m) }
+func (*PoolExplanation) ProtoMessage()    {}
+func (*PoolExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{8}
+}
+
+func (m *PoolExplanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoolExplanation.Unmarshal(m, b)
+}
+func (m *PoolExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoolExplanation.Marshal(b, m, deterministic)
+}
+func (m *PoolExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolExplanation.Merge(m, src)
+}
+func (m *PoolExplanation) XXX_Size() int {
+	return xxx_messageInfo_PoolExplanation.Size(m)
+}
+func (m *PoolExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolExplanation proto.InternalMessageInfo
+
+func (m *PoolExplanation) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
+}
+
+func (m *PoolExplanation) GetIncluded() bool {
+	if m != nil {
+		return m.Included
+	}
+	return false
+}
+
+func (m *PoolExplanation) GetExcludedBy() string {
+	if m != nil {
+		return m.ExcludedBy
+	}
+	return ""
+}
+
+type ExplainTicketResponse struct {
+	// The Ticket, as currently stored.
+	Ticket *Ticket `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// One explanation for each Pool of the MatchProfile, in the same order.
+	Pools []*PoolExplanation `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+	// Whether the Ticket was returned in a match and is waiting to be assigned
+	// or released.  Tickets pending release are not returned by queries.
+	PendingRelease bool `protobuf:"varint,3,opt,name=pending_release,json=pendingRelease,proto3" json:"pending_release,omitempty"`
+	// If the Ticket is pending release, the time it automatically becomes
+	// active again.
+	PendingReleaseExpireTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=pending_release_expire_time,json=pendingReleaseExpireTime,proto3" json:"pending_release_expire_time,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}             `json:"-"`
+	XXX_unrecognized         []byte               `json:"-"`
+	XXX_sizecache            int32                `json:"-"`
+}
+
+func (m *ExplainTicketResponse) Reset()         { *m = ExplainTicketResponse{} }
+func (m *ExplainTicketResponse) String() string { return proto.CompactTextString(m) }
+func (*ExplainTicketResponse) ProtoMessage()    {}
+func (*ExplainTicketResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{9}
+}
+
+func (m *ExplainTicketResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainTicketResponse.Unmarshal(m, b)
+}
+func (m *ExplainTicketResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainTicketResponse.Marshal(b, m, deterministic)
+}
+func (m *ExplainTicketResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainTicketResponse.Merge(m, src)
+}
+func (m *ExplainTicketResponse) XXX_Size() int {
+	return xxx_messageInfo_ExplainTicketResponse.Size(m)
+}
+func (m *ExplainTicketResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainTicketResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainTicketResponse proto.InternalMessageInfo
+
+func (m *ExplainTicketResponse) GetTicket() *Ticket {
+	if m != nil {
+		return m.Ticket
+	}
+	return nil
+}
+
+func (m *ExplainTicketResponse) GetPools() []*PoolExplanation {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *ExplainTicketResponse) GetPendingRelease() bool {
+	if m != nil {
+		return m.PendingRelease
+	}
+	return false
+}
+
+func (m *ExplainTicketResponse) GetPendingReleaseExpireTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PendingReleaseExpireTime
+	}
+	return nil
+}
+
+// HistogramRequest asks for a histogram of the values of a double_arg.
+type HistogramRequest struct {
+	// The name of the search_fields.double_args value to count.
+	DoubleArg string `protobuf:"bytes,1,opt,name=double_arg,json=doubleArg,proto3" json:"double_arg,omitempty"`
+	// The boundaries between buckets, in ascending order.  Bucket i counts the
+	// values v with bounds[i-1] <= v < bounds[i], with the first bucket counting
+	// every value below bounds[0], and the last every value from the last bound
+	// up.
+	Bounds               []float64 `protobuf:"fixed64,2,rep,packed,name=bounds,proto3" json:"bounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *HistogramRequest) Reset()         { *m = HistogramRequest{} }
+func (m *HistogramRequest) String() string { return proto.CompactTextString(m) }
+func (*HistogramRequest) ProtoMessage()    {}
+func (*HistogramRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{10}
+}
+
+func (m *HistogramRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistogramRequest.Unmarshal(m, b)
+}
+func (m *HistogramRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistogramRequest.Marshal(b, m, deterministic)
+}
+func (m *HistogramRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistogramRequest.Merge(m, src)
+}
+func (m *HistogramRequest) XXX_Size() int {
+	return xxx_messageInfo_HistogramRequest.Size(m)
+}
+func (m *HistogramRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistogramRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistogramRequest proto.InternalMessageInfo
+
+func (m *HistogramRequest) GetDoubleArg() string {
+	if m != nil {
+		return m.DoubleArg
+	}
+	return ""
+}
+
+func (m *HistogramRequest) GetBounds() []float64 {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+// Histogram counts the values of a double_arg among the Tickets in a Pool.
+type Histogram struct {
+	// The name of the double_arg counted.
+	DoubleArg string `protobuf:"bytes,1,opt,name=double_arg,json=doubleArg,proto3" json:"double_arg,omitempty"`
+	// The boundaries between buckets, as requested.
+	Bounds []float64 `protobuf:"fixed64,2,rep,packed,name=bounds,proto3" json:"bounds,omitempty"`
+	// The number of Tickets in each bucket, one more than the number of bounds.
+	Counts []int32 `protobuf:"varint,3,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	// The number of Tickets without the double_arg.
+	Missing              int32    `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Histogram) Reset()         { *m = Histogram{} }
+func (m *Histogram) String() string { return proto.CompactTextString(m) }
+func (*Histogram) ProtoMessage()    {}
+func (*Histogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{11}
+}
+
+func (m *Histogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Histogram.Unmarshal(m, b)
+}
+func (m *Histogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Histogram.Marshal(b, m, deterministic)
+}
+func (m *Histogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Histogram.Merge(m, src)
+}
+func (m *Histogram) XXX_Size() int {
+	return xxx_messageInfo_Histogram.Size(m)
+}
+func (m *Histogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_Histogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Histogram proto.InternalMessageInfo
+
+func (m *Histogram) GetDoubleArg() string {
+	if m != nil {
+		return m.DoubleArg
+	}
+	return ""
+}
+
+func (m *Histogram) GetBounds() []float64 {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+func (m *Histogram) GetCounts() []int32 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func (m *Histogram) GetMissing() int32 {
+	if m != nil {
+		return m.Missing
+	}
+	return 0
+}
+
+type GetPoolStatsRequest struct {
+	// The Pools to compute statistics for.
+	Pools []*Pool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	// Histograms to compute for every Pool.
+	Histograms           []*HistogramRequest `protobuf:"bytes,2,rep,name=histograms,proto3" json:"histograms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetPoolStatsRequest) Reset()         { *m = GetPoolStatsRequest{} }
+func (m *GetPoolStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPoolStatsRequest) ProtoMessage()    {}
+func (*GetPoolStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{12}
+}
+
+func (m *GetPoolStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPoolStatsRequest.Unmarshal(m, b)
+}
+func (m *GetPoolStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPoolStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPoolStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPoolStatsRequest.Merge(m, src)
+}
+func (m *GetPoolStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPoolStatsRequest.Size(m)
+}
+func (m *GetPoolStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPoolStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPoolStatsRequest proto.InternalMessageInfo
+
+func (m *GetPoolStatsRequest) GetPools() []*Pool {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *GetPoolStatsRequest) GetHistograms() []*HistogramRequest {
+	if m != nil {
+		return m.Histograms
+	}
+	return nil
+}
+
+// PoolStats summarizes the Tickets which meet all the filtering criteria of a
+// Pool.
+type PoolStats struct {
+	// The name of the Pool.
+	PoolName string `protobuf:"bytes,1,opt,name=pool_name,json=poolName,proto3" json:"pool_name,omitempty"`
+	// The number of Tickets in the Pool.
+	TicketCount int32 `protobuf:"varint,2,opt,name=ticket_count,json=ticketCount,proto3" json:"ticket_count,omitempty"`
+	// The create_time of the oldest Ticket in the Pool, unset if the Pool is
+	// empty.
+	OldestCreateTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=oldest_create_time,json=oldestCreateTime,proto3" json:"oldest_create_time,omitempty"`
+	// One Histogram for each requested histogram, in the same order.
+	Histograms           []*Histogram `protobuf:"bytes,4,rep,name=histograms,proto3" json:"histograms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PoolStats) Reset()         { *m = PoolStats{} }
+func (m *PoolStats) String() string { return proto.CompactTextString(m) }
+func (*PoolStats) ProtoMessage()    {}
+func (*PoolStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{13}
+}
+
+func (m *PoolStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoolStats.Unmarshal(m, b)
+}
+func (m *PoolStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoolStats.Marshal(b, m, deterministic)
+}
+func (m *PoolStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolStats.Merge(m, src)
+}
+func (m *PoolStats) XXX_Size() int {
+	return xxx_messageInfo_PoolStats.Size(m)
+}
+func (m *PoolStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolStats proto.InternalMessageInfo
+
+func (m *PoolStats) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
+}
+
+func (m *PoolStats) GetTicketCount() int32 {
+	if m != nil {
+		return m.TicketCount
+	}
+	return 0
+}
+
+func (m *PoolStats) GetOldestCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OldestCreateTime
+	}
+	return nil
+}
+
+func (m *PoolStats) GetHistograms() []*Histogram {
+	if m != nil {
+		return m.Histograms
+	}
+	return nil
+}
+
+type GetPoolStatsResponse struct {
+	// One PoolStats for each Pool of the request, in the same order.
+	Pools                []*PoolStats `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetPoolStatsResponse) Reset()         { *m = GetPoolStatsResponse{} }
+func (m *GetPoolStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPoolStatsResponse) ProtoMessage()    {}
+func (*GetPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{14}
+}
+
+func (m *GetPoolStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPoolStatsResponse.Unmarshal(m, b)
+}
+func (m *GetPoolStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPoolStatsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPoolStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPoolStatsResponse.Merge(m, src)
+}
+func (m *GetPoolStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPoolStatsResponse.Size(m)
+}
+func (m *GetPoolStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPoolStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPoolStatsResponse proto.InternalMessageInfo
+
+func (m *GetPoolStatsResponse) GetPools() []*PoolStats {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+type WatchPoolRequest struct {
+	// The Pool representing the set of Filters to be watched.
+	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPoolRequest) Reset()         { *m = WatchPoolRequest{} }
+func (m *WatchPoolRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchPoolRequest) ProtoMessage()    {}
+func (*WatchPoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{15}
+}
+
+func (m *WatchPoolRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPoolRequest.Unmarshal(m, b)
+}
+func (m *WatchPoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPoolRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchPoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPoolRequest.Merge(m, src)
+}
+func (m *WatchPoolRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchPoolRequest.Size(m)
+}
+func (m *WatchPoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPoolRequest proto.InternalMessageInfo
+
+func (m *WatchPoolRequest) GetPool() *Pool {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+type WatchPoolResponse struct {
+	// Tickets which entered the Pool, or were updated while in it, replacing any
+	// earlier copy.  The first responses hold every Ticket in the Pool.
+	Tickets []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	// The ids of Tickets which left the Pool, because they were deleted, no
+	// longer meet its filtering criteria, or are pending release.
+	RemovedIds []string `protobuf:"bytes,2,rep,name=removed_ids,json=removedIds,proto3" json:"removed_ids,omitempty"`
+	// The time of the ticket cache refresh the changes were found in.
+	SnapshotTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=snapshot_time,json=snapshotTime,proto3" json:"snapshot_time,omitempty"`
+	// Whether this is the last response for snapshot_time.  The Tickets sent so
+	// far, less the removed ones, are then exactly the Tickets in the Pool as of
+	// snapshot_time.
+	Complete             bool     `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPoolResponse) Reset()         { *m = WatchPoolResponse{} }
+func (m *WatchPoolResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchPoolResponse) ProtoMessage()    {}
+func (*WatchPoolResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{16}
+}
+
+func (m *WatchPoolResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPoolResponse.Unmarshal(m, b)
+}
+func (m *WatchPoolResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPoolResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchPoolResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPoolResponse.Merge(m, src)
+}
+func (m *WatchPoolResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchPoolResponse.Size(m)
+}
+func (m *WatchPoolResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPoolResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPoolResponse proto.InternalMessageInfo
+
+func (m *WatchPoolResponse) GetTickets() []*Ticket {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+func (m *WatchPoolResponse) GetRemovedIds() []string {
+	if m != nil {
+		return m.RemovedIds
+	}
+	return nil
+}
+
+func (m *WatchPoolResponse) GetSnapshotTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SnapshotTime
+	}
+	return nil
+}
+
+func (m *WatchPoolResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("openmatch.TicketOrder_Field", TicketOrder_Field_name, TicketOrder_Field_value)
+	proto.RegisterType((*TicketOrder)(nil), "openmatch.TicketOrder")
 	proto.RegisterType((*QueryTicketsRequest)(nil), "openmatch.QueryTicketsRequest")
 	proto.RegisterType((*QueryTicketsResponse)(nil), "openmatch.QueryTicketsResponse")
 	proto.RegisterType((*QueryTicketIdsRequest)(nil), "openmatch.QueryTicketIdsRequest")
 	proto.RegisterType((*QueryTicketIdsResponse)(nil), "openmatch.QueryTicketIdsResponse")
+	proto.RegisterType((*QueryBackfillsRequest)(nil), "openmatch.QueryBackfillsRequest")
+	proto.RegisterType((*QueryBackfillsResponse)(nil), "openmatch.QueryBackfillsResponse")
+	proto.RegisterType((*ExplainTicketRequest)(nil), "openmatch.ExplainTicketRequest")
+	proto.RegisterType((*PoolExplanation)(nil), "openmatch.PoolExplanation")
+	proto.RegisterType((*ExplainTicketResponse)(nil), "openmatch.ExplainTicketResponse")
+	proto.RegisterType((*HistogramRequest)(nil), "openmatch.HistogramRequest")
+	proto.RegisterType((*Histogram)(nil), "openmatch.Histogram")
+	proto.RegisterType((*GetPoolStatsRequest)(nil), "openmatch.GetPoolStatsRequest")
+	proto.RegisterType((*PoolStats)(nil), "openmatch.PoolStats")
+	proto.RegisterType((*GetPoolStatsResponse)(nil), "openmatch.GetPoolStatsResponse")
+	proto.RegisterType((*WatchPoolRequest)(nil), "openmatch.WatchPoolRequest")
+	proto.RegisterType((*WatchPoolResponse)(nil), "openmatch.WatchPoolResponse")
 }
 
 func init() { proto.RegisterFile("api/query.proto", fileDescriptor_5ec7651f31a90698) }
 
 var fileDescriptor_5ec7651f31a90698 = []byte{
-	// 577 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xdf, 0x4e, 0x13, 0x4f,
-	0x14, 0xc7, 0xb3, 0x5b, 0x02, 0x61, 0xf8, 0xe5, 0x07, 0x8e, 0x42, 0x48, 0x63, 0x70, 0x58, 0x62,
-	0x02, 0xc5, 0xee, 0x94, 0xca, 0x55, 0xd5, 0x04, 0x04, 0x2e, 0x48, 0x8a, 0x7f, 0x8a, 0xf1, 0xc2,
-	0xbb, 0xe9, 0xec, 0x71, 0x3b, 0xd2, 0xce, 0x19, 0x66, 0x66, 0x41, 0x12, 0xaf, 0x8c, 0x4f, 0x20,
-	0x37, 0xc6, 0x47, 0xf0, 0x25, 0x7c, 0x08, 0x5f, 0xc1, 0xf8, 0x1c, 0x66, 0x77, 0x8b, 0x94, 0x7f,
-	0x26, 0x5e, 0xb5, 0x3b, 0xdf, 0xef, 0x39, 0xdf, 0xcf, 0x9c, 0x99, 0x21, 0xd3, 0xc2, 0x28, 0x7e,
-	0x98, 0x81, 0x3d, 0x89, 0x8d, 0x45, 0x8f, 0x74, 0x12, 0x0d, 0xe8, 0x81, 0xf0, 0xb2, 0x57, 0xa5,
-	0xb9, 0x36, 0x00, 0xe7, 0x44, 0x0a, 0xae, 0x94, 0xab, 0x77, 0x53, 0xc4, 0xb4, 0x0f, 0x3c, 0x97,
-	0x84, 0xd6, 0xe8, 0x85, 0x57, 0xa8, 0xcf, 0xd4, 0x07, 0xc5, 0x8f, 0xac, 0xa7, 0xa0, 0xeb, 0xee,
-	0x58, 0xa4, 0x29, 0x58, 0x8e, 0xa6, 0x70, 0x5c, 0x75, 0x47, 0x2d, 0x72, 0xfb, 0x65, 0x9e, 0xfc,
-	0x4a, 0xc9, 0x03, 0xf0, 0xae, 0x03, 0x87, 0x19, 0x38, 0x4f, 0x97, 0xc8, 0x98, 0x41, 0xec, 0xcf,
-	0x07, 0x2c, 0x58, 0x9e, 0x6a, 0x4e, 0xc7, 0x7f, 0x80, 0xe2, 0x17, 0x88, 0xfd, 0x4e, 0x21, 0x46,
-	0x5b, 0xe4, 0xce, 0xc5, 0x5a, 0x67, 0x50, 0x3b, 0xa0, 0xab, 0x64, 0xc2, 0x97, 0x4b, 0xf3, 0x01,
-	0xab, 0x2c, 0x4f, 0x35, 0x6f, 0x8d, 0xd4, 0x97, 0xe6, 0xce, 0x99, 0x23, 0x7a, 0x4c, 0x66, 0x47,
-	0x9a, 0xec, 0x26, 0xff, 0x86, 0x50, 0x23, 0x73, 0x97, 0xab, 0x87, 0x10, 0x33, 0xa4, 0xa2, 0x92,
-	0x12, 0x60, 0xb2, 0x93, 0xff, 0x6d, 0x9e, 0x86, 0xe4, 0xbf, 0xc2, 0xbc, 0x0f, 0xf6, 0x48, 0x49,
-	0xa0, 0x1f, 0x86, 0xdf, 0x43, 0x7e, 0xba, 0x30, 0x92, 0x71, 0xcd, 0x50, 0xaa, 0xf7, 0x6e, 0xd4,
-	0xcb, 0xcc, 0x68, 0xe5, 0xe3, 0x8f, 0x9f, 0xa7, 0xe1, 0x52, 0xb4, 0xc0, 0x8f, 0xd6, 0xca, 0x03,
-	0x75, 0x65, 0x14, 0x1f, 0xee, 0xb6, 0x55, 0x2c, 0xb6, 0x82, 0x5a, 0x23, 0xa0, 0x9f, 0x02, 0xf2,
-	0xff, 0x45, 0x76, 0xca, 0xae, 0x0f, 0x38, 0x1f, 0x4a, 0x75, 0xf1, 0x2f, 0x8e, 0x21, 0xc4, 0x6a,
-	0x01, 0x71, 0x3f, 0x62, 0x37, 0x40, 0xa8, 0x64, 0x14, 0xe3, 0xe9, 0x97, 0xca, 0xe7, 0xcd, 0x5f,
-	0x21, 0xfd, 0x1e, 0x90, 0xd9, 0xbd, 0x3d, 0xd6, 0xc6, 0x54, 0x49, 0xb6, 0xbc, 0x2d, 0xbc, 0x60,
-	0x6d, 0x71, 0x02, 0x76, 0x25, 0xda, 0x25, 0xe4, 0xb9, 0x01, 0xcd, 0xf6, 0xf2, 0x50, 0x3a, 0xd7,
-	0xf3, 0xde, 0xb8, 0x16, 0xe7, 0x39, 0x47, 0xbd, 0x04, 0x49, 0xe0, 0xa8, 0xba, 0x74, 0xfe, 0x5d,
-	0x4f, 0x94, 0x93, 0x99, 0x73, 0x1b, 0xe5, 0x35, 0x4d, 0x2d, 0x66, 0xc6, 0xc5, 0x12, 0x07, 0xb5,
-	0xd7, 0x84, 0x6e, 0x1a, 0x21, 0x7b, 0xc0, 0x9a, 0x71, 0x83, 0xb5, 0x95, 0x84, 0xfc, 0xa4, 0x36,
-	0xce, 0x5a, 0xa6, 0xca, 0xf7, 0xb2, 0x6e, 0xee, 0xe4, 0x65, 0xe9, 0x5b, 0xb4, 0xa9, 0x18, 0x80,
-	0x1b, 0x09, 0xe3, 0xdd, 0x3e, 0x76, 0xf9, 0x40, 0x38, 0x0f, 0x96, 0xb7, 0x77, 0xb7, 0x76, 0x9e,
-	0xed, 0xef, 0x34, 0x2b, 0x6b, 0x71, 0xa3, 0x16, 0x06, 0x61, 0x73, 0x46, 0x18, 0xd3, 0x57, 0xb2,
-	0xb8, 0xe1, 0xfc, 0x9d, 0x43, 0xdd, 0xba, 0xb2, 0xd2, 0x79, 0x44, 0x2a, 0xeb, 0x8d, 0x75, 0xba,
-	0x4e, 0x6a, 0x1d, 0xf0, 0x99, 0xd5, 0x90, 0xb0, 0xe3, 0x1e, 0x68, 0xe6, 0x7b, 0xc0, 0x2c, 0x38,
-	0xcc, 0xac, 0x04, 0x96, 0x20, 0x38, 0xa6, 0xd1, 0x33, 0x78, 0xaf, 0x9c, 0x8f, 0xe9, 0x38, 0x19,
-	0xfb, 0x1a, 0x06, 0x13, 0xf6, 0x09, 0x99, 0x3f, 0x1f, 0x06, 0xdb, 0x46, 0x99, 0x0d, 0x40, 0x97,
-	0x2f, 0x8a, 0x2e, 0x5e, 0x3f, 0x1a, 0xee, 0x94, 0x07, 0x9e, 0xa0, 0x74, 0xfc, 0x0d, 0xbb, 0x24,
-	0x8d, 0xec, 0xcb, 0x1c, 0xa4, 0xdc, 0x74, 0xbf, 0x85, 0x93, 0x79, 0xff, 0xa2, 0x7d, 0x77, 0xbc,
-	0x78, 0xa2, 0x0f, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x69, 0xc6, 0x96, 0x66, 0x20, 0x04, 0x00,
+	// 1409 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcb, 0x6e, 0xdb, 0x46,
+	0x17, 0xfe, 0x29, 0xf9, 0xa6, 0x23, 0x5f, 0x94, 0x89, 0xe3, 0x5f, 0x55, 0x5c, 0x9b, 0x66, 0x10,
+	0xc4, 0x71, 0x62, 0xd1, 0x56, 0x0d, 0x04, 0x70, 0x7a, 0x89, 0x2f, 0x6a, 0x62, 0xd4, 0x8e, 0x53,
+	0xda, 0x69, 0xd1, 0x6e, 0x04, 0x8a, 0x1c, 0x4b, 0xac, 0x49, 0x0e, 0xc3, 0x19, 0x3a, 0x32, 0x50,
+	0xa0, 0x40, 0x91, 0x45, 0xd7, 0xed, 0xa6, 0xe8, 0x23, 0xf4, 0x0d, 0xba, 0x69, 0x1f, 0xa0, 0xcb,
+	0x3e, 0x41, 0xd1, 0xa2, 0xcf, 0xd0, 0x65, 0x31, 0x17, 0xca, 0xd4, 0x25, 0xd7, 0xee, 0xba, 0xb1,
+	0x35, 0xe7, 0x9c, 0x99, 0x73, 0xbe, 0xef, 0xdc, 0x24, 0x98, 0xb1, 0x23, 0xcf, 0x7c, 0x92, 0xe0,
+	0xf8, 0xbc, 0x1a, 0xc5, 0x84, 0x11, 0x54, 0x20, 0x11, 0x0e, 0x03, 0x9b, 0x39, 0xed, 0x0a, 0xe2,
+	0xba, 0x00, 0x53, 0x6a, 0xb7, 0x30, 0x95, 0xea, 0xca, 0x7c, 0x8b, 0x90, 0x96, 0x8f, 0x4d, 0xae,
+	0xb2, 0xc3, 0x90, 0x30, 0x9b, 0x79, 0x24, 0x4c, 0xb5, 0x0b, 0x4a, 0x2b, 0x4e, 0xcd, 0xe4, 0xc4,
+	0x74, 0x93, 0x58, 0x18, 0x28, 0xfd, 0x62, 0xbf, 0x9e, 0x79, 0x01, 0xa6, 0xcc, 0x0e, 0x22, 0x65,
+	0x70, 0x5b, 0xfc, 0x73, 0x56, 0x5b, 0x38, 0x5c, 0xa5, 0x4f, 0xed, 0x56, 0x0b, 0xc7, 0x26, 0x89,
+	0x84, 0x8b, 0x41, 0x77, 0xc6, 0x4f, 0x1a, 0x14, 0x8f, 0x3d, 0xe7, 0x14, 0xb3, 0xc3, 0xd8, 0xc5,
+	0x31, 0xaa, 0xc1, 0xe8, 0x89, 0x87, 0x7d, 0xb7, 0xac, 0xe9, 0xda, 0xf2, 0x74, 0x6d, 0xbe, 0xda,
+	0xc5, 0x52, 0xcd, 0x98, 0x55, 0x3f, 0xe4, 0x36, 0x96, 0x34, 0x45, 0x6f, 0x03, 0xb8, 0x24, 0x69,
+	0xfa, 0xb8, 0x61, 0xc7, 0xad, 0x72, 0x4e, 0xd7, 0x96, 0x0b, 0x56, 0x41, 0x4a, 0xb6, 0xe2, 0x16,
+	0x5a, 0x00, 0x70, 0x31, 0x75, 0x70, 0xe8, 0x7a, 0x61, 0xab, 0x9c, 0xd7, 0xb5, 0xe5, 0x09, 0x2b,
+	0x23, 0x31, 0xee, 0xc0, 0xa8, 0x78, 0x0e, 0x4d, 0x41, 0xe1, 0xf1, 0xc3, 0x43, 0x6b, 0xb7, 0x6e,
+	0xd5, 0x77, 0x4b, 0xff, 0x43, 0x33, 0x50, 0xdc, 0xb1, 0xea, 0x5b, 0xc7, 0xf5, 0xc6, 0xf1, 0xde,
+	0x41, 0xbd, 0xa4, 0xa1, 0x69, 0x80, 0xdd, 0xc3, 0xc7, 0xdb, 0xfb, 0xf5, 0xc6, 0x96, 0x75, 0xbf,
+	0x94, 0x33, 0x7e, 0xd7, 0xe0, 0xf2, 0xc7, 0x9c, 0x77, 0x19, 0x19, 0xb5, 0xf0, 0x93, 0x04, 0x53,
+	0x86, 0xae, 0xc1, 0x48, 0x44, 0x88, 0x2f, 0x20, 0x14, 0x6b, 0x33, 0x19, 0x08, 0x8f, 0x08, 0xf1,
+	0x2d, 0xa1, 0x44, 0x8b, 0x50, 0x0c, 0xec, 0x4e, 0x23, 0xc6, 0x34, 0xf1, 0x19, 0x15, 0x51, 0x8f,
+	0x5a, 0x10, 0xd8, 0x1d, 0x4b, 0x4a, 0xd0, 0x6d, 0x18, 0x25, 0x1c, 0xab, 0x88, 0xb8, 0x58, 0x9b,
+	0x1b, 0xce, 0x84, 0x25, 0x8d, 0xd0, 0x1c, 0x8c, 0x51, 0x3b, 0x88, 0x7c, 0x5c, 0x1e, 0x11, 0x00,
+	0xd5, 0x09, 0xbd, 0x0f, 0x53, 0xdc, 0x0d, 0x65, 0xb6, 0x8f, 0x43, 0x4c, 0x69, 0x79, 0x54, 0xbc,
+	0xf6, 0x56, 0x55, 0xa6, 0xb1, 0x9a, 0xa6, 0xb1, 0xba, 0xab, 0xd2, 0x6c, 0x4d, 0x06, 0x76, 0xe7,
+	0x28, 0x35, 0x37, 0x9e, 0x69, 0x30, 0xdb, 0x8b, 0x91, 0x46, 0x24, 0xa4, 0x18, 0xdd, 0x82, 0x71,
+	0x26, 0x45, 0x65, 0x4d, 0xcf, 0x2f, 0x17, 0x6b, 0x97, 0x06, 0x02, 0xb4, 0x52, 0x0b, 0xf4, 0x01,
+	0x4c, 0xd1, 0xd0, 0x8e, 0x68, 0x9b, 0xb0, 0x06, 0xaf, 0x17, 0x01, 0xb7, 0x58, 0xab, 0x0c, 0x44,
+	0x71, 0x9c, 0x16, 0x93, 0x35, 0x99, 0x5e, 0xe0, 0x22, 0xe3, 0x0f, 0x0d, 0xae, 0x64, 0xc2, 0xd8,
+	0x73, 0xff, 0x93, 0x64, 0x9f, 0xc2, 0x5c, 0x3f, 0x48, 0xc5, 0x76, 0x09, 0xf2, 0x9e, 0x2b, 0x99,
+	0x2e, 0x58, 0xfc, 0xe3, 0xbf, 0xa7, 0xf4, 0x5d, 0xc5, 0xe8, 0xb6, 0xed, 0x9c, 0x9e, 0x78, 0xbe,
+	0xff, 0x5a, 0x8c, 0x1a, 0x1f, 0xa9, 0x50, 0x33, 0xb7, 0x55, 0xa8, 0xeb, 0x50, 0x68, 0xa6, 0x42,
+	0x55, 0x1a, 0x97, 0x33, 0x6f, 0xa4, 0x17, 0xac, 0x0b, 0x2b, 0xe3, 0x04, 0x66, 0xeb, 0x9d, 0xc8,
+	0xb7, 0xbd, 0x50, 0x15, 0x8e, 0x8a, 0xe4, 0x2a, 0x14, 0x64, 0x05, 0x35, 0x3c, 0x39, 0x10, 0x0a,
+	0xd6, 0x04, 0x53, 0xdc, 0xa0, 0x75, 0x18, 0x8f, 0x62, 0x72, 0xe2, 0xf9, 0x29, 0xf4, 0xff, 0x67,
+	0xbc, 0x1c, 0xf0, 0xbf, 0x8f, 0xa4, 0xda, 0x4a, 0xed, 0x8c, 0x53, 0x98, 0xe1, 0x10, 0x84, 0xaf,
+	0x50, 0x24, 0x80, 0xbb, 0xe0, 0x78, 0x1a, 0xa1, 0x1d, 0xe0, 0xd4, 0x05, 0x17, 0x3c, 0xb4, 0x03,
+	0x8c, 0x2a, 0x30, 0xe1, 0x85, 0x8e, 0x9f, 0xb8, 0xd8, 0x15, 0x3e, 0x26, 0xac, 0xee, 0x99, 0x97,
+	0x14, 0xee, 0xc8, 0xcf, 0x8d, 0xe6, 0xb9, 0xa8, 0x9b, 0x82, 0x05, 0xa9, 0x68, 0xfb, 0xdc, 0xf8,
+	0x5b, 0x83, 0x2b, 0x7d, 0xa8, 0x14, 0x43, 0x37, 0x61, 0x4c, 0xa2, 0x50, 0x14, 0x0f, 0xe9, 0x1c,
+	0x65, 0x80, 0xd6, 0x60, 0x94, 0x47, 0xc3, 0x4b, 0x36, 0x2f, 0xb2, 0xdb, 0x9b, 0x8c, 0x0c, 0x12,
+	0x4b, 0x1a, 0xa2, 0x1b, 0x30, 0x13, 0xc9, 0xc1, 0xd6, 0x88, 0xb1, 0x8f, 0x6d, 0x8a, 0xd5, 0xc8,
+	0x9b, 0x56, 0x62, 0x4b, 0x4a, 0xd1, 0x67, 0x70, 0xb5, 0xcf, 0xb0, 0x81, 0x3b, 0x91, 0x17, 0x63,
+	0x59, 0x4e, 0x23, 0x2f, 0x2d, 0xa7, 0x72, 0xef, 0x83, 0x75, 0x71, 0x59, 0x94, 0xd6, 0x1e, 0x94,
+	0x1e, 0x78, 0x94, 0x91, 0x56, 0x6c, 0x07, 0x69, 0x2e, 0x7b, 0x87, 0xb4, 0xd6, 0x3f, 0xa4, 0xe7,
+	0x60, 0xac, 0x49, 0x92, 0xd0, 0x95, 0x48, 0x35, 0x4b, 0x9d, 0x0c, 0x06, 0x85, 0xee, 0x53, 0x6f,
+	0xf8, 0x06, 0x97, 0x3b, 0x24, 0x09, 0x19, 0x2d, 0xe7, 0xf5, 0xfc, 0xf2, 0xa8, 0xa5, 0x4e, 0xa8,
+	0x0c, 0xe3, 0x81, 0x47, 0x29, 0xdf, 0x0a, 0x23, 0x62, 0x22, 0xa4, 0x47, 0xe3, 0x1c, 0x2e, 0xdf,
+	0xc7, 0x8c, 0x33, 0x7c, 0xc4, 0xec, 0x8b, 0xc1, 0x7e, 0x3d, 0xcd, 0x86, 0x2c, 0xeb, 0x81, 0xd6,
+	0x50, 0x29, 0xb8, 0x0b, 0xd0, 0x4e, 0x63, 0x4e, 0x33, 0x77, 0x35, 0x63, 0xdb, 0xcf, 0x8d, 0x95,
+	0x31, 0x37, 0x7e, 0xd5, 0xa0, 0xd0, 0x75, 0xfc, 0xe2, 0xf2, 0x5c, 0x82, 0x49, 0xd5, 0x1e, 0x02,
+	0x90, 0x1a, 0x6b, 0x45, 0x29, 0xdb, 0xe1, 0x22, 0xf4, 0x00, 0x10, 0xf1, 0x5d, 0x4c, 0x59, 0xc3,
+	0x89, 0xb1, 0xcd, 0x54, 0x6e, 0xf3, 0x2f, 0xcd, 0x6d, 0x49, 0xde, 0xda, 0x11, 0x97, 0xb8, 0x18,
+	0x6d, 0xf4, 0x80, 0x1a, 0x11, 0xa0, 0x66, 0x87, 0x82, 0xca, 0xa2, 0xd9, 0x86, 0xd9, 0x5e, 0x22,
+	0x55, 0x0b, 0xac, 0xf4, 0x32, 0x39, 0xdb, 0xc7, 0xa4, 0x34, 0x96, 0x26, 0xc6, 0x1d, 0x28, 0x7d,
+	0x2a, 0xda, 0x99, 0x53, 0xfc, 0x3a, 0x33, 0xea, 0x67, 0x0d, 0x2e, 0x65, 0x6e, 0xbe, 0xc9, 0xe2,
+	0x5a, 0x84, 0x62, 0x8c, 0x03, 0x72, 0x86, 0xdd, 0x86, 0xa7, 0xea, 0xaa, 0x60, 0x81, 0x12, 0xed,
+	0x0d, 0x1b, 0xc3, 0xf9, 0xd7, 0x1b, 0xc3, 0x7c, 0xc6, 0x38, 0x84, 0x6f, 0x0f, 0x96, 0x6e, 0x93,
+	0xee, 0xb9, 0xf6, 0xcd, 0x18, 0x4c, 0x8a, 0x29, 0x7b, 0x84, 0xe3, 0x33, 0xcf, 0xc1, 0xe8, 0x4b,
+	0x75, 0x56, 0xcb, 0x18, 0x2d, 0x64, 0x42, 0x1f, 0xf2, 0x4d, 0xa4, 0xb2, 0xf8, 0x5c, 0xbd, 0x24,
+	0xc3, 0xb8, 0xf9, 0xf5, 0x6f, 0x7f, 0x7e, 0x97, 0xbb, 0x66, 0x2c, 0x98, 0x67, 0xeb, 0xf2, 0x3b,
+	0x24, 0x95, 0xae, 0x4c, 0xc5, 0xc0, 0xa6, 0x10, 0x6e, 0x6a, 0x2b, 0x6b, 0x1a, 0x7a, 0xa6, 0xc1,
+	0x74, 0xef, 0x7e, 0x42, 0xfa, 0x70, 0x07, 0x17, 0xfb, 0xb9, 0xb2, 0xf4, 0x02, 0x0b, 0x15, 0xc4,
+	0x2d, 0x11, 0xc4, 0x75, 0x43, 0x7f, 0x4e, 0x10, 0x9e, 0x3b, 0x3c, 0x8c, 0xee, 0xee, 0x19, 0x0c,
+	0xa3, 0x7f, 0xa9, 0x0d, 0x86, 0x31, 0xb0, 0xb8, 0x5e, 0x10, 0x46, 0x77, 0x53, 0x65, 0xc3, 0xf8,
+	0x0a, 0xa6, 0x7a, 0xc6, 0x3b, 0xca, 0x92, 0x3d, 0x6c, 0x9d, 0x55, 0xf4, 0xe7, 0x1b, 0xbc, 0x22,
+	0x13, 0x74, 0x13, 0xcb, 0x7b, 0x9b, 0xda, 0x0a, 0xea, 0xc0, 0x64, 0xb6, 0xb7, 0x7a, 0x8a, 0x61,
+	0xc8, 0xf4, 0xea, 0x29, 0x86, 0x61, 0x4d, 0x69, 0xdc, 0x10, 0xde, 0x97, 0x8c, 0xf9, 0x01, 0xef,
+	0xa2, 0x11, 0x37, 0x29, 0xb7, 0xe6, 0x9e, 0x9f, 0x40, 0xa1, 0xdb, 0x57, 0x28, 0x3b, 0xd9, 0xfa,
+	0xfb, 0xb4, 0x32, 0x3f, 0x5c, 0xf9, 0x8a, 0x0e, 0x9f, 0xf2, 0x1b, 0x82, 0xed, 0xed, 0xef, 0xf3,
+	0xdf, 0x6e, 0xfd, 0x95, 0x43, 0xbf, 0x68, 0x70, 0xe5, 0xe0, 0x40, 0xdf, 0x27, 0x2d, 0xcf, 0xd1,
+	0x97, 0x77, 0x6d, 0x66, 0xeb, 0xfb, 0xf6, 0x39, 0x8e, 0x6f, 0x1a, 0x7b, 0x00, 0x87, 0x11, 0x0e,
+	0x75, 0xb1, 0xf9, 0xd1, 0x5c, 0x9b, 0xb1, 0x88, 0x6e, 0x9a, 0x26, 0x77, 0xbf, 0x2a, 0xfd, 0xbb,
+	0xf8, 0xac, 0x72, 0xed, 0xe2, 0xbc, 0xea, 0x7a, 0xd4, 0x49, 0x28, 0xbd, 0x27, 0x3b, 0xb5, 0x15,
+	0x93, 0x24, 0xa2, 0x55, 0x87, 0x04, 0x2b, 0x9f, 0x00, 0xda, 0x8a, 0x6c, 0xa7, 0x8d, 0xf5, 0x5a,
+	0x75, 0x4d, 0xdf, 0xf7, 0x1c, 0xcc, 0xe7, 0xc6, 0xbd, 0xf4, 0xc9, 0x96, 0xc7, 0xda, 0x49, 0x93,
+	0x5b, 0x9a, 0xf2, 0xea, 0x09, 0x89, 0x5b, 0x76, 0x80, 0x69, 0xc6, 0x99, 0xd9, 0xf4, 0x49, 0xd3,
+	0x0c, 0x6c, 0xca, 0x70, 0x6c, 0xee, 0xef, 0xed, 0xd4, 0x1f, 0x1e, 0xd5, 0x6b, 0xf9, 0xf5, 0xea,
+	0xda, 0x4a, 0x4e, 0xcb, 0xd5, 0x4a, 0x76, 0x14, 0xf9, 0x9e, 0x23, 0xf6, 0xb6, 0xf9, 0x05, 0x25,
+	0xe1, 0xe6, 0x80, 0xc4, 0xba, 0x0b, 0xf9, 0x8d, 0xb5, 0x0d, 0xb4, 0x01, 0x2b, 0x16, 0x66, 0x49,
+	0x1c, 0x62, 0x57, 0x7f, 0xda, 0xc6, 0xa1, 0xce, 0xda, 0x58, 0x8f, 0x31, 0x25, 0x49, 0xec, 0x60,
+	0xdd, 0x25, 0x98, 0xea, 0x21, 0x61, 0x3a, 0xee, 0x78, 0x94, 0x55, 0xd1, 0x18, 0x8c, 0xfc, 0x90,
+	0xd3, 0xc6, 0xe3, 0xf7, 0xa0, 0x7c, 0x41, 0x86, 0xbe, 0x4b, 0x9c, 0x24, 0xc0, 0xa1, 0xfc, 0xe1,
+	0x85, 0x96, 0x86, 0x53, 0x63, 0x52, 0x8f, 0x61, 0xd3, 0x25, 0x0e, 0x35, 0x3f, 0xd7, 0xfb, 0x54,
+	0x19, 0x5c, 0xd1, 0x69, 0xcb, 0x8c, 0x9a, 0x3f, 0xe6, 0x0a, 0xfc, 0x7d, 0xf1, 0x7c, 0x73, 0x4c,
+	0xcc, 0xb8, 0x77, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0xf6, 0xcd, 0xa0, 0x0a, 0x88, 0x0e, 0x00,
 	0x00,
 }
 
@@ -252,12 +1160,37 @@ type QueryServiceClient interface {
 	//   - If the Pool contains no Filters, QueryTickets will return all Tickets in the state storage.
 	// QueryTickets pages the Tickets by `queryPageSize` and stream back responses.
 	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	//   - max_results, order and sample are applied before paging, so only the selected Tickets are sent.
+	//   - max_staleness lets the query be served from the ticket cache without refreshing it.
 	QueryTickets(ctx context.Context, in *QueryTicketsRequest, opts ...grpc.CallOption) (QueryService_QueryTicketsClient, error)
 	// QueryTicketIds gets the list of TicketIDs that meet all the filtering criteria requested by the pool.
 	//   - If the Pool contains no Filters, QueryTicketIds will return all TicketIDs in the state storage.
 	// QueryTicketIds pages the TicketIDs by `queryPageSize` and stream back responses.
 	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	//   - max_results, order and sample are applied before paging, as in QueryTickets.
 	QueryTicketIds(ctx context.Context, in *QueryTicketIdsRequest, opts ...grpc.CallOption) (QueryService_QueryTicketIdsClient, error)
+	// QueryBackfills gets a list of Backfills that match all Filters of the input Pool.
+	//   - If the Pool contains no Filters, QueryBackfills will return all Backfills in the state storage.
+	// QueryBackfills pages the Backfills by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	QueryBackfills(ctx context.Context, in *QueryBackfillsRequest, opts ...grpc.CallOption) (QueryService_QueryBackfillsClient, error)
+	// ExplainTicket reports which of the MatchProfile's Pools the Ticket falls
+	// into, which filter excludes it from each of the other Pools, and whether
+	// it is pending release.  Intended for debugging why a Ticket isn't being
+	// matched.
+	ExplainTicket(ctx context.Context, in *ExplainTicketRequest, opts ...grpc.CallOption) (*ExplainTicketResponse, error)
+	// GetPoolStats counts the Tickets in each Pool, without returning them.
+	//   - Tickets are counted from the same cache as QueryTickets, so the
+	//     statistics of every Pool are consistent with each other.
+	//   - Histograms count the values of double_args among each Pool's Tickets.
+	GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error)
+	// WatchPool streams the Tickets in a Pool, for match functions which keep
+	// running rather than querying on each FetchMatches call.
+	//   - The first responses are a snapshot of every Ticket in the Pool, followed by
+	//     the Tickets which enter and leave the Pool each time the ticket cache is refreshed.
+	//   - The cache is refreshed at least every `watchPoolInterval`, or sooner by other queries.
+	//   - Responses are paged by `queryPageSize`, as in QueryTickets.
+	WatchPool(ctx context.Context, in *WatchPoolRequest, opts ...grpc.CallOption) (QueryService_WatchPoolClient, error)
 }
 
 type queryServiceClient struct {
@@ -332,18 +1265,125 @@ func (x *queryServiceQueryTicketIdsClient) Recv() (*QueryTicketIdsResponse, erro
 	return m, nil
 }
 
+func (c *queryServiceClient) QueryBackfills(ctx context.Context, in *QueryBackfillsRequest, opts ...grpc.CallOption) (QueryService_QueryBackfillsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QueryService_serviceDesc.Streams[2], "/openmatch.QueryService/QueryBackfills", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceQueryBackfillsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryService_QueryBackfillsClient interface {
+	Recv() (*QueryBackfillsResponse, error)
+	grpc.ClientStream
+}
+
+type queryServiceQueryBackfillsClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceQueryBackfillsClient) Recv() (*QueryBackfillsResponse, error) {
+	m := new(QueryBackfillsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryServiceClient) ExplainTicket(ctx context.Context, in *ExplainTicketRequest, opts ...grpc.CallOption) (*ExplainTicketResponse, error) {
+	out := new(ExplainTicketResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.QueryService/ExplainTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetPoolStats(ctx context.Context, in *GetPoolStatsRequest, opts ...grpc.CallOption) (*GetPoolStatsResponse, error) {
+	out := new(GetPoolStatsResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.QueryService/GetPoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) WatchPool(ctx context.Context, in *WatchPoolRequest, opts ...grpc.CallOption) (QueryService_WatchPoolClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_QueryService_serviceDesc.Streams[3], "/openmatch.QueryService/WatchPool", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceWatchPoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryService_WatchPoolClient interface {
+	Recv() (*WatchPoolResponse, error)
+	grpc.ClientStream
+}
+
+type queryServiceWatchPoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceWatchPoolClient) Recv() (*WatchPoolResponse, error) {
+	m := new(WatchPoolResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // QueryServiceServer is the server API for QueryService service.
 type QueryServiceServer interface {
 	// QueryTickets gets a list of Tickets that match all Filters of the input Pool.
 	//   - If the Pool contains no Filters, QueryTickets will return all Tickets in the state storage.
 	// QueryTickets pages the Tickets by `queryPageSize` and stream back responses.
 	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	//   - max_results, order and sample are applied before paging, so only the selected Tickets are sent.
+	//   - max_staleness lets the query be served from the ticket cache without refreshing it.
 	QueryTickets(*QueryTicketsRequest, QueryService_QueryTicketsServer) error
 	// QueryTicketIds gets the list of TicketIDs that meet all the filtering criteria requested by the pool.
 	//   - If the Pool contains no Filters, QueryTicketIds will return all TicketIDs in the state storage.
 	// QueryTicketIds pages the TicketIDs by `queryPageSize` and stream back responses.
 	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	//   - max_results, order and sample are applied before paging, as in QueryTickets.
 	QueryTicketIds(*QueryTicketIdsRequest, QueryService_QueryTicketIdsServer) error
+	// QueryBackfills gets a list of Backfills that match all Filters of the input Pool.
+	//   - If the Pool contains no Filters, QueryBackfills will return all Backfills in the state storage.
+	// QueryBackfills pages the Backfills by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	QueryBackfills(*QueryBackfillsRequest, QueryService_QueryBackfillsServer) error
+	// ExplainTicket reports which of the MatchProfile's Pools the Ticket falls
+	// into, which filter excludes it from each of the other Pools, and whether
+	// it is pending release.  Intended for debugging why a Ticket isn't being
+	// matched.
+	ExplainTicket(context.Context, *ExplainTicketRequest) (*ExplainTicketResponse, error)
+	// GetPoolStats counts the Tickets in each Pool, without returning them.
+	//   - Tickets are counted from the same cache as QueryTickets, so the
+	//     statistics of every Pool are consistent with each other.
+	//   - Histograms count the values of double_args among each Pool's Tickets.
+	GetPoolStats(context.Context, *GetPoolStatsRequest) (*GetPoolStatsResponse, error)
+	// WatchPool streams the Tickets in a Pool, for match functions which keep
+	// running rather than querying on each FetchMatches call.
+	//   - The first responses are a snapshot of every Ticket in the Pool, followed by
+	//     the Tickets which enter and leave the Pool each time the ticket cache is refreshed.
+	//   - The cache is refreshed at least every `watchPoolInterval`, or sooner by other queries.
+	//   - Responses are paged by `queryPageSize`, as in QueryTickets.
+	WatchPool(*WatchPoolRequest, QueryService_WatchPoolServer) error
 }
 
 // UnimplementedQueryServiceServer can be embedded to have forward compatible implementations.
@@ -356,6 +1396,18 @@ func (*UnimplementedQueryServiceServer) QueryTickets(req *QueryTicketsRequest, s
 func (*UnimplementedQueryServiceServer) QueryTicketIds(req *QueryTicketIdsRequest, srv QueryService_QueryTicketIdsServer) error {
 	return status.Errorf(codes.Unimplemented, "method QueryTicketIds not implemented")
 }
+func (*UnimplementedQueryServiceServer) QueryBackfills(req *QueryBackfillsRequest, srv QueryService_QueryBackfillsServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryBackfills not implemented")
+}
+func (*UnimplementedQueryServiceServer) ExplainTicket(ctx context.Context, req *ExplainTicketRequest) (*ExplainTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainTicket not implemented")
+}
+func (*UnimplementedQueryServiceServer) GetPoolStats(ctx context.Context, req *GetPoolStatsRequest) (*GetPoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolStats not implemented")
+}
+func (*UnimplementedQueryServiceServer) WatchPool(req *WatchPoolRequest, srv QueryService_WatchPoolServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPool not implemented")
+}
 
 func RegisterQueryServiceServer(s *grpc.Server, srv QueryServiceServer) {
 	s.RegisterService(&_QueryService_serviceDesc, srv)
@@ -403,10 +1455,97 @@ func (x *queryServiceQueryTicketIdsServer) Send(m *QueryTicketIdsResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _QueryService_QueryBackfills_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryBackfillsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).QueryBackfills(m, &queryServiceQueryBackfillsServer{stream})
+}
+
+type QueryService_QueryBackfillsServer interface {
+	Send(*QueryBackfillsResponse) error
+	grpc.ServerStream
+}
+
+type queryServiceQueryBackfillsServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceQueryBackfillsServer) Send(m *QueryBackfillsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QueryService_ExplainTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).ExplainTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.QueryService/ExplainTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).ExplainTicket(ctx, req.(*ExplainTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.QueryService/GetPoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetPoolStats(ctx, req.(*GetPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_WatchPool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPoolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).WatchPool(m, &queryServiceWatchPoolServer{stream})
+}
+
+type QueryService_WatchPoolServer interface {
+	Send(*WatchPoolResponse) error
+	grpc.ServerStream
+}
+
+type queryServiceWatchPoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceWatchPoolServer) Send(m *WatchPoolResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _QueryService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.QueryService",
 	HandlerType: (*QueryServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExplainTicket",
+			Handler:    _QueryService_ExplainTicket_Handler,
+		},
+		{
+			MethodName: "GetPoolStats",
+			Handler:    _QueryService_GetPoolStats_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "QueryTickets",
@@ -418,6 +1557,16 @@ var _QueryService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _QueryService_QueryTicketIds_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "QueryBackfills",
+			Handler:       _QueryService_QueryBackfills_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchPool",
+			Handler:       _QueryService_WatchPool_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/query.proto",
 }